@@ -49,8 +49,12 @@ func main() {
 	defer rdb.Close()
 	logger.Info("connected to Redis")
 
+	// Cancelled on SIGINT/SIGTERM; stops the background schedulers as well
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Setup router
-	router := handler.NewRouter(logger, db, rdb, cfg)
+	router := handler.NewRouter(ctx, logger, db, rdb, cfg)
 
 	// Start server
 	srv := &http.Server{
@@ -62,9 +66,6 @@ func main() {
 	}
 
 	// Graceful shutdown
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
-
 	go func() {
 		logger.Info("server starting", slog.String("addr", cfg.Addr()))
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -73,13 +74,14 @@ func main() {
 		}
 	}()
 
-	<-done
+	<-ctx.Done()
+	stop()
 	logger.Info("shutting down server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown failed", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
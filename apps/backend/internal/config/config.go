@@ -11,6 +11,7 @@ type Config struct {
 	// Server
 	Port        int    `envconfig:"PORT" default:"8080"`
 	Environment string `envconfig:"ENVIRONMENT" default:"development"`
+	PublicURL   string `envconfig:"PUBLIC_URL" default:"http://localhost:8080"`
 
 	// Database
 	DatabaseURL string `envconfig:"DATABASE_URL" required:"true"`
@@ -35,10 +36,36 @@ type Config struct {
 	S3SecretKey string `envconfig:"S3_SECRET_KEY"`
 	S3Bucket    string `envconfig:"S3_BUCKET" default:"tennisapp"`
 	S3PublicURL string `envconfig:"S3_PUBLIC_URL"`
+	// S3PrivateBucket holds documents and exports; it must not be publicly readable
+	S3PrivateBucket string `envconfig:"S3_PRIVATE_BUCKET" default:"tennisapp-private"`
 
 	// Firebase
 	FirebaseCredentials string `envconfig:"FIREBASE_CREDENTIALS"`
 
+	// Event lifecycle scheduler
+	EventSchedulerInterval time.Duration `envconfig:"EVENT_SCHEDULER_INTERVAL" default:"1m"`
+	EventCancelCutoff      time.Duration `envconfig:"EVENT_CANCEL_CUTOFF" default:"2h"`
+	EventDefaultDuration   time.Duration `envconfig:"EVENT_DEFAULT_DURATION" default:"2h"`
+	EventArchiveAfter      time.Duration `envconfig:"EVENT_ARCHIVE_AFTER" default:"720h"`
+
+	// Game reminders
+	ReminderInterval time.Duration `envconfig:"REMINDER_INTERVAL" default:"1m"`
+
+	// Paid memberships
+	DuesSchedulerInterval time.Duration `envconfig:"DUES_SCHEDULER_INTERVAL" default:"1h"`
+	PaymentProvider       string        `envconfig:"PAYMENT_PROVIDER" default:"fake"`
+	PaymentCallbackSecret string        `envconfig:"PAYMENT_CALLBACK_SECRET"`
+
+	// Web-admin dashboards and exports
+	DashboardCacheTTL    time.Duration `envconfig:"DASHBOARD_CACHE_TTL" default:"5m"`
+	ExportAsyncThreshold int           `envconfig:"EXPORT_ASYNC_THRESHOLD" default:"5000"`
+
+	// Community ladders (challenge deadlines)
+	LadderSchedulerInterval time.Duration `envconfig:"LADDER_SCHEDULER_INTERVAL" default:"15m"`
+
+	// Scheduled community posts
+	PostSchedulerInterval time.Duration `envconfig:"POST_SCHEDULER_INTERVAL" default:"1m"`
+
 	// Sentry
 	SentryDSN string `envconfig:"SENTRY_DSN"`
 
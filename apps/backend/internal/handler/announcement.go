@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// AnnouncementHandler handles community announcement endpoints
+type AnnouncementHandler struct {
+	announcementService *service.AnnouncementService
+}
+
+// NewAnnouncementHandler creates a new AnnouncementHandler
+func NewAnnouncementHandler(announcementService *service.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{announcementService: announcementService}
+}
+
+// Create handles POST /v1/communities/:id/announcements
+func (h *AnnouncementHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.CreateAnnouncementInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	announcement, err := h.announcementService.Create(r.Context(), userID, communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, announcement)
+}
+
+// List handles GET /v1/communities/:id/announcements
+func (h *AnnouncementHandler) List(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	announcements, pagination, err := h.announcementService.List(
+		r.Context(),
+		communityID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, announcements, *pagination)
+}
+
+// Get handles GET /v1/communities/:id/announcements/:announcementId
+func (h *AnnouncementHandler) Get(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	announcementID, err := parseUUIDParam(r, "announcementId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid announcement ID")
+		return
+	}
+
+	announcement, err := h.announcementService.Get(r.Context(), communityID, announcementID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, announcement)
+}
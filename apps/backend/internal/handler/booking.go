@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/handler/middleware"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// BookingHandler handles court booking endpoints
+type BookingHandler struct {
+	bookingService *service.BookingService
+}
+
+// NewBookingHandler creates a new BookingHandler
+func NewBookingHandler(bookingService *service.BookingService) *BookingHandler {
+	return &BookingHandler{bookingService: bookingService}
+}
+
+// Availability handles GET /v1/courts/:id/availability?date=YYYY-MM-DD
+func (h *BookingHandler) Availability(w http.ResponseWriter, r *http.Request) {
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "date is required")
+		return
+	}
+
+	availability, err := h.bookingService.Availability(r.Context(), courtID, date)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, availability)
+}
+
+// Create handles POST /v1/courts/:id/bookings
+func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	var input service.CreateBookingInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if input.StartsAt.IsZero() || input.EndsAt.IsZero() {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "starts_at and ends_at are required")
+		return
+	}
+
+	booking, err := h.bookingService.Create(r.Context(), userID, courtID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, booking)
+}
+
+// ListMy handles GET /v1/bookings/my
+func (h *BookingHandler) ListMy(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	bookings, err := h.bookingService.ListMy(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, bookings)
+}
+
+// Cancel handles POST /v1/bookings/:id/cancel
+func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	bookingID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid booking ID")
+		return
+	}
+
+	booking, err := h.bookingService.Cancel(r.Context(), userID, middleware.IsSuperadmin(r), bookingID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, booking)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// CalendarHandler handles calendar feed endpoints
+type CalendarHandler struct {
+	calendarService *service.CalendarService
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(calendarService *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// GetFeeds handles GET /v1/calendar/feeds
+func (h *CalendarHandler) GetFeeds(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	feeds, err := h.calendarService.GetFeeds(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, feeds)
+}
+
+// RotateToken handles POST /v1/calendar/feeds/rotate
+func (h *CalendarHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	feeds, err := h.calendarService.RotateToken(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, feeds)
+}
+
+// PersonalFeed handles GET /v1/calendar/{token}/personal.ics
+func (h *CalendarHandler) PersonalFeed(w http.ResponseWriter, r *http.Request) {
+	data, err := h.calendarService.PersonalFeed(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondICS(w, "personal.ics", data)
+}
+
+// CommunityFeed handles GET /v1/calendar/{token}/communities/{id}.ics
+func (h *CalendarHandler) CommunityFeed(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	data, err := h.calendarService.CommunityFeed(r.Context(), chi.URLParam(r, "token"), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondICS(w, "community-"+communityID.String()+".ics", data)
+}
+
+func respondICS(w http.ResponseWriter, filename string, data []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// CommentHandler handles post comment endpoints
+type CommentHandler struct {
+	commentService *service.CommentService
+}
+
+// NewCommentHandler creates a new CommentHandler
+func NewCommentHandler(commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// List handles GET /v1/posts/:id/comments
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	comments, pagination, err := h.commentService.List(
+		r.Context(),
+		userID,
+		postID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, comments, *pagination)
+}
+
+// Replies handles GET /v1/posts/:id/comments/:commentId/replies
+func (h *CommentHandler) Replies(w http.ResponseWriter, r *http.Request) {
+	userID, postID, commentID, ok := commentParams(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	replies, pagination, err := h.commentService.Replies(
+		r.Context(),
+		userID,
+		postID,
+		commentID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, replies, *pagination)
+}
+
+// Create handles POST /v1/posts/:id/comments
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	var input service.CommentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	comment, err := h.commentService.Create(r.Context(), userID, postID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, comment)
+}
+
+// Update handles PATCH /v1/posts/:id/comments/:commentId
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, postID, commentID, ok := commentParams(w, r)
+	if !ok {
+		return
+	}
+
+	var input service.CommentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	comment, err := h.commentService.Update(r.Context(), userID, postID, commentID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, comment)
+}
+
+// Delete handles DELETE /v1/posts/:id/comments/:commentId
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, postID, commentID, ok := commentParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.commentService.Delete(r.Context(), userID, postID, commentID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Комментарий удалён"})
+}
+
+// commentParams reads the caller, the post :id and the :commentId path parameters,
+// writing the error response on failure
+func commentParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	commentID, err := parseUUIDParam(r, "commentId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid comment ID")
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	return userID, postID, commentID, true
+}
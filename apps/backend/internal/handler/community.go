@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -103,6 +105,80 @@ func (h *CommunityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, community)
 }
 
+// Update handles PATCH /v1/communities/:id
+func (h *CommunityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.UpdateCommunityInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	community, err := h.communityService.Update(r.Context(), communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, community)
+}
+
+// UploadLogo handles POST /v1/communities/:id/logo
+func (h *CommunityHandler) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	h.uploadImage(w, r, "logo", h.communityService.UploadLogo)
+}
+
+// UploadBanner handles POST /v1/communities/:id/banner
+func (h *CommunityHandler) UploadBanner(w http.ResponseWriter, r *http.Request) {
+	h.uploadImage(w, r, "banner", h.communityService.UploadBanner)
+}
+
+// uploadImage reads the multipart file field named after kind and responds with {"<kind>_url": url}
+func (h *CommunityHandler) uploadImage(
+	w http.ResponseWriter,
+	r *http.Request,
+	kind string,
+	upload func(ctx context.Context, communityID uuid.UUID, fileData []byte) (string, error),
+) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	// Parse multipart form (max 6 MB to account for overhead)
+	if err := r.ParseMultipartForm(6 << 20); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Could not parse multipart form")
+		return
+	}
+
+	file, _, err := r.FormFile(kind)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "MISSING_FILE", kind+" file is required")
+		return
+	}
+	defer file.Close()
+
+	fileData, err := io.ReadAll(io.LimitReader(file, 6<<20))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "READ_ERROR", "Could not read file")
+		return
+	}
+
+	url, err := upload(r.Context(), communityID, fileData)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{kind + "_url": url})
+}
+
 // Join handles POST /v1/communities/:id/join
 func (h *CommunityHandler) Join(w http.ResponseWriter, r *http.Request) {
 	userID, err := getUserUUID(r)
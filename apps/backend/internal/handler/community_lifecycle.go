@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// CommunityLifecycleHandler handles ownership transfer and deactivation endpoints
+type CommunityLifecycleHandler struct {
+	lifecycleService *service.CommunityLifecycleService
+}
+
+// NewCommunityLifecycleHandler creates a new CommunityLifecycleHandler
+func NewCommunityLifecycleHandler(lifecycleService *service.CommunityLifecycleService) *CommunityLifecycleHandler {
+	return &CommunityLifecycleHandler{lifecycleService: lifecycleService}
+}
+
+// StartTransfer handles POST /v1/communities/:id/ownership-transfer
+func (h *CommunityLifecycleHandler) StartTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var body struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+	if body.UserID == uuid.Nil {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "user_id is required")
+		return
+	}
+
+	transfer, err := h.lifecycleService.StartTransfer(r.Context(), userID, communityID, body.UserID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, transfer)
+}
+
+// GetTransfer handles GET /v1/communities/:id/ownership-transfer
+func (h *CommunityLifecycleHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	transfer, err := h.lifecycleService.GetTransfer(r.Context(), userID, communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transfer)
+}
+
+// CancelTransfer handles DELETE /v1/communities/:id/ownership-transfer
+func (h *CommunityLifecycleHandler) CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.lifecycleService.CancelTransfer)
+}
+
+// AcceptTransfer handles POST /v1/communities/:id/ownership-transfer/accept
+func (h *CommunityLifecycleHandler) AcceptTransfer(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.lifecycleService.AcceptTransfer)
+}
+
+// DeclineTransfer handles POST /v1/communities/:id/ownership-transfer/decline
+func (h *CommunityLifecycleHandler) DeclineTransfer(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.lifecycleService.DeclineTransfer)
+}
+
+// Deactivate handles POST /v1/communities/:id/deactivate
+func (h *CommunityLifecycleHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+			return
+		}
+	}
+
+	if err := h.lifecycleService.Deactivate(r.Context(), userID, communityID, body.Reason); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// ListDeactivated handles GET /v1/superadmin/communities/deactivated
+func (h *CommunityLifecycleHandler) ListDeactivated(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	communities, pagination, err := h.lifecycleService.ListDeactivated(
+		r.Context(),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, communities, *pagination)
+}
+
+// Reactivate handles POST /v1/superadmin/communities/:id/reactivate
+func (h *CommunityLifecycleHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.lifecycleService.Reactivate)
+}
+
+// respond runs a user+community action and replies with {"status":"ok"}
+func (h *CommunityLifecycleHandler) respond(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, userID, communityID uuid.UUID) error) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	if err := action(r.Context(), userID, communityID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/handler/middleware"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// CourtHandler handles courts directory endpoints
+type CourtHandler struct {
+	courtService *service.CourtService
+}
+
+// NewCourtHandler creates a new CourtHandler
+func NewCourtHandler(courtService *service.CourtService) *CourtHandler {
+	return &CourtHandler{courtService: courtService}
+}
+
+// List handles GET /v1/courts?open_now=true|open_at=<RFC3339>&sort=name|rating
+func (h *CourtHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	input := service.ListCourtsInput{
+		District: q.Get("district"),
+		Surface:  q.Get("surface"),
+		MinPrice: parseQueryFloat(q.Get("min_price")),
+		MaxPrice: parseQueryFloat(q.Get("max_price")),
+		Query:    q.Get("q"),
+		SortBy:   q.Get("sort"),
+		Page:     queryInt(q.Get("page"), 1),
+		PerPage:  queryInt(q.Get("per_page"), 20),
+	}
+
+	if v := q.Get("indoor"); v != "" {
+		indoor, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "indoor must be true or false")
+			return
+		}
+		input.Indoor = &indoor
+	}
+
+	if v := q.Get("open_at"); v != "" {
+		openAt, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "open_at must be an RFC3339 timestamp")
+			return
+		}
+		input.OpenAt = &openAt
+	} else if v := q.Get("open_now"); v != "" {
+		openNow, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "open_now must be true or false")
+			return
+		}
+		if openNow {
+			now := time.Now()
+			input.OpenAt = &now
+		}
+	}
+
+	courts, pagination, err := h.courtService.List(r.Context(), input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, courts, *pagination)
+}
+
+// Map handles GET /v1/courts/map?min_lat=&max_lat=&min_lng=&max_lng=
+func (h *CourtHandler) Map(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	minLat := parseQueryFloat(q.Get("min_lat"))
+	maxLat := parseQueryFloat(q.Get("max_lat"))
+	minLng := parseQueryFloat(q.Get("min_lng"))
+	maxLng := parseQueryFloat(q.Get("max_lng"))
+	if minLat == nil || maxLat == nil || minLng == nil || maxLng == nil {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "min_lat, max_lat, min_lng and max_lng are required")
+		return
+	}
+
+	courts, err := h.courtService.Map(r.Context(), service.MapBounds{
+		MinLat: *minLat,
+		MaxLat: *maxLat,
+		MinLng: *minLng,
+		MaxLng: *maxLng,
+	})
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, courts)
+}
+
+// Nearby handles GET /v1/courts/nearby?lat=&lng=&radius_km=
+func (h *CourtHandler) Nearby(w http.ResponseWriter, r *http.Request) {
+	input, ok := parseNearbyInput(w, r)
+	if !ok {
+		return
+	}
+
+	courts, err := h.courtService.Nearby(r.Context(), input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, courts)
+}
+
+// GetByID handles GET /v1/courts/:id
+func (h *CourtHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	court, err := h.courtService.GetByID(r.Context(), userID, middleware.IsSuperadmin(r), courtID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, court)
+}
+
+// Create handles POST /v1/courts (community owner/admin) and POST /v1/superadmin/courts
+func (h *CourtHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	var input service.CreateCourtInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	court, err := h.courtService.Create(r.Context(), userID, middleware.IsSuperadmin(r), input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, court)
+}
+
+// ListForModeration handles GET /v1/superadmin/courts?status=pending
+func (h *CourtHandler) ListForModeration(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	courts, pagination, err := h.courtService.ListForModeration(
+		r.Context(),
+		q.Get("status"),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, courts, *pagination)
+}
+
+// Update handles PATCH /v1/superadmin/courts/:id
+func (h *CourtHandler) Update(w http.ResponseWriter, r *http.Request) {
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	var input service.UpdateCourtInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	court, err := h.courtService.Update(r.Context(), courtID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, court)
+}
+
+// Delete handles DELETE /v1/superadmin/courts/:id
+func (h *CourtHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	if err := h.courtService.Deactivate(r.Context(), courtID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// Moderate handles POST /v1/superadmin/courts/:id/moderate
+func (h *CourtHandler) Moderate(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	var body struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	court, err := h.courtService.Moderate(r.Context(), adminID, courtID, body.Action, body.Note)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, court)
+}
+
+// parseNearbyInput reads lat, lng, radius_km and limit query parameters.
+// It writes a 400 response and returns false when lat/lng are missing.
+func parseNearbyInput(w http.ResponseWriter, r *http.Request) (service.NearbyInput, bool) {
+	q := r.URL.Query()
+
+	lat := parseQueryFloat(q.Get("lat"))
+	lng := parseQueryFloat(q.Get("lng"))
+	if lat == nil || lng == nil {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "lat and lng are required")
+		return service.NearbyInput{}, false
+	}
+
+	input := service.NearbyInput{
+		Lat:   *lat,
+		Lng:   *lng,
+		Limit: queryInt(q.Get("limit"), 0),
+	}
+	if radius := parseQueryFloat(q.Get("radius_km")); radius != nil {
+		input.RadiusKm = *radius
+	}
+
+	return input, true
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// DashboardHandler handles web-admin dashboard endpoints
+type DashboardHandler struct {
+	dashboardService *service.DashboardService
+}
+
+// NewDashboardHandler creates a new DashboardHandler
+func NewDashboardHandler(dashboardService *service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{dashboardService: dashboardService}
+}
+
+// CommunityDashboard handles GET /v1/admin/communities/:id/dashboard
+func (h *DashboardHandler) CommunityDashboard(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	dashboard, err := h.dashboardService.GetCommunityDashboard(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dashboard)
+}
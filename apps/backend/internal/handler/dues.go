@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// DuesHandler handles membership plan, dues and payment endpoints
+type DuesHandler struct {
+	duesService *service.DuesService
+}
+
+// NewDuesHandler creates a new DuesHandler
+func NewDuesHandler(duesService *service.DuesService) *DuesHandler {
+	return &DuesHandler{duesService: duesService}
+}
+
+// ListPlans handles GET /v1/communities/:id/plans
+func (h *DuesHandler) ListPlans(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	plans, err := h.duesService.ListPlans(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, plans)
+}
+
+// CreatePlan handles POST /v1/communities/:id/plans
+func (h *DuesHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.CreatePlanInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	plan, err := h.duesService.CreatePlan(r.Context(), communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, plan)
+}
+
+// UpdatePlan handles PATCH /v1/communities/:id/plans/:planId
+func (h *DuesHandler) UpdatePlan(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	planID, err := parseUUIDParam(r, "planId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid plan ID")
+		return
+	}
+
+	var input service.UpdatePlanInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	plan, err := h.duesService.UpdatePlan(r.Context(), communityID, planID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, plan)
+}
+
+// GetMyMembership handles GET /v1/communities/:id/membership
+func (h *DuesHandler) GetMyMembership(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	membership, err := h.duesService.GetMyMembership(r.Context(), userID, communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, membership)
+}
+
+// Pay handles POST /v1/communities/:id/membership/pay
+func (h *DuesHandler) Pay(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var body struct {
+		PlanID uuid.UUID `json:"plan_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	payment, err := h.duesService.StartPayment(r.Context(), userID, communityID, body.PlanID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, payment)
+}
+
+// ListDues handles GET /v1/communities/:id/dues?user_id=
+func (h *DuesHandler) ListDues(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+
+	var userID *uuid.UUID
+	if s := q.Get("user_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+			return
+		}
+		userID = &id
+	}
+
+	dues, pagination, err := h.duesService.ListDues(
+		r.Context(),
+		communityID,
+		userID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, dues, *pagination)
+}
+
+// RecordPayment handles POST /v1/communities/:id/dues
+func (h *DuesHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.RecordPaymentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	dues, err := h.duesService.RecordPayment(r.Context(), adminID, communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dues)
+}
+
+// Callback handles POST /v1/payments/:provider/callback (called by the payment provider)
+func (h *DuesHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	if err := h.duesService.HandleCallback(r.Context(), chi.URLParam(r, "provider"), r); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
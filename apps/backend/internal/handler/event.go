@@ -221,6 +221,22 @@ func (h *EventHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, calendar)
 }
 
+// Nearby handles GET /v1/events/nearby?lat=&lng=&radius_km=
+func (h *EventHandler) Nearby(w http.ResponseWriter, r *http.Request) {
+	input, ok := parseNearbyInput(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := h.eventService.Nearby(r.Context(), input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, events)
+}
+
 // GetMyEvents handles GET /v1/events/my
 func (h *EventHandler) GetMyEvents(w http.ResponseWriter, r *http.Request) {
 	userID, err := getUserUUID(r)
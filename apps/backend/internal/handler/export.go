@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// ExportHandler handles community data export endpoints
+type ExportHandler struct {
+	exportService *service.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler
+func NewExportHandler(exportService *service.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// Export handles GET /v1/admin/communities/:id/export?dataset=&format=&async=
+// Small exports are streamed as a file; large ones return 202 with a background job.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	input := service.ExportInput{
+		Dataset: q.Get("dataset"),
+		Format:  q.Get("format"),
+		Async:   q.Get("async") == "true",
+	}
+
+	plan, err := h.exportService.Prepare(r.Context(), userID, communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	if plan.Job != nil {
+		respondJSON(w, http.StatusAccepted, plan.Job)
+		return
+	}
+
+	w.Header().Set("Content-Type", plan.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, plan.Filename))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so a failure midway can only be logged
+	if _, err := h.exportService.Stream(r.Context(), w, communityID, input.Dataset, plan.Format); err != nil {
+		slog.Error("community export stream failed", "community_id", communityID, "dataset", input.Dataset, "error", err)
+	}
+}
+
+// ListJobs handles GET /v1/admin/communities/:id/exports
+func (h *ExportHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	jobs, err := h.exportService.ListJobs(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, jobs)
+}
+
+// GetJob handles GET /v1/admin/communities/:id/exports/:exportId
+func (h *ExportHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	jobID, err := parseUUIDParam(r, "exportId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid export ID")
+		return
+	}
+
+	job, err := h.exportService.GetJob(r.Context(), communityID, jobID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, job)
+}
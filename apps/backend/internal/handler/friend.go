@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// FriendHandler handles friend endpoints
+type FriendHandler struct {
+	friendService *service.FriendService
+}
+
+// NewFriendHandler creates a new FriendHandler
+func NewFriendHandler(friendService *service.FriendService) *FriendHandler {
+	return &FriendHandler{friendService: friendService}
+}
+
+// List handles GET /v1/friends
+func (h *FriendHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	q := r.URL.Query()
+	friends, pagination, err := h.friendService.List(
+		r.Context(),
+		userID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, friends, *pagination)
+}
+
+// ListRequests handles GET /v1/friends/requests?direction=incoming|outgoing
+func (h *FriendHandler) ListRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	q := r.URL.Query()
+	requests, pagination, err := h.friendService.ListRequests(
+		r.Context(),
+		userID,
+		q.Get("direction"),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, requests, *pagination)
+}
+
+// Suggestions handles GET /v1/friends/suggestions
+func (h *FriendHandler) Suggestions(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	suggestions, err := h.friendService.Suggestions(r.Context(), userID, queryInt(r.URL.Query().Get("limit"), 20))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, suggestions)
+}
+
+// Check handles GET /v1/friends/check/:userId
+func (h *FriendHandler) Check(w http.ResponseWriter, r *http.Request) {
+	userID, targetID, ok := friendParams(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.friendService.Check(r.Context(), userID, targetID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, status)
+}
+
+// Add handles POST /v1/friends/:userId
+func (h *FriendHandler) Add(w http.ResponseWriter, r *http.Request) {
+	userID, targetID, ok := friendParams(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := h.friendService.Add(r.Context(), userID, targetID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	msg := "Заявка в друзья отправлена"
+	if status == service.FriendshipFriends {
+		msg = "Добавлен в друзья"
+	}
+	respondJSON(w, http.StatusCreated, map[string]string{"status": status, "message": msg})
+}
+
+// Accept handles POST /v1/friends/:userId/accept
+func (h *FriendHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, requesterID, ok := friendParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.friendService.Accept(r.Context(), userID, requesterID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": service.FriendshipFriends, "message": "Добавлен в друзья"})
+}
+
+// Decline handles POST /v1/friends/:userId/decline
+func (h *FriendHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	userID, requesterID, ok := friendParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.friendService.Decline(r.Context(), userID, requesterID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": service.FriendshipNone, "message": "Заявка отклонена"})
+}
+
+// Remove handles DELETE /v1/friends/:userId
+func (h *FriendHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	userID, targetID, ok := friendParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.friendService.Remove(r.Context(), userID, targetID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": service.FriendshipNone, "message": "Удалён из друзей"})
+}
+
+// friendParams reads the caller and the :userId path parameter, writing the error response on failure
+func friendParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	targetID, err := parseUUIDParam(r, "userId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, targetID, true
+}
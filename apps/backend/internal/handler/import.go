@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// maxImportUpload bounds the CSV upload, which comfortably fits the row limit
+const maxImportUpload = 5 << 20
+
+// ImportHandler handles member and match import endpoints
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// Preview handles POST /v1/admin/communities/:id/imports?kind=members|matches
+// The CSV is sent either as the "file" field of a multipart form or as the raw body.
+func (h *ImportHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportUpload)
+
+	var file io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportUpload); err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Could not parse multipart form")
+			return
+		}
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "MISSING_FILE", "file is required")
+			return
+		}
+		defer f.Close()
+		file = f
+	}
+
+	preview, err := h.importService.Preview(r.Context(), userID, communityID, r.URL.Query().Get("kind"), file)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, preview)
+}
+
+// List handles GET /v1/admin/communities/:id/imports
+func (h *ImportHandler) List(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	imports, err := h.importService.ListImports(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, imports)
+}
+
+// Get handles GET /v1/admin/communities/:id/imports/:importId
+func (h *ImportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	importID, err := parseUUIDParam(r, "importId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid import ID")
+		return
+	}
+
+	imp, err := h.importService.GetImport(r.Context(), communityID, importID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, imp)
+}
+
+// Commit handles POST /v1/admin/communities/:id/imports/:importId/commit
+func (h *ImportHandler) Commit(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	importID, err := parseUUIDParam(r, "importId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid import ID")
+		return
+	}
+
+	var body struct {
+		ReplayRatings bool `json:"replay_ratings"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+			return
+		}
+	}
+
+	imp, err := h.importService.Commit(r.Context(), userID, communityID, importID, body.ReplayRatings)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, imp)
+}
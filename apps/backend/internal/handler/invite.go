@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// InviteHandler handles community invite endpoints
+type InviteHandler struct {
+	inviteService *service.InviteService
+}
+
+// NewInviteHandler creates a new InviteHandler
+func NewInviteHandler(inviteService *service.InviteService) *InviteHandler {
+	return &InviteHandler{inviteService: inviteService}
+}
+
+// Create handles POST /v1/communities/:id/invites
+func (h *InviteHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.CreateInviteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	invite, err := h.inviteService.Create(r.Context(), userID, communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, invite)
+}
+
+// List handles GET /v1/communities/:id/invites
+func (h *InviteHandler) List(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	invites, err := h.inviteService.List(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, invites)
+}
+
+// Revoke handles DELETE /v1/communities/:id/invites/:inviteId
+func (h *InviteHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	inviteID, err := parseUUIDParam(r, "inviteId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid invite ID")
+		return
+	}
+
+	if err := h.inviteService.Revoke(r.Context(), communityID, inviteID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// ListUses handles GET /v1/communities/:id/invites/:inviteId/members
+func (h *InviteHandler) ListUses(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	inviteID, err := parseUUIDParam(r, "inviteId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid invite ID")
+		return
+	}
+
+	members, err := h.inviteService.ListUses(r.Context(), communityID, inviteID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, members)
+}
+
+// Preview handles GET /v1/invites/:code
+func (h *InviteHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	invite, err := h.inviteService.Preview(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, invite)
+}
+
+// Accept handles POST /v1/invites/:code/accept
+func (h *InviteHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	result, err := h.inviteService.Accept(r.Context(), userID, chi.URLParam(r, "code"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// LadderHandler handles community ladder endpoints
+type LadderHandler struct {
+	ladderService *service.LadderService
+}
+
+// NewLadderHandler creates a new LadderHandler
+func NewLadderHandler(ladderService *service.LadderService) *LadderHandler {
+	return &LadderHandler{ladderService: ladderService}
+}
+
+// Get handles GET /v1/communities/:id/ladder
+func (h *LadderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	ladder, err := h.ladderService.Get(r.Context(), userID, communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladder)
+}
+
+// Configure handles PUT /v1/communities/:id/ladder
+func (h *LadderHandler) Configure(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.LadderSettingsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	ladder, err := h.ladderService.Configure(r.Context(), communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladder)
+}
+
+// Join handles POST /v1/communities/:id/ladder/join
+func (h *LadderHandler) Join(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	position, err := h.ladderService.Join(r.Context(), userID, communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, position)
+}
+
+// Leave handles POST /v1/communities/:id/ladder/leave
+func (h *LadderHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	if err := h.ladderService.Leave(r.Context(), userID, communityID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Left the ladder"})
+}
+
+// History handles GET /v1/communities/:id/ladder/history
+func (h *LadderHandler) History(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	var userID *uuid.UUID
+	if raw := q.Get("user_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+			return
+		}
+		userID = &id
+	}
+
+	history, pagination, err := h.ladderService.History(
+		r.Context(),
+		communityID,
+		userID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, history, *pagination)
+}
+
+// ListChallenges handles GET /v1/communities/:id/ladder/challenges
+// (?mine=true limits the list to the caller's challenges)
+func (h *LadderHandler) ListChallenges(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	var player *uuid.UUID
+	if q.Get("mine") == "true" {
+		player = &userID
+	}
+
+	challenges, pagination, err := h.ladderService.ListChallenges(
+		r.Context(),
+		communityID,
+		player,
+		q.Get("status"),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, challenges, *pagination)
+}
+
+// Challenge handles POST /v1/communities/:id/ladder/challenges
+func (h *LadderHandler) Challenge(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input struct {
+		DefenderID uuid.UUID `json:"defender_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.DefenderID == uuid.Nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "defender_id is required")
+		return
+	}
+
+	challenge, err := h.ladderService.Challenge(r.Context(), userID, communityID, input.DefenderID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, challenge)
+}
+
+// Accept handles POST /v1/communities/:id/ladder/challenges/:challengeId/accept
+func (h *LadderHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.ladderService.Accept)
+}
+
+// Decline handles POST /v1/communities/:id/ladder/challenges/:challengeId/decline
+func (h *LadderHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.ladderService.Decline)
+}
+
+// Cancel handles POST /v1/communities/:id/ladder/challenges/:challengeId/cancel
+func (h *LadderHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, h.ladderService.Cancel)
+}
+
+// respond runs one of the challenge state transitions for the caller
+func (h *LadderHandler) respond(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, userID, communityID, challengeID uuid.UUID) (map[string]interface{}, error)) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	challengeID, err := parseUUIDParam(r, "challengeId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid challenge ID")
+		return
+	}
+
+	challenge, err := action(r.Context(), userID, communityID, challengeID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, challenge)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// RoleSuperadmin is the platform role that grants access to /superadmin endpoints
+const RoleSuperadmin = "superadmin"
+
+// RequireSuperadmin creates middleware that only lets platform superadmins through.
+// It must run after Auth, which puts the role from the access token into the context.
+func RequireSuperadmin() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if GetUserID(r.Context()) == "" {
+				respondUnauthorized(w)
+				return
+			}
+
+			if GetUserRole(r.Context()) != RoleSuperadmin {
+				respondJSON(w, http.StatusForbidden, "FORBIDDEN", "Superadmin access required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsSuperadmin reports whether the authenticated user is a platform superadmin
+func IsSuperadmin(r *http.Request) bool {
+	return GetUserRole(r.Context()) == RoleSuperadmin
+}
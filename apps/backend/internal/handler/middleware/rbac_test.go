@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireSuperadmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		role           string
+		expectedStatus int
+	}{
+		{"superadmin", "user-1", "superadmin", http.StatusOK},
+		{"player", "user-1", "player", http.StatusForbidden},
+		{"unauthenticated", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireSuperadmin()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := context.WithValue(context.Background(), userIDKey, tt.userID)
+			ctx = context.WithValue(ctx, userRoleKey, tt.role)
+			req := httptest.NewRequest("GET", "/v1/superadmin/courts", nil).WithContext(ctx)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
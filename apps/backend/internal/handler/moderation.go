@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// ModerationHandler handles community moderation endpoints
+type ModerationHandler struct {
+	moderationService *service.ModerationService
+}
+
+// NewModerationHandler creates a new ModerationHandler
+func NewModerationHandler(moderationService *service.ModerationService) *ModerationHandler {
+	return &ModerationHandler{moderationService: moderationService}
+}
+
+// Kick handles POST /v1/communities/:id/members/:userId/kick
+func (h *ModerationHandler) Kick(w http.ResponseWriter, r *http.Request) {
+	actorID, communityID, targetUserID, ok := parseModerationParams(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.moderationService.Kick(r.Context(), actorID, communityID, targetUserID, body.Reason); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// Ban handles POST /v1/communities/:id/members/:userId/ban
+func (h *ModerationHandler) Ban(w http.ResponseWriter, r *http.Request) {
+	actorID, communityID, targetUserID, ok := parseModerationParams(w, r)
+	if !ok {
+		return
+	}
+
+	var input service.BanInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.moderationService.Ban(r.Context(), actorID, communityID, targetUserID, input); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// Unban handles POST /v1/communities/:id/members/:userId/unban
+func (h *ModerationHandler) Unban(w http.ResponseWriter, r *http.Request) {
+	actorID, communityID, targetUserID, ok := parseModerationParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.moderationService.Unban(r.Context(), actorID, communityID, targetUserID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// History handles GET /v1/communities/:id/members/:userId/moderation
+func (h *ModerationHandler) History(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	targetUserID, err := parseUUIDParam(r, "userId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return
+	}
+
+	history, err := h.moderationService.History(r.Context(), communityID, targetUserID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
+
+// parseModerationParams reads the actor, community and target user of a moderation action
+func parseModerationParams(w http.ResponseWriter, r *http.Request) (actorID, communityID, targetUserID uuid.UUID, ok bool) {
+	actor, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	community, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	target, err := parseUUIDParam(r, "userId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return
+	}
+
+	return actor, community, target, true
+}
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+const (
+	// postMaxPhotos matches the number of photos the service accepts per post
+	postMaxPhotos = 10
+	// maxPostUpload bounds the multipart body: up to ten 6 MB photos plus overhead
+	maxPostUpload = (postMaxPhotos*6 + 1) << 20
+)
+
+// PostHandler handles post and feed endpoints
+type PostHandler struct {
+	postService *service.PostService
+}
+
+// NewPostHandler creates a new PostHandler
+func NewPostHandler(postService *service.PostService) *PostHandler {
+	return &PostHandler{postService: postService}
+}
+
+// Create handles POST /v1/posts
+// Accepts multipart/form-data with content, optional community_id and
+// scheduled_at (RFC 3339) and up to ten "photos" files, or a JSON body for
+// text-only posts.
+func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	var (
+		input       service.CreatePostInput
+		communityID string
+		scheduledAt string
+	)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		// Parse multipart form (files beyond 6 MB in total spill over to disk)
+		r.Body = http.MaxBytesReader(w, r.Body, maxPostUpload)
+		if err := r.ParseMultipartForm(6 << 20); err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Could not parse multipart form")
+			return
+		}
+		input.Content = r.FormValue("content")
+		communityID = r.FormValue("community_id")
+		scheduledAt = r.FormValue("scheduled_at")
+
+		photos := r.MultipartForm.File["photos"]
+		if len(photos) > postMaxPhotos {
+			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("At most %d photos are allowed", postMaxPhotos))
+			return
+		}
+		for _, fh := range photos {
+			file, err := fh.Open()
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "READ_ERROR", "Could not read file")
+				return
+			}
+			data, err := io.ReadAll(io.LimitReader(file, 6<<20))
+			file.Close()
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "READ_ERROR", "Could not read file")
+				return
+			}
+			input.Photos = append(input.Photos, data)
+		}
+	} else {
+		var req struct {
+			Content     string `json:"content"`
+			CommunityID string `json:"community_id"`
+			ScheduledAt string `json:"scheduled_at"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+			return
+		}
+		input.Content = req.Content
+		communityID = req.CommunityID
+		scheduledAt = req.ScheduledAt
+	}
+
+	if communityID != "" {
+		id, err := uuid.Parse(communityID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+			return
+		}
+		input.CommunityID = &id
+	}
+	if scheduledAt != "" {
+		at, err := time.Parse(time.RFC3339, scheduledAt)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "scheduled_at must be an RFC 3339 timestamp")
+			return
+		}
+		input.ScheduledAt = &at
+	}
+
+	post, err := h.postService.Create(r.Context(), userID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, post)
+}
+
+// Get handles GET /v1/posts/:id
+func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	post, err := h.postService.Get(r.Context(), userID, postID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, post)
+}
+
+// Delete handles DELETE /v1/posts/:id
+func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.postService.Delete(r.Context(), userID, postID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Пост удалён"})
+}
+
+// Like handles POST /v1/posts/:id/like
+func (h *PostHandler) Like(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.postService.Like(r.Context(), userID, postID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// Unlike handles DELETE /v1/posts/:id/like
+func (h *PostHandler) Unlike(w http.ResponseWriter, r *http.Request) {
+	userID, postID, ok := postParams(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.postService.Unlike(r.Context(), userID, postID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// Feed handles GET /v1/feed?cursor=&limit=
+func (h *PostHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	q := r.URL.Query()
+	posts, nextCursor, hasMore, err := h.postService.Feed(r.Context(), userID, q.Get("cursor"), queryInt(q.Get("limit"), 20))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"data":        posts,
+		"next_cursor": nextCursor,
+		"has_more":    hasMore,
+	})
+}
+
+// ListScheduled handles GET /v1/communities/:id/scheduled-posts
+func (h *PostHandler) ListScheduled(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	posts, pagination, err := h.postService.ListScheduled(
+		r.Context(),
+		communityID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, posts, *pagination)
+}
+
+// UpdateScheduled handles PATCH /v1/communities/:id/scheduled-posts/:postId
+func (h *PostHandler) UpdateScheduled(w http.ResponseWriter, r *http.Request) {
+	communityID, postID, ok := scheduledPostParams(w, r)
+	if !ok {
+		return
+	}
+
+	var input service.UpdateScheduledPostInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	post, err := h.postService.UpdateScheduled(r.Context(), communityID, postID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, post)
+}
+
+// CancelScheduled handles DELETE /v1/communities/:id/scheduled-posts/:postId
+func (h *PostHandler) CancelScheduled(w http.ResponseWriter, r *http.Request) {
+	communityID, postID, ok := scheduledPostParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.postService.CancelScheduled(r.Context(), communityID, postID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Публикация отменена"})
+}
+
+// scheduledPostParams reads the community :id and :postId path parameters, writing the error response on failure
+func scheduledPostParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	postID, err := parseUUIDParam(r, "postId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid post ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return communityID, postID, true
+}
+
+// postParams reads the caller and the :id path parameter, writing the error response on failure
+func postParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	postID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid post ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, postID, true
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// ReviewHandler handles court review endpoints
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler
+func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService}
+}
+
+// List handles GET /v1/courts/:id/reviews
+func (h *ReviewHandler) List(w http.ResponseWriter, r *http.Request) {
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	q := r.URL.Query()
+	reviews, pagination, err := h.reviewService.List(
+		r.Context(),
+		courtID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, reviews, *pagination)
+}
+
+// Upsert handles PUT /v1/courts/:id/reviews/me
+func (h *ReviewHandler) Upsert(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	courtID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid court ID")
+		return
+	}
+
+	var input service.ReviewInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	review, err := h.reviewService.Upsert(r.Context(), userID, courtID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, review)
+}
+
+// Delete handles DELETE /v1/courts/:id/reviews/:reviewId
+func (h *ReviewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	reviewID, err := parseUUIDParam(r, "reviewId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid review ID")
+		return
+	}
+
+	if err := h.reviewService.Delete(r.Context(), userID, reviewID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
+// ListForModeration handles GET /v1/superadmin/reviews?status=published|hidden
+func (h *ReviewHandler) ListForModeration(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	reviews, pagination, err := h.reviewService.ListForModeration(
+		r.Context(),
+		q.Get("status"),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, reviews, *pagination)
+}
+
+// Moderate handles POST /v1/superadmin/reviews/:id/moderate
+func (h *ReviewHandler) Moderate(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	reviewID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid review ID")
+		return
+	}
+
+	var body struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	review, err := h.reviewService.Moderate(r.Context(), adminID, reviewID, body.Action, body.Note)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, review)
+}
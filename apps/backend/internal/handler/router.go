@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -11,13 +12,16 @@ import (
 
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/config"
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/handler/middleware"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/payments"
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/validator"
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/ws"
 )
 
-func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg *config.Config) *chi.Mux {
+// NewRouter wires the services and handlers. Background schedulers run until
+// ctx is cancelled, so the server stops them on shutdown.
+func NewRouter(ctx context.Context, logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg *config.Config) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware chain
@@ -33,29 +37,86 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 	// Initialize services
 	queries := repository.New(db)
 	tokenService := service.NewTokenService(cfg.JWTSecret, cfg.JWTAccessTTL, cfg.JWTRefreshTTL, redis)
-	authService := service.NewAuthService(queries, redis, tokenService, cfg.Environment)
 	quizService := service.NewQuizService(queries)
 
 	// Storage service (optional — may not be configured)
 	storageService, err := service.NewStorageService(
-		cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3PublicURL,
+		cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3PrivateBucket, cfg.S3PublicURL,
 	)
 	if err != nil {
 		logger.Warn("storage service initialization failed, avatar uploads disabled", "error", err)
 	}
 
-	userService := service.NewUserService(queries, storageService)
-	communityService := service.NewCommunityService(queries)
-	eventService := service.NewEventService(queries)
-
 	// Notifications + Firebase (mock in development)
 	firebaseService := service.NewFirebaseService(logger, cfg)
 	notificationService := service.NewNotificationService(queries, logger, firebaseService)
+	communityNews := service.NewCommunityNews(queries, firebaseService)
+
+	authService := service.NewAuthService(queries, redis, tokenService, communityNews, cfg.Environment)
+	userService := service.NewUserService(queries, storageService, communityNews)
+	communityService := service.NewCommunityService(queries, storageService, communityNews)
+	bookingService := service.NewBookingService(queries)
+	eventService := service.NewEventService(queries, db, bookingService)
+
+	// WebSocket hub (chat rooms)
+	hub := ws.NewHub(redis)
+	go hub.Run()
 
 	// Core domain services
 	matchService := service.NewMatchService(queries, db, notificationService)
 	ratingService := service.NewRatingService(queries)
 	chatService := service.NewChatService(queries)
+	calendarService := service.NewCalendarService(queries, cfg.PublicURL)
+	courtService := service.NewCourtService(queries)
+	reviewService := service.NewReviewService(queries)
+	verificationService := service.NewVerificationService(queries, db, storageService, notificationService)
+
+	// Online dues payments (only the fake provider exists so far; disabled in production)
+	var paymentProvider payments.Provider
+	if cfg.PaymentProvider == "fake" && !cfg.IsProduction() {
+		paymentProvider = payments.NewFake(cfg.PaymentCallbackSecret, cfg.PublicURL)
+	} else {
+		logger.Warn("online dues payments disabled", "provider", cfg.PaymentProvider)
+	}
+	duesService := service.NewDuesService(queries, db, paymentProvider, notificationService, communityNews)
+	inviteService := service.NewInviteService(queries, db, communityNews, cfg.PublicURL)
+	moderationService := service.NewModerationService(queries, db, notificationService, hub, communityNews)
+	lifecycleService := service.NewCommunityLifecycleService(queries, db, notificationService)
+	dashboardService := service.NewDashboardService(queries, ratingService, redis, cfg.DashboardCacheTTL)
+	exportService := service.NewExportService(queries, storageService, notificationService, cfg.ExportAsyncThreshold)
+	importService := service.NewImportService(queries, db)
+	announcementService := service.NewAnnouncementService(queries, db, firebaseService)
+	go announcementService.Run(ctx)
+	teamService := service.NewTeamService(queries, db)
+	ladderService := service.NewLadderService(queries, db, notificationService)
+	friendService := service.NewFriendService(queries, db, notificationService)
+	postService := service.NewPostService(queries, db, storageService)
+	commentService := service.NewCommentService(queries, notificationService)
+
+	// Background event lifecycle transitions
+	eventScheduler := service.NewEventScheduler(queries, db, notificationService, service.EventSchedulerConfig{
+		Interval:        cfg.EventSchedulerInterval,
+		CancelCutoff:    cfg.EventCancelCutoff,
+		DefaultDuration: cfg.EventDefaultDuration,
+		ArchiveAfter:    cfg.EventArchiveAfter,
+	})
+	go eventScheduler.Run(ctx)
+
+	// Game reminders (24h / 1h before start)
+	reminderScheduler := service.NewReminderScheduler(queries, notificationService, cfg.ReminderInterval)
+	go reminderScheduler.Run(ctx)
+
+	// Membership dues reminders and expiry
+	duesScheduler := service.NewDuesScheduler(queries, notificationService, communityNews, cfg.DuesSchedulerInterval)
+	go duesScheduler.Run(ctx)
+
+	// Ladder challenge deadlines
+	ladderScheduler := service.NewLadderScheduler(queries, db, notificationService, cfg.LadderSchedulerInterval)
+	go ladderScheduler.Run(ctx)
+
+	// Scheduled community posts
+	postScheduler := service.NewPostScheduler(queries, db, firebaseService, cfg.PostSchedulerInterval)
+	go postScheduler.Run(ctx)
 
 	// Initialize validator
 	v := validator.New()
@@ -70,10 +131,26 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 	ratingHandler := NewRatingHandler(ratingService)
 	chatHandler := NewChatHandler(chatService)
 	notificationHandler := NewNotificationHandler(notificationService)
-
-	// WebSocket hub and handler (chat)
-	hub := ws.NewHub(redis)
-	go hub.Run()
+	calendarHandler := NewCalendarHandler(calendarService)
+	courtHandler := NewCourtHandler(courtService)
+	bookingHandler := NewBookingHandler(bookingService)
+	reviewHandler := NewReviewHandler(reviewService)
+	verificationHandler := NewVerificationHandler(verificationService)
+	duesHandler := NewDuesHandler(duesService)
+	inviteHandler := NewInviteHandler(inviteService)
+	moderationHandler := NewModerationHandler(moderationService)
+	lifecycleHandler := NewCommunityLifecycleHandler(lifecycleService)
+	dashboardHandler := NewDashboardHandler(dashboardService)
+	exportHandler := NewExportHandler(exportService)
+	importHandler := NewImportHandler(importService)
+	announcementHandler := NewAnnouncementHandler(announcementService)
+	teamHandler := NewTeamHandler(teamService)
+	ladderHandler := NewLadderHandler(ladderService)
+	friendHandler := NewFriendHandler(friendService)
+	postHandler := NewPostHandler(postService)
+	commentHandler := NewCommentHandler(commentService)
+
+	// WebSocket handler (chat)
 	wsHandler := ws.NewHandler(hub, chatService, tokenService, redis)
 
 	// API v1 routes
@@ -87,6 +164,13 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 			r.Post("/auth/otp/send", authHandler.SendOTP)
 			r.Post("/auth/otp/verify", authHandler.VerifyOTP)
 			r.Post("/auth/refresh", authHandler.RefreshToken)
+
+			// ICS calendar feeds (secret token in URL, no auth header)
+			r.Get("/calendar/{token}/personal.ics", calendarHandler.PersonalFeed)
+			r.Get("/calendar/{token}/communities/{id}.ics", calendarHandler.CommunityFeed)
+
+			// Payment provider callbacks (authenticated by the provider's signature)
+			r.Post("/payments/{provider}/callback", duesHandler.Callback)
 		})
 
 		// Protected routes (auth required)
@@ -108,11 +192,43 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 			r.Route("/users", func(r chi.Router) {
 				r.Get("/me", userHandler.GetMe)
 				r.Patch("/me", userHandler.UpdateMe)
+				r.Patch("/me/notifications", userHandler.UpdateNotifications)
 				r.Post("/me/avatar", userHandler.UploadAvatar)
+				r.Get("/me/location", userHandler.GetLocation)
+				r.Put("/me/location", userHandler.SetLocation)
+				r.Delete("/me/location", userHandler.DeleteLocation)
 				r.Get("/search", userHandler.SearchUsers)
+				r.Get("/nearby", userHandler.NearbyPlayers)
 				r.Get("/{id}", userHandler.GetUser)
 			})
 
+			// Friends
+			r.Route("/friends", func(r chi.Router) {
+				r.Get("/", friendHandler.List)
+				r.Get("/requests", friendHandler.ListRequests)
+				r.Get("/suggestions", friendHandler.Suggestions)
+				r.Get("/check/{userId}", friendHandler.Check)
+				r.Post("/{userId}", friendHandler.Add)
+				r.Post("/{userId}/accept", friendHandler.Accept)
+				r.Post("/{userId}/decline", friendHandler.Decline)
+				r.Delete("/{userId}", friendHandler.Remove)
+			})
+
+			// Posts & feed
+			r.Get("/feed", postHandler.Feed)
+			r.Route("/posts", func(r chi.Router) {
+				r.Post("/", postHandler.Create)
+				r.Get("/{id}", postHandler.Get)
+				r.Delete("/{id}", postHandler.Delete)
+				r.Post("/{id}/like", postHandler.Like)
+				r.Delete("/{id}/like", postHandler.Unlike)
+				r.Get("/{id}/comments", commentHandler.List)
+				r.Post("/{id}/comments", commentHandler.Create)
+				r.Patch("/{id}/comments/{commentId}", commentHandler.Update)
+				r.Delete("/{id}/comments/{commentId}", commentHandler.Delete)
+				r.Get("/{id}/comments/{commentId}/replies", commentHandler.Replies)
+			})
+
 			// Communities
 			r.Route("/communities", func(r chi.Router) {
 				r.Get("/", communityHandler.List)
@@ -127,26 +243,90 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 					// Members
 					r.Get("/members", communityHandler.ListMembers)
 
+					// Paid membership
+					r.Get("/plans", duesHandler.ListPlans)
+					r.Get("/membership", duesHandler.GetMyMembership)
+					r.Post("/membership/pay", duesHandler.Pay)
+
+					// Teams and interclub ties
+					r.Get("/teams", teamHandler.ListTeams)
+					r.Get("/ties", teamHandler.ListTies)
+
+					// Challenge ladder
+					r.Get("/ladder", ladderHandler.Get)
+					r.Post("/ladder/join", ladderHandler.Join)
+					r.Post("/ladder/leave", ladderHandler.Leave)
+					r.Get("/ladder/history", ladderHandler.History)
+					r.Get("/ladder/challenges", ladderHandler.ListChallenges)
+					r.Post("/ladder/challenges", ladderHandler.Challenge)
+					r.Post("/ladder/challenges/{challengeId}/accept", ladderHandler.Accept)
+					r.Post("/ladder/challenges/{challengeId}/decline", ladderHandler.Decline)
+					r.Post("/ladder/challenges/{challengeId}/cancel", ladderHandler.Cancel)
+
+					// Ownership transfer (sender or nominated member)
+					r.Get("/ownership-transfer", lifecycleHandler.GetTransfer)
+					r.Post("/ownership-transfer/accept", lifecycleHandler.AcceptTransfer)
+					r.Post("/ownership-transfer/decline", lifecycleHandler.DeclineTransfer)
+
 					// Admin routes (owner/admin only)
 					r.Group(func(r chi.Router) {
 						r.Use(middleware.RequireCommunityRole(queries, "owner", "admin"))
+						r.Patch("/", communityHandler.Update)
+						r.Post("/logo", communityHandler.UploadLogo)
+						r.Post("/banner", communityHandler.UploadBanner)
+						r.Get("/verification", verificationHandler.GetStatus)
 						r.Patch("/members/{userId}", communityHandler.UpdateMemberRole)
+						r.Post("/plans", duesHandler.CreatePlan)
+						r.Patch("/plans/{planId}", duesHandler.UpdatePlan)
+						r.Get("/dues", duesHandler.ListDues)
+						r.Post("/dues", duesHandler.RecordPayment)
+						r.Get("/invites", inviteHandler.List)
+						r.Post("/invites", inviteHandler.Create)
+						r.Delete("/invites/{inviteId}", inviteHandler.Revoke)
+						r.Get("/invites/{inviteId}/members", inviteHandler.ListUses)
+						r.Get("/members/{userId}/moderation", moderationHandler.History)
+						r.Get("/announcements", announcementHandler.List)
+						r.Post("/announcements", announcementHandler.Create)
+						r.Get("/announcements/{announcementId}", announcementHandler.Get)
+						r.Post("/teams", teamHandler.CreateTeam)
+						r.Post("/ties", teamHandler.CreateTie)
+						r.Put("/ladder", ladderHandler.Configure)
+						r.Get("/scheduled-posts", postHandler.ListScheduled)
+						r.Patch("/scheduled-posts/{postId}", postHandler.UpdateScheduled)
+						r.Delete("/scheduled-posts/{postId}", postHandler.CancelScheduled)
+					})
+
+					// Owner-only routes
+					r.Group(func(r chi.Router) {
+						r.Use(middleware.RequireCommunityRole(queries, "owner"))
+						r.Post("/verification", verificationHandler.Submit)
+						r.Post("/ownership-transfer", lifecycleHandler.StartTransfer)
+						r.Delete("/ownership-transfer", lifecycleHandler.CancelTransfer)
+						r.Post("/deactivate", lifecycleHandler.Deactivate)
 					})
 
 					// Moderator+ routes (owner/admin/moderator)
 					r.Group(func(r chi.Router) {
 						r.Use(middleware.RequireCommunityRole(queries, "owner", "admin", "moderator"))
 						r.Post("/members/{userId}/review", communityHandler.ReviewRequest)
+						r.Post("/members/{userId}/kick", moderationHandler.Kick)
+						r.Post("/members/{userId}/ban", moderationHandler.Ban)
+						r.Post("/members/{userId}/unban", moderationHandler.Unban)
 					})
 				})
 			})
 
+			// Community invites
+			r.Get("/invites/{code}", inviteHandler.Preview)
+			r.Post("/invites/{code}/accept", inviteHandler.Accept)
+
 			// Events
 			r.Route("/events", func(r chi.Router) {
 				r.Get("/", eventHandler.List)
 				r.Post("/", eventHandler.Create)
 				r.Get("/calendar", eventHandler.GetCalendar)
 				r.Get("/my", eventHandler.GetMyEvents)
+				r.Get("/nearby", eventHandler.Nearby)
 
 				r.Route("/{id}", func(r chi.Router) {
 					r.Get("/", eventHandler.GetByID)
@@ -154,9 +334,44 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 					r.Post("/leave", eventHandler.Leave)
 					r.Patch("/status", eventHandler.UpdateStatus)
 					r.Get("/participants", eventHandler.ListParticipants)
+					r.Get("/team-standings", teamHandler.EventStandings)
 				})
 			})
 
+			// Teams (captain or owner/admin of the team's community)
+			r.Route("/teams/{id}", func(r chi.Router) {
+				r.Get("/", teamHandler.GetTeam)
+				r.Patch("/", teamHandler.UpdateTeam)
+				r.Post("/members", teamHandler.AddMember)
+				r.Delete("/members/{userId}", teamHandler.RemoveMember)
+			})
+
+			// Interclub ties
+			r.Route("/ties/{id}", func(r chi.Router) {
+				r.Get("/", teamHandler.GetTie)
+				r.Put("/lineups/{teamId}", teamHandler.SubmitLineup)
+			})
+
+			// Courts directory
+			r.Route("/courts", func(r chi.Router) {
+				r.Get("/", courtHandler.List)
+				r.Post("/", courtHandler.Create)
+				r.Get("/map", courtHandler.Map)
+				r.Get("/nearby", courtHandler.Nearby)
+				r.Get("/{id}", courtHandler.GetByID)
+				r.Get("/{id}/availability", bookingHandler.Availability)
+				r.Post("/{id}/bookings", bookingHandler.Create)
+				r.Get("/{id}/reviews", reviewHandler.List)
+				r.Put("/{id}/reviews/me", reviewHandler.Upsert)
+				r.Delete("/{id}/reviews/{reviewId}", reviewHandler.Delete)
+			})
+
+			// Court bookings
+			r.Route("/bookings", func(r chi.Router) {
+				r.Get("/my", bookingHandler.ListMy)
+				r.Post("/{id}/cancel", bookingHandler.Cancel)
+			})
+
 			// Matches
 			r.Route("/matches", func(r chi.Router) {
 				r.Get("/my", matchHandler.ListMyMatches)
@@ -192,6 +407,10 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 				})
 			})
 
+			// Calendar feed URLs
+			r.Get("/calendar/feeds", calendarHandler.GetFeeds)
+			r.Post("/calendar/feeds/rotate", calendarHandler.RotateToken)
+
 			// Notifications
 			r.Route("/notifications", func(r chi.Router) {
 				r.Get("/", notificationHandler.List)
@@ -199,6 +418,43 @@ func NewRouter(logger *slog.Logger, db *pgxpool.Pool, redis *goredis.Client, cfg
 				r.Get("/unread-count", notificationHandler.GetUnreadCount)
 				r.Delete("/{id}", notificationHandler.Delete)
 			})
+
+			// Web-admin (community owners and admins)
+			r.Route("/admin/communities/{id}", func(r chi.Router) {
+				r.Use(middleware.RequireCommunityRole(queries, "owner", "admin"))
+				r.Get("/dashboard", dashboardHandler.CommunityDashboard)
+				r.Get("/export", exportHandler.Export)
+				r.Get("/exports", exportHandler.ListJobs)
+				r.Get("/exports/{exportId}", exportHandler.GetJob)
+				r.Get("/imports", importHandler.List)
+				r.Post("/imports", importHandler.Preview)
+				r.Get("/imports/{importId}", importHandler.Get)
+				r.Post("/imports/{importId}/commit", importHandler.Commit)
+			})
+
+			// Superadmin (platform role from the access token)
+			r.Route("/superadmin", func(r chi.Router) {
+				r.Use(middleware.RequireSuperadmin())
+
+				r.Route("/courts", func(r chi.Router) {
+					r.Get("/", courtHandler.ListForModeration)
+					r.Post("/", courtHandler.Create)
+					r.Patch("/{id}", courtHandler.Update)
+					r.Delete("/{id}", courtHandler.Delete)
+					r.Post("/{id}/moderate", courtHandler.Moderate)
+				})
+
+				r.Route("/reviews", func(r chi.Router) {
+					r.Get("/", reviewHandler.ListForModeration)
+					r.Post("/{id}/moderate", reviewHandler.Moderate)
+				})
+
+				r.Get("/verifications", verificationHandler.List)
+				r.Post("/verifications/{communityId}", verificationHandler.Review)
+
+				r.Get("/communities/deactivated", lifecycleHandler.ListDeactivated)
+				r.Post("/communities/{id}/reactivate", lifecycleHandler.Reactivate)
+			})
 		})
 	})
 
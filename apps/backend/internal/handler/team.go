@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// TeamHandler handles team and interclub tie endpoints
+type TeamHandler struct {
+	teamService *service.TeamService
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(teamService *service.TeamService) *TeamHandler {
+	return &TeamHandler{teamService: teamService}
+}
+
+// CreateTeam handles POST /v1/communities/:id/teams
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.CreateTeamInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(r.Context(), communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, team)
+}
+
+// ListTeams handles GET /v1/communities/:id/teams
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, teams)
+}
+
+// GetTeam handles GET /v1/teams/:id
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	teamID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	team, err := h.teamService.GetTeam(r.Context(), teamID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, team)
+}
+
+// UpdateTeam handles PATCH /v1/teams/:id
+func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	teamID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	var input service.UpdateTeamInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(r.Context(), userID, teamID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, team)
+}
+
+// AddMember handles POST /v1/teams/:id/members
+func (h *TeamHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	teamID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	var input struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.UserID == uuid.Nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "user_id is required")
+		return
+	}
+
+	if err := h.teamService.AddMember(r.Context(), userID, teamID, input.UserID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, map[string]string{"message": "Player added to team"})
+}
+
+// RemoveMember handles DELETE /v1/teams/:id/members/:userId
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	teamID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	memberID, err := parseUUIDParam(r, "userId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return
+	}
+
+	if err := h.teamService.RemoveMember(r.Context(), userID, teamID, memberID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Player removed from team"})
+}
+
+// CreateTie handles POST /v1/communities/:id/ties
+func (h *TeamHandler) CreateTie(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var input service.CreateTieInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	tie, err := h.teamService.CreateTie(r.Context(), userID, communityID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, tie)
+}
+
+// ListTies handles GET /v1/communities/:id/ties
+func (h *TeamHandler) ListTies(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	q := r.URL.Query()
+	ties, pagination, err := h.teamService.ListTies(
+		r.Context(),
+		communityID,
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, ties, *pagination)
+}
+
+// GetTie handles GET /v1/ties/:id
+func (h *TeamHandler) GetTie(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	tieID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid tie ID")
+		return
+	}
+
+	tie, err := h.teamService.GetTie(r.Context(), userID, tieID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tie)
+}
+
+// SubmitLineup handles PUT /v1/ties/:id/lineups/:teamId
+func (h *TeamHandler) SubmitLineup(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	tieID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid tie ID")
+		return
+	}
+
+	teamID, err := parseUUIDParam(r, "teamId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid team ID")
+		return
+	}
+
+	var input struct {
+		Rubbers []service.LineupEntry `json:"rubbers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	tie, err := h.teamService.SubmitLineup(r.Context(), userID, tieID, teamID, input.Rubbers)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tie)
+}
+
+// EventStandings handles GET /v1/events/:id/team-standings
+func (h *TeamHandler) EventStandings(w http.ResponseWriter, r *http.Request) {
+	eventID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid event ID")
+		return
+	}
+
+	standings, err := h.teamService.EventStandings(r.Context(), eventID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, standings)
+}
@@ -63,6 +63,29 @@ func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, profile)
 }
 
+// UpdateNotifications handles PATCH /v1/users/me/notifications
+func (h *UserHandler) UpdateNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	var input service.UpdateNotificationSettingsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	result, err := h.userService.UpdateNotificationSettings(r.Context(), userID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
 // UploadAvatar handles POST /v1/users/me/avatar
 func (h *UserHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 	userID, err := getUserUUID(r)
@@ -157,6 +180,93 @@ func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
 	respondPaginated(w, http.StatusOK, result.Users, result.Pagination)
 }
 
+// NearbyPlayers handles GET /v1/users/nearby?lat=&lng=&radius_km=
+func (h *UserHandler) NearbyPlayers(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	input, ok := parseNearbyInput(w, r)
+	if !ok {
+		return
+	}
+
+	players, err := h.userService.NearbyPlayers(r.Context(), userID, input)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, players)
+}
+
+// GetLocation handles GET /v1/users/me/location
+func (h *UserHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	location, err := h.userService.GetLocation(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, location)
+}
+
+// SetLocation handles PUT /v1/users/me/location
+func (h *UserHandler) SetLocation(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	var body struct {
+		Lat *float64 `json:"lat"`
+		Lng *float64 `json:"lng"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+	if body.Lat == nil || body.Lng == nil {
+		respondError(w, http.StatusBadRequest, "VALIDATION_ERROR", "lat and lng are required")
+		return
+	}
+
+	location, err := h.userService.SetLocation(r.Context(), userID, *body.Lat, *body.Lng)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, location)
+}
+
+// DeleteLocation handles DELETE /v1/users/me/location
+func (h *UserHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	if err := h.userService.DeleteLocation(r.Context(), userID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "ok",
+	})
+}
+
 // getUserUUID extracts and parses user ID from context
 func getUserUUID(r *http.Request) (uuid.UUID, error) {
 	userIDStr := middleware.GetUserID(r.Context())
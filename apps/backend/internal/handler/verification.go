@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/service"
+)
+
+// maxVerificationUpload bounds the multipart body: up to 5 documents of 10 MB plus overhead
+const maxVerificationUpload = 51 << 20
+
+// VerificationHandler handles community verification endpoints
+type VerificationHandler struct {
+	verificationService *service.VerificationService
+}
+
+// NewVerificationHandler creates a new VerificationHandler
+func NewVerificationHandler(verificationService *service.VerificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService: verificationService}
+}
+
+// Submit handles POST /v1/communities/:id/verification (multipart: documents[], comment)
+func (h *VerificationHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	userID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxVerificationUpload)
+	if err := r.ParseMultipartForm(maxVerificationUpload); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "Could not parse multipart form")
+		return
+	}
+
+	headers := r.MultipartForm.File["documents"]
+	if len(headers) == 0 {
+		respondError(w, http.StatusBadRequest, "MISSING_FILE", "At least one document is required")
+		return
+	}
+
+	files := make([]service.VerificationFile, 0, len(headers))
+	for _, fh := range headers {
+		file, err := fh.Open()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "READ_ERROR", "Could not read file")
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(file, 11<<20))
+		file.Close()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "READ_ERROR", "Could not read file")
+			return
+		}
+		files = append(files, service.VerificationFile{Name: fh.Filename, Data: data})
+	}
+
+	request, err := h.verificationService.Submit(r.Context(), userID, communityID, files, r.FormValue("comment"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, request)
+}
+
+// GetStatus handles GET /v1/communities/:id/verification
+func (h *VerificationHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	communityID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	status, err := h.verificationService.GetStatus(r.Context(), communityID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, status)
+}
+
+// List handles GET /v1/superadmin/verifications?status=pending
+func (h *VerificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	requests, pagination, err := h.verificationService.ListRequests(
+		r.Context(),
+		q.Get("status"),
+		queryInt(q.Get("page"), 1),
+		queryInt(q.Get("per_page"), 20),
+	)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondPaginated(w, http.StatusOK, requests, *pagination)
+}
+
+// Review handles POST /v1/superadmin/verifications/:communityId
+func (h *VerificationHandler) Review(w http.ResponseWriter, r *http.Request) {
+	adminID, err := getUserUUID(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		return
+	}
+
+	communityID, err := parseUUIDParam(r, "communityId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_ID", "Invalid community ID")
+		return
+	}
+
+	var body struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	request, err := h.verificationService.Review(r.Context(), adminID, communityID, body.Action, body.Note)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, request)
+}
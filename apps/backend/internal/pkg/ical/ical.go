@@ -0,0 +1,150 @@
+package ical
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ProdID = "-//TennisApp//Calendar//RU"
+
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+
+	maxLineOctets = 75
+)
+
+// Event is a single VEVENT entry
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Status       string
+	Sequence     int
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR with events in a single time zone
+type Calendar struct {
+	Name     string
+	Location *time.Location
+	Events   []Event
+}
+
+// Build renders the calendar as an RFC 5545 document.
+// now is used for DTSTAMP and to pick the zone offset for VTIMEZONE.
+func (c Calendar) Build(now time.Time) []byte {
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	tzid := loc.String()
+
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+ProdID)
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+	writeLine(&b, "X-WR-TIMEZONE:"+tzid)
+	writeTimezone(&b, loc, now)
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, fmt.Sprintf("DTSTART;TZID=%s:%s", tzid, e.Start.In(loc).Format("20060102T150405")))
+		if !e.End.IsZero() {
+			writeLine(&b, fmt.Sprintf("DTEND;TZID=%s:%s", tzid, e.End.In(loc).Format("20060102T150405")))
+		}
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(e.Location))
+		}
+		status := e.Status
+		if status == "" {
+			status = StatusConfirmed
+		}
+		writeLine(&b, "STATUS:"+status)
+		writeLine(&b, fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		if !e.LastModified.IsZero() {
+			writeLine(&b, "LAST-MODIFIED:"+e.LastModified.UTC().Format("20060102T150405Z"))
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// writeTimezone emits a VTIMEZONE with a single STANDARD component.
+// Zones used by the app (Asia/Almaty) have no daylight saving time.
+func writeTimezone(b *strings.Builder, loc *time.Location, now time.Time) {
+	name, offset := now.In(loc).Zone()
+	off := formatOffset(offset)
+
+	writeLine(b, "BEGIN:VTIMEZONE")
+	writeLine(b, "TZID:"+loc.String())
+	writeLine(b, "BEGIN:STANDARD")
+	writeLine(b, "DTSTART:19700101T000000")
+	writeLine(b, "TZOFFSETFROM:"+off)
+	writeLine(b, "TZOFFSETTO:"+off)
+	writeLine(b, "TZNAME:"+name)
+	writeLine(b, "END:STANDARD")
+	writeLine(b, "END:VTIMEZONE")
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// escapeText escapes TEXT values per RFC 5545 section 3.3.11
+func escapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// writeLine writes a content line folded at 75 octets, without splitting UTF-8 runes
+func writeLine(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
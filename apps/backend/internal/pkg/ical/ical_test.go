@@ -0,0 +1,56 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuild_EventInLocalTime(t *testing.T) {
+	loc := time.FixedZone("+05", 5*60*60)
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	cal := Calendar{
+		Name:     "Мои игры",
+		Location: loc,
+		Events: []Event{{
+			UID:      "abc@tennisapp",
+			Summary:  "Игра, парная; корт 1",
+			Start:    time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC),
+			End:      time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC),
+			Status:   StatusCancelled,
+			Sequence: 3,
+		}},
+	}
+	out := string(cal.Build(now))
+
+	expected := []string{
+		"BEGIN:VTIMEZONE\r\n",
+		"TZOFFSETTO:+0500\r\n",
+		"DTSTART;TZID=+05:20250602T180000\r\n",
+		"DTEND;TZID=+05:20250602T200000\r\n",
+		"DTSTAMP:20250601T080000Z\r\n",
+		`SUMMARY:Игра\, парная\; корт 1` + "\r\n",
+		"STATUS:CANCELLED\r\n",
+		"SEQUENCE:3\r\n",
+	}
+	for _, s := range expected {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected output to contain %q", s)
+		}
+	}
+}
+
+func TestWriteLine_Folding(t *testing.T) {
+	var b strings.Builder
+	writeLine(&b, "DESCRIPTION:"+strings.Repeat("теннис ", 30))
+
+	for i, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Line %d is %d octets, expected <= %d", i, len(line), maxLineOctets)
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("Continuation line %d should start with a space", i)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeSignatureHeader carries the hex HMAC-SHA256 of the callback body
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// Fake is an in-memory provider for development and tests.
+// Payments are never charged; a callback is simulated with CallbackRequest.
+type Fake struct {
+	secret  []byte
+	baseURL string
+
+	mu       sync.Mutex
+	payments map[string]Request
+}
+
+// NewFake creates a Fake provider that signs callbacks with secret
+// and builds checkout URLs under baseURL
+func NewFake(secret, baseURL string) *Fake {
+	return &Fake{
+		secret:   []byte(secret),
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		payments: make(map[string]Request),
+	}
+}
+
+// Name implements Provider
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+// CreatePayment implements Provider
+func (f *Fake) CreatePayment(_ context.Context, req Request) (*Payment, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("fake payment: amount must be positive")
+	}
+
+	id := "fake_" + uuid.NewString()
+
+	f.mu.Lock()
+	f.payments[id] = req
+	f.mu.Unlock()
+
+	return &Payment{
+		ID:          id,
+		CheckoutURL: f.baseURL + "/fake-checkout/" + id,
+	}, nil
+}
+
+// ParseCallback implements Provider
+func (f *Fake) ParseCallback(r *http.Request) (*Callback, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64<<10))
+	if err != nil {
+		return nil, ErrInvalidCallback
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, f.sign(body)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload struct {
+		PaymentID string `json:"payment_id"`
+		Status    Status `json:"status"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidCallback
+	}
+	if payload.Status != StatusSucceeded && payload.Status != StatusFailed {
+		return nil, ErrInvalidCallback
+	}
+
+	f.mu.Lock()
+	_, ok := f.payments[payload.PaymentID]
+	f.mu.Unlock()
+	if !ok {
+		return nil, ErrInvalidCallback
+	}
+
+	return &Callback{PaymentID: payload.PaymentID, Status: payload.Status}, nil
+}
+
+// CallbackRequest builds the signed request the provider would send
+// once the customer completes (or abandons) the payment
+func (f *Fake) CallbackRequest(paymentID string, status Status) *http.Request {
+	body, _ := json.Marshal(map[string]string{
+		"payment_id": paymentID,
+		"status":     string(status),
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/v1/payments/fake/callback", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(FakeSignatureHeader, hex.EncodeToString(f.sign(body)))
+	return req
+}
+
+func (f *Fake) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,59 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFake_CreateAndCallback(t *testing.T) {
+	f := NewFake("secret", "http://localhost:8080/")
+
+	payment, err := f.CreatePayment(context.Background(), Request{Reference: "dues-1", Amount: 15000, Currency: "KZT"})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	if payment.CheckoutURL != "http://localhost:8080/fake-checkout/"+payment.ID {
+		t.Errorf("unexpected checkout url %q", payment.CheckoutURL)
+	}
+
+	cb, err := f.ParseCallback(f.CallbackRequest(payment.ID, StatusSucceeded))
+	if err != nil {
+		t.Fatalf("ParseCallback: %v", err)
+	}
+	if cb.PaymentID != payment.ID || cb.Status != StatusSucceeded {
+		t.Errorf("unexpected callback %+v", cb)
+	}
+}
+
+func TestFake_RejectsForeignSignature(t *testing.T) {
+	f := NewFake("secret", "http://localhost")
+	other := NewFake("other", "http://localhost")
+
+	payment, err := f.CreatePayment(context.Background(), Request{Amount: 100})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+
+	_, err = f.ParseCallback(other.CallbackRequest(payment.ID, StatusSucceeded))
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestFake_RejectsUnknownPayment(t *testing.T) {
+	f := NewFake("secret", "http://localhost")
+
+	_, err := f.ParseCallback(f.CallbackRequest("fake_missing", StatusSucceeded))
+	if !errors.Is(err, ErrInvalidCallback) {
+		t.Errorf("expected ErrInvalidCallback, got %v", err)
+	}
+}
+
+func TestFake_RejectsNonPositiveAmount(t *testing.T) {
+	f := NewFake("secret", "http://localhost")
+
+	if _, err := f.CreatePayment(context.Background(), Request{Amount: 0}); err == nil {
+		t.Error("expected error for zero amount")
+	}
+}
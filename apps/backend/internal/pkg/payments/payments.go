@@ -0,0 +1,56 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Status is the outcome reported by a provider callback
+type Status string
+
+const (
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+var (
+	// ErrInvalidSignature is returned when a callback can't be authenticated
+	ErrInvalidSignature = errors.New("payments: invalid callback signature")
+	// ErrInvalidCallback is returned when a callback body can't be parsed
+	ErrInvalidCallback = errors.New("payments: invalid callback payload")
+)
+
+// Request describes a payment the customer should complete on the provider's page
+type Request struct {
+	// Reference is our own id of the payment (the dues entry id)
+	Reference   string
+	Amount      float64
+	Currency    string
+	Description string
+	ReturnURL   string
+}
+
+// Payment is a payment created on the provider side
+type Payment struct {
+	ID          string
+	CheckoutURL string
+}
+
+// Callback is a verified notification about a payment's outcome
+type Callback struct {
+	PaymentID string
+	Status    Status
+}
+
+// Provider is an online payment gateway.
+// Implementations must authenticate callbacks in ParseCallback;
+// the caller trusts whatever a returned Callback says.
+type Provider interface {
+	// Name identifies the provider in URLs and in the dues ledger
+	Name() string
+	// CreatePayment registers a payment and returns where to send the customer
+	CreatePayment(ctx context.Context, req Request) (*Payment, error)
+	// ParseCallback verifies and decodes a provider's callback request
+	ParseCallback(r *http.Request) (*Callback, error)
+}
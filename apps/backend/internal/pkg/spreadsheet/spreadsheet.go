@@ -0,0 +1,120 @@
+// Package spreadsheet streams tabular data as CSV or XLSX.
+//
+// Rows are written one at a time, so exports of any size use constant memory.
+// Cells may be strings, integers, floats, time.Time or nil; numbers become
+// numeric cells in XLSX. Strings that a spreadsheet would read as a formula
+// get a leading apostrophe, so user input cannot inject formulas.
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	// TimeLayout is how time.Time cells are rendered
+	TimeLayout = "2006-01-02 15:04"
+)
+
+// ErrUnknownFormat is returned for formats other than csv and xlsx
+var ErrUnknownFormat = errors.New("unknown spreadsheet format")
+
+// Writer writes rows of cells
+type Writer interface {
+	WriteRow(cells ...any) error
+	// Close flushes buffered data and finishes the document. It does not close
+	// the underlying io.Writer.
+	Close() error
+}
+
+// New creates a writer for the given format. sheet names the XLSX worksheet.
+func New(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(w)
+	case FormatXLSX:
+		return NewXLSX(w, sheet)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ValidFormat reports whether format is supported
+func ValidFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSV creates a CSV writer. The output starts with a UTF-8 byte order mark
+// so that Excel opens Cyrillic text correctly.
+func NewCSV(w io.Writer) (Writer, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) WriteRow(cells ...any) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = formatCell(cell)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// formatCell renders a cell as text
+func formatCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(v)
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(TimeLayout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// escapeFormula prefixes text starting like a formula with an apostrophe
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,125 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(FormatCSV, &buf, "ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.WriteRow("Имя", "Рейтинг", "Дата")
+	_ = w.WriteRow("Алма, \"А\"", 1012.5, time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC))
+	_ = w.WriteRow(nil, int32(3), "")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "\ufeffИмя,Рейтинг,Дата\n\"Алма, \"\"А\"\"\",1012.5,2025-03-01 18:30\n,3,\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(FormatXLSX, &buf, "Участники/2025")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.WriteRow("Имя", "Игры")
+	_ = w.WriteRow("<Алма & Co>", 12)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+
+	files := map[string]string{}
+	for _, f := range z.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	if !strings.Contains(files["xl/workbook.xml"], `name="Участники_2025"`) {
+		t.Errorf("sheet name not sanitized: %s", files["xl/workbook.xml"])
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">&lt;Алма &amp; Co&gt;</t></is></c>`,
+		`<c r="B2"><v>12</v></c>`,
+		`</sheetData></worksheet>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s", want)
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := New("pdf", io.Discard, ""); err != ErrUnknownFormat {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestFormulaInjection(t *testing.T) {
+	tests := []struct {
+		cell any
+		want string
+	}{
+		{`=HYPERLINK("http://evil","x")`, `'=HYPERLINK("http://evil","x")`},
+		{"+cmd|' /C calc'!A0", "'+cmd|' /C calc'!A0"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\tTab", "'\tTab"},
+		{"\rReturn", "'\rReturn"},
+		{"Алма = чемпион", "Алма = чемпион"},
+		{"", ""},
+		{-5, "-5"},
+		{-1.5, "-1.5"},
+	}
+	for _, tt := range tests {
+		if got := formatCell(tt.cell); got != tt.want {
+			t.Errorf("formatCell(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewCSV(&buf)
+	_ = w.WriteRow("=1+1", "Игрок")
+	_ = w.Close()
+	if want := "\ufeff'=1+1,Игрок\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,230 @@
+package workinghours
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Days are the keys of Schedule.Weekly, Monday first
+var Days = [7]string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+const (
+	dateLayout = "2006-01-02"
+	dayMinutes = 24 * 60
+)
+
+// Interval is an opening interval within a day, "HH:MM" to "HH:MM".
+// Close may be "24:00" for venues open until midnight.
+type Interval struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Exception overrides the weekly schedule for a single date (holidays, tournaments)
+type Exception struct {
+	Date   string     `json:"date"`
+	Closed bool       `json:"closed,omitempty"`
+	Hours  []Interval `json:"hours,omitempty"`
+	Note   string     `json:"note,omitempty"`
+}
+
+// Schedule is the structured form of courts.working_hours.
+// A day missing from Weekly (or with no intervals) is closed.
+type Schedule struct {
+	Weekly     map[string][]Interval `json:"weekly"`
+	Exceptions []Exception           `json:"exceptions,omitempty"`
+}
+
+// Range is an opening interval resolved to absolute times
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Parse decodes and validates a working_hours document.
+// An empty or null document returns nil: the hours are unknown.
+func Parse(raw []byte) (*Schedule, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var s Schedule
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid working hours: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks day keys, time formats, interval order and exception dates.
+// Intervals are sorted in place so that later lookups can rely on the order.
+func (s *Schedule) Validate() error {
+	if len(s.Weekly) == 0 {
+		return errors.New("working hours: weekly schedule is required")
+	}
+
+	for day, intervals := range s.Weekly {
+		if !isDay(day) {
+			return fmt.Errorf("working hours: unknown day %q, expected one of mon..sun", day)
+		}
+		if err := validateIntervals(intervals); err != nil {
+			return fmt.Errorf("working hours: %s: %w", day, err)
+		}
+	}
+
+	seen := make(map[string]bool, len(s.Exceptions))
+	for _, e := range s.Exceptions {
+		if _, err := time.Parse(dateLayout, e.Date); err != nil {
+			return fmt.Errorf("working hours: exception date %q must be YYYY-MM-DD", e.Date)
+		}
+		if seen[e.Date] {
+			return fmt.Errorf("working hours: duplicate exception for %s", e.Date)
+		}
+		seen[e.Date] = true
+
+		if e.Closed && len(e.Hours) > 0 {
+			return fmt.Errorf("working hours: exception %s is closed but has hours", e.Date)
+		}
+		if !e.Closed && len(e.Hours) == 0 {
+			return fmt.Errorf("working hours: exception %s needs hours or closed=true", e.Date)
+		}
+		if err := validateIntervals(e.Hours); err != nil {
+			return fmt.Errorf("working hours: exception %s: %w", e.Date, err)
+		}
+	}
+
+	return nil
+}
+
+// IntervalsOn returns the opening intervals for the calendar date of day.
+// The date is taken in day's location, which should be the venue's time zone.
+func (s *Schedule) IntervalsOn(day time.Time) []Interval {
+	date := day.Format(dateLayout)
+	for _, e := range s.Exceptions {
+		if e.Date == date {
+			if e.Closed {
+				return nil
+			}
+			return e.Hours
+		}
+	}
+	return s.Weekly[dayKey(day)]
+}
+
+// Ranges returns the opening intervals of day's date as absolute times
+func (s *Schedule) Ranges(day time.Time) []Range {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+
+	intervals := s.IntervalsOn(day)
+	ranges := make([]Range, 0, len(intervals))
+	for _, iv := range intervals {
+		open, _ := parseClock(iv.Open)
+		closing, _ := parseClock(iv.Close)
+		ranges = append(ranges, Range{
+			Start: midnight.Add(time.Duration(open) * time.Minute),
+			End:   midnight.Add(time.Duration(closing) * time.Minute),
+		})
+	}
+	return ranges
+}
+
+// IsOpenAt reports whether the venue is open at t (in the venue's time zone)
+func (s *Schedule) IsOpenAt(t time.Time) bool {
+	_, ok := s.rangeAt(t)
+	return ok
+}
+
+// Covers reports whether the venue is open for the whole of [start, end).
+// Back-to-back intervals, including ones continuing past midnight, count as open.
+func (s *Schedule) Covers(start, end time.Time) bool {
+	if !end.After(start) {
+		return false
+	}
+
+	cursor := start
+	// a booking spans at most a few days; the bound guards against bad input
+	for i := 0; i < 8; i++ {
+		r, ok := s.rangeAt(cursor)
+		if !ok {
+			return false
+		}
+		if !r.End.Before(end) {
+			return true
+		}
+		cursor = r.End
+	}
+	return false
+}
+
+func (s *Schedule) rangeAt(t time.Time) (Range, bool) {
+	for _, r := range s.Ranges(t) {
+		if !t.Before(r.Start) && t.Before(r.End) {
+			return r, true
+		}
+	}
+	return Range{}, false
+}
+
+func validateIntervals(intervals []Interval) error {
+	for _, iv := range intervals {
+		open, err := parseClock(iv.Open)
+		if err != nil || open == dayMinutes {
+			return fmt.Errorf("invalid open time %q", iv.Open)
+		}
+		closing, err := parseClock(iv.Close)
+		if err != nil {
+			return fmt.Errorf("invalid close time %q", iv.Close)
+		}
+		if closing <= open {
+			return fmt.Errorf("close time %s must be after open time %s, split overnight hours across days", iv.Close, iv.Open)
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Open < intervals[j].Open })
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i].Open < intervals[i-1].Close {
+			return fmt.Errorf("intervals %s-%s and %s-%s overlap",
+				intervals[i-1].Open, intervals[i-1].Close, intervals[i].Open, intervals[i].Close)
+		}
+	}
+	return nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight; "24:00" is allowed
+func parseClock(s string) (int, error) {
+	var h, m int
+	if len(s) != 5 || s[2] != ':' {
+		return 0, errors.New("expected HH:MM")
+	}
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &h, &m); err != nil {
+		return 0, err
+	}
+	if h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, errors.New("time out of range")
+	}
+	return h*60 + m, nil
+}
+
+func dayKey(t time.Time) string {
+	// time.Weekday starts on Sunday
+	return Days[(int(t.Weekday())+6)%7]
+}
+
+func isDay(key string) bool {
+	for _, d := range Days {
+		if d == key {
+			return true
+		}
+	}
+	return false
+}
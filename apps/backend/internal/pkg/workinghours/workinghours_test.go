@@ -0,0 +1,110 @@
+package workinghours
+
+import (
+	"testing"
+	"time"
+)
+
+var almaty = time.FixedZone("Asia/Almaty", 5*60*60)
+
+const sample = `{
+	"weekly": {
+		"mon": [{"open": "07:00", "close": "12:00"}, {"open": "14:00", "close": "23:00"}],
+		"tue": [{"open": "07:00", "close": "23:00"}],
+		"sat": [{"open": "08:00", "close": "24:00"}],
+		"sun": [{"open": "00:00", "close": "02:00"}, {"open": "08:00", "close": "20:00"}]
+	},
+	"exceptions": [
+		{"date": "2025-03-22", "closed": true, "note": "Наурыз"},
+		{"date": "2025-03-25", "hours": [{"open": "10:00", "close": "16:00"}]}
+	]
+}`
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown day", `{"weekly": {"monday": [{"open": "07:00", "close": "23:00"}]}}`},
+		{"bad time", `{"weekly": {"mon": [{"open": "7:00", "close": "23:00"}]}}`},
+		{"close before open", `{"weekly": {"mon": [{"open": "22:00", "close": "02:00"}]}}`},
+		{"overlap", `{"weekly": {"mon": [{"open": "07:00", "close": "12:00"}, {"open": "11:00", "close": "20:00"}]}}`},
+		{"closed with hours", `{"weekly": {"mon": []}, "exceptions": [{"date": "2025-01-01", "closed": true, "hours": [{"open": "10:00", "close": "12:00"}]}]}`},
+		{"bad exception date", `{"weekly": {"mon": []}, "exceptions": [{"date": "01.01.2025", "closed": true}]}`},
+		{"unknown field", `{"weekly": {"mon": []}, "holidays": []}`},
+		{"no weekly", `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.raw)); err == nil {
+				t.Errorf("Expected error for %s", tt.raw)
+			}
+		})
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	for _, raw := range []string{"", "null", "  "} {
+		s, err := Parse([]byte(raw))
+		if err != nil || s != nil {
+			t.Errorf("Parse(%q) = %v, %v; expected nil, nil", raw, s, err)
+		}
+	}
+}
+
+func TestIsOpenAt(t *testing.T) {
+	s, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		expected bool
+	}{
+		{"monday morning", time.Date(2025, 3, 17, 9, 0, 0, 0, almaty), true},
+		{"monday lunch break", time.Date(2025, 3, 17, 13, 0, 0, 0, almaty), false},
+		{"monday close is exclusive", time.Date(2025, 3, 17, 23, 0, 0, 0, almaty), false},
+		{"wednesday not in schedule", time.Date(2025, 3, 19, 10, 0, 0, 0, almaty), false},
+		{"holiday closed", time.Date(2025, 3, 22, 10, 0, 0, 0, almaty), false},
+		{"short day", time.Date(2025, 3, 25, 17, 0, 0, 0, almaty), false},
+		{"utc input is converted by caller", time.Date(2025, 3, 18, 2, 0, 0, 0, time.UTC).In(almaty), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.IsOpenAt(tt.at); got != tt.expected {
+				t.Errorf("IsOpenAt(%v) = %v, expected %v", tt.at, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	s, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   bool
+	}{
+		{"inside interval", time.Date(2025, 3, 18, 18, 0, 0, 0, almaty), time.Date(2025, 3, 18, 20, 0, 0, 0, almaty), true},
+		{"across lunch break", time.Date(2025, 3, 17, 11, 0, 0, 0, almaty), time.Date(2025, 3, 17, 15, 0, 0, 0, almaty), false},
+		{"ends at closing", time.Date(2025, 3, 18, 21, 0, 0, 0, almaty), time.Date(2025, 3, 18, 23, 0, 0, 0, almaty), true},
+		{"past closing", time.Date(2025, 3, 18, 22, 0, 0, 0, almaty), time.Date(2025, 3, 18, 23, 30, 0, 0, almaty), false},
+		{"across midnight", time.Date(2025, 3, 29, 23, 0, 0, 0, almaty), time.Date(2025, 3, 30, 1, 0, 0, 0, almaty), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Covers(tt.start, tt.end); got != tt.expected {
+				t.Errorf("Covers(%v, %v) = %v, expected %v", tt.start, tt.end, got, tt.expected)
+			}
+		})
+	}
+}
@@ -59,6 +59,26 @@ func (q *Queries) AddCommunityMember(ctx context.Context, arg AddCommunityMember
 	return i, err
 }
 
+const communitySlugExists = `-- name: CommunitySlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM communities
+    WHERE slug = $1
+      AND ($2::uuid IS NULL OR id <> $2)
+) AS slug_exists
+`
+
+type CommunitySlugExistsParams struct {
+	Slug      string      `json:"slug"`
+	ExcludeID pgtype.UUID `json:"exclude_id"`
+}
+
+func (q *Queries) CommunitySlugExists(ctx context.Context, arg CommunitySlugExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, communitySlugExists, arg.Slug, arg.ExcludeID)
+	var slug_exists bool
+	err := row.Scan(&slug_exists)
+	return slug_exists, err
+}
+
 const countCommunities = `-- name: CountCommunities :one
 SELECT COUNT(*)
 FROM communities
@@ -311,7 +331,7 @@ SELECT
     END as win_rate
 FROM community_members cm
 JOIN users u ON cm.user_id = u.id
-WHERE cm.community_id = $1 AND cm.status = 'active' AND u.status = 'active'
+WHERE cm.community_id = $1 AND cm.status = 'active' AND u.status IN ('active', 'placeholder')
   AND cm.community_games_count >= $2
 ORDER BY cm.community_rating DESC
 LIMIT $3 OFFSET $4
@@ -422,6 +442,7 @@ WHERE is_active = TRUE
   AND ($4::text IS NULL OR district = $4)
   AND ($5::text IS NULL OR name ILIKE '%' || $5 || '%')
 ORDER BY
+    verification_status = 'verified' DESC NULLS LAST,
     CASE WHEN $6::text = 'members' THEN member_count END DESC,
     CASE WHEN $6::text = 'activity' THEN event_count END DESC,
     CASE WHEN $6::text = 'name' THEN 1 END ASC,
@@ -673,18 +694,19 @@ func (q *Queries) ListMyCommunities(ctx context.Context, userID pgtype.UUID) ([]
 const updateCommunity = `-- name: UpdateCommunity :one
 UPDATE communities SET
     name              = COALESCE($1, name),
-    description       = COALESCE($2, description),
-    rules             = COALESCE($3, rules),
-    access_level      = COALESCE($4, access_level),
-    logo_url          = COALESCE($5, logo_url),
-    banner_url        = COALESCE($6, banner_url),
-    contact_phone     = COALESCE($7, contact_phone),
-    contact_email     = COALESCE($8, contact_email),
-    social_links      = COALESCE($9, social_links),
-    address           = COALESCE($10, address),
-    district          = COALESCE($11, district),
+    slug              = COALESCE($2, slug),
+    description       = COALESCE($3, description),
+    rules             = COALESCE($4, rules),
+    access_level      = COALESCE($5, access_level),
+    logo_url          = COALESCE($6, logo_url),
+    banner_url        = COALESCE($7, banner_url),
+    contact_phone     = COALESCE($8, contact_phone),
+    contact_email     = COALESCE($9, contact_email),
+    social_links      = COALESCE($10, social_links),
+    address           = COALESCE($11, address),
+    district          = COALESCE($12, district),
     updated_at        = NOW()
-WHERE id = $12 AND is_active = TRUE
+WHERE id = $13 AND is_active = TRUE
 RETURNING id, name, slug, description, rules, community_type, access_level,
     verification_status, verified_at, verification_documents,
     logo_url, banner_url, contact_phone, contact_email, social_links,
@@ -696,6 +718,7 @@ RETURNING id, name, slug, description, rules, community_type, access_level,
 
 type UpdateCommunityParams struct {
 	Name         pgtype.Text         `json:"name"`
+	Slug         pgtype.Text         `json:"slug"`
 	Description  pgtype.Text         `json:"description"`
 	Rules        pgtype.Text         `json:"rules"`
 	AccessLevel  NullCommunityAccess `json:"access_level"`
@@ -712,6 +735,7 @@ type UpdateCommunityParams struct {
 func (q *Queries) UpdateCommunity(ctx context.Context, arg UpdateCommunityParams) (Community, error) {
 	row := q.db.QueryRow(ctx, updateCommunity,
 		arg.Name,
+		arg.Slug,
 		arg.Description,
 		arg.Rules,
 		arg.AccessLevel,
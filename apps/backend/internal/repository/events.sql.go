@@ -50,7 +50,7 @@ func (q *Queries) AddEventParticipant(ctx context.Context, arg AddEventParticipa
 
 const checkFriendship = `-- name: CheckFriendship :one
 SELECT EXISTS(
-    SELECT 1 FROM friends WHERE user_id = $1 AND friend_id = $2
+    SELECT 1 FROM friends WHERE user_id = $1 AND friend_id = $2 AND status = 'accepted'
 ) as is_friend
 `
 
@@ -716,6 +716,76 @@ func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]ListE
 	return items, nil
 }
 
+const listEventsForLifecycle = `-- name: ListEventsForLifecycle :many
+SELECT id, title, status, start_time, end_time, registration_deadline,
+    min_participants, current_participants
+FROM events
+WHERE (status IN ('published', 'registration_open', 'registration_closed')
+        AND start_time <= $1::timestamptz
+        AND COALESCE(current_participants, 0) < min_participants)
+   OR (status IN ('published', 'registration_open') AND (registration_deadline <= $2::timestamptz OR start_time <= $2::timestamptz))
+   OR (status = 'registration_closed' AND start_time <= $2::timestamptz)
+   OR (status = 'in_progress' AND end_time <= $2::timestamptz)
+   OR (status = 'in_progress' AND end_time IS NULL AND start_time <= $3::timestamptz)
+   OR (status = 'completed' AND COALESCE(end_time, start_time) <= $4::timestamptz)
+ORDER BY start_time ASC
+LIMIT $5
+`
+
+type ListEventsForLifecycleParams struct {
+	CancelHorizon pgtype.Timestamptz `json:"cancel_horizon"`
+	Now           pgtype.Timestamptz `json:"now"`
+	StartedBefore pgtype.Timestamptz `json:"started_before"`
+	ArchiveBefore pgtype.Timestamptz `json:"archive_before"`
+	BatchLimit    int32              `json:"batch_limit"`
+}
+
+type ListEventsForLifecycleRow struct {
+	ID                   pgtype.UUID        `json:"id"`
+	Title                string             `json:"title"`
+	Status               NullEventStatus    `json:"status"`
+	StartTime            pgtype.Timestamptz `json:"start_time"`
+	EndTime              pgtype.Timestamptz `json:"end_time"`
+	RegistrationDeadline pgtype.Timestamptz `json:"registration_deadline"`
+	MinParticipants      pgtype.Int4        `json:"min_participants"`
+	CurrentParticipants  pgtype.Int4        `json:"current_participants"`
+}
+
+func (q *Queries) ListEventsForLifecycle(ctx context.Context, arg ListEventsForLifecycleParams) ([]ListEventsForLifecycleRow, error) {
+	rows, err := q.db.Query(ctx, listEventsForLifecycle,
+		arg.CancelHorizon,
+		arg.Now,
+		arg.StartedBefore,
+		arg.ArchiveBefore,
+		arg.BatchLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEventsForLifecycleRow{}
+	for rows.Next() {
+		var i ListEventsForLifecycleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Status,
+			&i.StartTime,
+			&i.EndTime,
+			&i.RegistrationDeadline,
+			&i.MinParticipants,
+			&i.CurrentParticipants,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMyCreatedEvents = `-- name: ListMyCreatedEvents :many
 SELECT id, title, description, event_type, status,
     community_id, start_time, end_time,
@@ -952,6 +1022,27 @@ func (q *Queries) RemoveEventParticipant(ctx context.Context, arg RemoveEventPar
 	return err
 }
 
+const transitionEventStatus = `-- name: TransitionEventStatus :execrows
+UPDATE events SET
+    status = $1,
+    updated_at = NOW()
+WHERE id = $2 AND status = $3
+`
+
+type TransitionEventStatusParams struct {
+	ToStatus   NullEventStatus `json:"to_status"`
+	ID         pgtype.UUID     `json:"id"`
+	FromStatus NullEventStatus `json:"from_status"`
+}
+
+func (q *Queries) TransitionEventStatus(ctx context.Context, arg TransitionEventStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, transitionEventStatus, arg.ToStatus, arg.ID, arg.FromStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateEvent = `-- name: UpdateEvent :one
 UPDATE events SET
     title              = COALESCE($1, title),
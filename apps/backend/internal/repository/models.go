@@ -12,6 +12,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BookingStatus string
+
+const (
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusCancelled BookingStatus = "cancelled"
+)
+
+func (e *BookingStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BookingStatus(s)
+	case string:
+		*e = BookingStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BookingStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBookingStatus struct {
+	BookingStatus BookingStatus `json:"booking_status"`
+	Valid         bool          `json:"valid"` // Valid is true if BookingStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBookingStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BookingStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BookingStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBookingStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BookingStatus), nil
+}
+
 type ChatType string
 
 const (
@@ -187,6 +229,49 @@ func (ns NullCommunityType) Value() (driver.Value, error) {
 	return string(ns.CommunityType), nil
 }
 
+type CourtStatus string
+
+const (
+	CourtStatusPending  CourtStatus = "pending"
+	CourtStatusApproved CourtStatus = "approved"
+	CourtStatusRejected CourtStatus = "rejected"
+)
+
+func (e *CourtStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CourtStatus(s)
+	case string:
+		*e = CourtStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CourtStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCourtStatus struct {
+	CourtStatus CourtStatus `json:"court_status"`
+	Valid       bool        `json:"valid"` // Valid is true if CourtStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCourtStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CourtStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CourtStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCourtStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CourtStatus), nil
+}
+
 type CourtSurface string
 
 const (
@@ -232,6 +317,49 @@ func (ns NullCourtSurface) Value() (driver.Value, error) {
 	return string(ns.CourtSurface), nil
 }
 
+type DuesStatus string
+
+const (
+	DuesStatusPending   DuesStatus = "pending"
+	DuesStatusPaid      DuesStatus = "paid"
+	DuesStatusCancelled DuesStatus = "cancelled"
+)
+
+func (e *DuesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DuesStatus(s)
+	case string:
+		*e = DuesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DuesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDuesStatus struct {
+	DuesStatus DuesStatus `json:"dues_status"`
+	Valid      bool       `json:"valid"` // Valid is true if DuesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDuesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DuesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DuesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDuesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DuesStatus), nil
+}
+
 type EventStatus string
 
 const (
@@ -324,6 +452,92 @@ func (ns NullEventType) Value() (driver.Value, error) {
 	return string(ns.EventType), nil
 }
 
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusRunning   ExportStatus = "running"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+func (e *ExportStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ExportStatus(s)
+	case string:
+		*e = ExportStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ExportStatus: %T", src)
+	}
+	return nil
+}
+
+type NullExportStatus struct {
+	ExportStatus ExportStatus `json:"export_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ExportStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullExportStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ExportStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ExportStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullExportStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ExportStatus), nil
+}
+
+type FriendStatus string
+
+const (
+	FriendStatusPending  FriendStatus = "pending"
+	FriendStatusAccepted FriendStatus = "accepted"
+)
+
+func (e *FriendStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FriendStatus(s)
+	case string:
+		*e = FriendStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FriendStatus: %T", src)
+	}
+	return nil
+}
+
+type NullFriendStatus struct {
+	FriendStatus FriendStatus `json:"friend_status"`
+	Valid        bool         `json:"valid"` // Valid is true if FriendStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFriendStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.FriendStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FriendStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFriendStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FriendStatus), nil
+}
+
 type GenderType string
 
 const (
@@ -367,6 +581,140 @@ func (ns NullGenderType) Value() (driver.Value, error) {
 	return string(ns.GenderType), nil
 }
 
+type ImportStatus string
+
+const (
+	ImportStatusPreview   ImportStatus = "preview"
+	ImportStatusCommitted ImportStatus = "committed"
+)
+
+func (e *ImportStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ImportStatus(s)
+	case string:
+		*e = ImportStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ImportStatus: %T", src)
+	}
+	return nil
+}
+
+type NullImportStatus struct {
+	ImportStatus ImportStatus `json:"import_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ImportStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullImportStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ImportStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ImportStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullImportStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ImportStatus), nil
+}
+
+type LadderChallengeStatus string
+
+const (
+	LadderChallengeStatusPending   LadderChallengeStatus = "pending"
+	LadderChallengeStatusAccepted  LadderChallengeStatus = "accepted"
+	LadderChallengeStatusDeclined  LadderChallengeStatus = "declined"
+	LadderChallengeStatusCancelled LadderChallengeStatus = "cancelled"
+	LadderChallengeStatusExpired   LadderChallengeStatus = "expired"
+	LadderChallengeStatusCompleted LadderChallengeStatus = "completed"
+)
+
+func (e *LadderChallengeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LadderChallengeStatus(s)
+	case string:
+		*e = LadderChallengeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LadderChallengeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullLadderChallengeStatus struct {
+	LadderChallengeStatus LadderChallengeStatus `json:"ladder_challenge_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if LadderChallengeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLadderChallengeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.LadderChallengeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LadderChallengeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLadderChallengeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LadderChallengeStatus), nil
+}
+
+type LadderChangeReason string
+
+const (
+	LadderChangeReasonJoined         LadderChangeReason = "joined"
+	LadderChangeReasonLeft           LadderChangeReason = "left"
+	LadderChangeReasonChallengeWon   LadderChangeReason = "challenge_won"
+	LadderChangeReasonChallengeLost  LadderChangeReason = "challenge_lost"
+	LadderChangeReasonDeclinePenalty LadderChangeReason = "decline_penalty"
+	LadderChangeReasonShifted        LadderChangeReason = "shifted"
+)
+
+func (e *LadderChangeReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LadderChangeReason(s)
+	case string:
+		*e = LadderChangeReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LadderChangeReason: %T", src)
+	}
+	return nil
+}
+
+type NullLadderChangeReason struct {
+	LadderChangeReason LadderChangeReason `json:"ladder_change_reason"`
+	Valid              bool               `json:"valid"` // Valid is true if LadderChangeReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLadderChangeReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.LadderChangeReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LadderChangeReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLadderChangeReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LadderChangeReason), nil
+}
+
 type MatchFormat string
 
 const (
@@ -419,6 +767,7 @@ const (
 	MemberStatusActive  MemberStatus = "active"
 	MemberStatusBanned  MemberStatus = "banned"
 	MemberStatusLeft    MemberStatus = "left"
+	MemberStatusExpired MemberStatus = "expired"
 )
 
 func (e *MemberStatus) Scan(src interface{}) error {
@@ -456,22 +805,117 @@ func (ns NullMemberStatus) Value() (driver.Value, error) {
 	return string(ns.MemberStatus), nil
 }
 
+type MembershipPeriod string
+
+const (
+	MembershipPeriodMonthly  MembershipPeriod = "monthly"
+	MembershipPeriodSeasonal MembershipPeriod = "seasonal"
+)
+
+func (e *MembershipPeriod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MembershipPeriod(s)
+	case string:
+		*e = MembershipPeriod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MembershipPeriod: %T", src)
+	}
+	return nil
+}
+
+type NullMembershipPeriod struct {
+	MembershipPeriod MembershipPeriod `json:"membership_period"`
+	Valid            bool             `json:"valid"` // Valid is true if MembershipPeriod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMembershipPeriod) Scan(value interface{}) error {
+	if value == nil {
+		ns.MembershipPeriod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MembershipPeriod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMembershipPeriod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MembershipPeriod), nil
+}
+
+type ModerationAction string
+
+const (
+	ModerationActionKick  ModerationAction = "kick"
+	ModerationActionBan   ModerationAction = "ban"
+	ModerationActionUnban ModerationAction = "unban"
+)
+
+func (e *ModerationAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ModerationAction(s)
+	case string:
+		*e = ModerationAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ModerationAction: %T", src)
+	}
+	return nil
+}
+
+type NullModerationAction struct {
+	ModerationAction ModerationAction `json:"moderation_action"`
+	Valid            bool             `json:"valid"` // Valid is true if ModerationAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullModerationAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.ModerationAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ModerationAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullModerationAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ModerationAction), nil
+}
+
 type NotificationType string
 
 const (
-	NotificationTypeEventResponse   NotificationType = "event_response"
-	NotificationTypeGameReminder24h NotificationType = "game_reminder_24h"
-	NotificationTypeGameReminder1h  NotificationType = "game_reminder_1h"
-	NotificationTypeResultConfirm   NotificationType = "result_confirm"
-	NotificationTypeCommunityNews   NotificationType = "community_news"
-	NotificationTypeNewMessage      NotificationType = "new_message"
-	NotificationTypeRatingChange    NotificationType = "rating_change"
-	NotificationTypeNewBadge        NotificationType = "new_badge"
-	NotificationTypeJoinRequest     NotificationType = "join_request"
-	NotificationTypeJoinApproved    NotificationType = "join_approved"
-	NotificationTypeJoinRejected    NotificationType = "join_rejected"
-	NotificationTypeEventCancelled  NotificationType = "event_cancelled"
-	NotificationTypeSpotAvailable   NotificationType = "spot_available"
+	NotificationTypeEventResponse         NotificationType = "event_response"
+	NotificationTypeGameReminder24h       NotificationType = "game_reminder_24h"
+	NotificationTypeGameReminder1h        NotificationType = "game_reminder_1h"
+	NotificationTypeResultConfirm         NotificationType = "result_confirm"
+	NotificationTypeCommunityNews         NotificationType = "community_news"
+	NotificationTypeNewMessage            NotificationType = "new_message"
+	NotificationTypeRatingChange          NotificationType = "rating_change"
+	NotificationTypeNewBadge              NotificationType = "new_badge"
+	NotificationTypeJoinRequest           NotificationType = "join_request"
+	NotificationTypeJoinApproved          NotificationType = "join_approved"
+	NotificationTypeJoinRejected          NotificationType = "join_rejected"
+	NotificationTypeEventCancelled        NotificationType = "event_cancelled"
+	NotificationTypeSpotAvailable         NotificationType = "spot_available"
+	NotificationTypeCommunityVerification NotificationType = "community_verification"
+	NotificationTypeMembershipDues        NotificationType = "membership_dues"
+	NotificationTypeCommunityModeration   NotificationType = "community_moderation"
+	NotificationTypeCommunityOwnership    NotificationType = "community_ownership"
+	NotificationTypeExportReady           NotificationType = "export_ready"
+	NotificationTypeLadderChallenge       NotificationType = "ladder_challenge"
+	NotificationTypeFriendRequest         NotificationType = "friend_request"
+	NotificationTypeFriendAccepted        NotificationType = "friend_accepted"
+	NotificationTypeCommentMention        NotificationType = "comment_mention"
+	NotificationTypeCommunityInvite       NotificationType = "community_invite"
 )
 
 func (e *NotificationType) Scan(src interface{}) error {
@@ -509,6 +953,51 @@ func (ns NullNotificationType) Value() (driver.Value, error) {
 	return string(ns.NotificationType), nil
 }
 
+type OwnershipTransferStatus string
+
+const (
+	OwnershipTransferStatusPending   OwnershipTransferStatus = "pending"
+	OwnershipTransferStatusAccepted  OwnershipTransferStatus = "accepted"
+	OwnershipTransferStatusDeclined  OwnershipTransferStatus = "declined"
+	OwnershipTransferStatusCancelled OwnershipTransferStatus = "cancelled"
+	OwnershipTransferStatusExpired   OwnershipTransferStatus = "expired"
+)
+
+func (e *OwnershipTransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OwnershipTransferStatus(s)
+	case string:
+		*e = OwnershipTransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OwnershipTransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOwnershipTransferStatus struct {
+	OwnershipTransferStatus OwnershipTransferStatus `json:"ownership_transfer_status"`
+	Valid                   bool                    `json:"valid"` // Valid is true if OwnershipTransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOwnershipTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OwnershipTransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OwnershipTransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOwnershipTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OwnershipTransferStatus), nil
+}
+
 type ParticipantStatus string
 
 const (
@@ -554,6 +1043,49 @@ func (ns NullParticipantStatus) Value() (driver.Value, error) {
 	return string(ns.ParticipantStatus), nil
 }
 
+type PaymentMethod string
+
+const (
+	PaymentMethodCash          PaymentMethod = "cash"
+	PaymentMethodKaspiTransfer PaymentMethod = "kaspi_transfer"
+	PaymentMethodOnline        PaymentMethod = "online"
+)
+
+func (e *PaymentMethod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentMethod(s)
+	case string:
+		*e = PaymentMethod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentMethod: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentMethod struct {
+	PaymentMethod PaymentMethod `json:"payment_method"`
+	Valid         bool          `json:"valid"` // Valid is true if PaymentMethod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentMethod) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentMethod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentMethod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentMethod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentMethod), nil
+}
+
 type PlatformRole string
 
 const (
@@ -727,6 +1259,48 @@ func (ns NullResultStatus) Value() (driver.Value, error) {
 	return string(ns.ResultStatus), nil
 }
 
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusHidden    ReviewStatus = "hidden"
+)
+
+func (e *ReviewStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReviewStatus(s)
+	case string:
+		*e = ReviewStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReviewStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReviewStatus struct {
+	ReviewStatus ReviewStatus `json:"review_status"`
+	Valid        bool         `json:"valid"` // Valid is true if ReviewStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReviewStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReviewStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReviewStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReviewStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReviewStatus), nil
+}
+
 type TournamentSystem string
 
 const (
@@ -775,9 +1349,10 @@ func (ns NullTournamentSystem) Value() (driver.Value, error) {
 type UserStatus string
 
 const (
-	UserStatusActive  UserStatus = "active"
-	UserStatusBanned  UserStatus = "banned"
-	UserStatusDeleted UserStatus = "deleted"
+	UserStatusActive      UserStatus = "active"
+	UserStatusBanned      UserStatus = "banned"
+	UserStatusDeleted     UserStatus = "deleted"
+	UserStatusPlaceholder UserStatus = "placeholder"
 )
 
 func (e *UserStatus) Scan(src interface{}) error {
@@ -884,6 +1459,12 @@ type BadgeDefinition struct {
 	SortOrder      pgtype.Int4 `json:"sort_order"`
 }
 
+type CalendarToken struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Token     string             `json:"token"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Chat struct {
 	ID                 pgtype.UUID        `json:"id"`
 	ChatType           ChatType           `json:"chat_type"`
@@ -936,6 +1517,91 @@ type Community struct {
 	UpdatedAt             pgtype.Timestamptz     `json:"updated_at"`
 }
 
+type CommunityAnnouncement struct {
+	ID             pgtype.UUID        `json:"id"`
+	CommunityID    pgtype.UUID        `json:"community_id"`
+	AuthorID       pgtype.UUID        `json:"author_id"`
+	Title          string             `json:"title"`
+	Body           string             `json:"body"`
+	TargetRole     NullCommunityRole  `json:"target_role"`
+	MinNtrp        pgtype.Numeric     `json:"min_ntrp"`
+	MaxNtrp        pgtype.Numeric     `json:"max_ntrp"`
+	RecipientCount int32              `json:"recipient_count"`
+	PushCount      int32              `json:"push_count"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type CommunityDeactivation struct {
+	ID            pgtype.UUID        `json:"id"`
+	CommunityID   pgtype.UUID        `json:"community_id"`
+	DeactivatedBy pgtype.UUID        `json:"deactivated_by"`
+	Reason        pgtype.Text        `json:"reason"`
+	ReactivatedBy pgtype.UUID        `json:"reactivated_by"`
+	ReactivatedAt pgtype.Timestamptz `json:"reactivated_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type CommunityExport struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	RequestedBy pgtype.UUID        `json:"requested_by"`
+	Dataset     string             `json:"dataset"`
+	Format      string             `json:"format"`
+	Status      ExportStatus       `json:"status"`
+	RowCount    pgtype.Int4        `json:"row_count"`
+	FileUrl     pgtype.Text        `json:"file_url"`
+	Error       pgtype.Text        `json:"error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+type CommunityImport struct {
+	ID            pgtype.UUID        `json:"id"`
+	CommunityID   pgtype.UUID        `json:"community_id"`
+	CreatedBy     pgtype.UUID        `json:"created_by"`
+	Kind          string             `json:"kind"`
+	Status        ImportStatus       `json:"status"`
+	TotalRows     int32              `json:"total_rows"`
+	ValidRows     []byte             `json:"valid_rows"`
+	RowErrors     []byte             `json:"row_errors"`
+	ReplayRatings bool               `json:"replay_ratings"`
+	Result        []byte             `json:"result"`
+	CommittedBy   pgtype.UUID        `json:"committed_by"`
+	CommittedAt   pgtype.Timestamptz `json:"committed_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type CommunityInvite struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	Code        string             `json:"code"`
+	Role        CommunityRole      `json:"role"`
+	MaxUses     pgtype.Int4        `json:"max_uses"`
+	UseCount    int32              `json:"use_count"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	CreatedBy   pgtype.UUID        `json:"created_by"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type CommunityInviteUse struct {
+	InviteID    pgtype.UUID        `json:"invite_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	JoinedAt    pgtype.Timestamptz `json:"joined_at"`
+}
+
+type CommunityLadder struct {
+	CommunityID    pgtype.UUID        `json:"community_id"`
+	ChallengeRange int32              `json:"challenge_range"`
+	AcceptHours    int32              `json:"accept_hours"`
+	PlayDays       int32              `json:"play_days"`
+	DeclinePenalty int32              `json:"decline_penalty"`
+	IsActive       bool               `json:"is_active"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type CommunityMember struct {
 	ID                  pgtype.UUID        `json:"id"`
 	CommunityID         pgtype.UUID        `json:"community_id"`
@@ -953,27 +1619,113 @@ type CommunityMember struct {
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 }
 
+type CommunityModerationAction struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	ActorID     pgtype.UUID        `json:"actor_id"`
+	Action      ModerationAction   `json:"action"`
+	Reason      pgtype.Text        `json:"reason"`
+	BannedUntil pgtype.Timestamptz `json:"banned_until"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type CommunityOwnershipTransfer struct {
+	ID          pgtype.UUID             `json:"id"`
+	CommunityID pgtype.UUID             `json:"community_id"`
+	FromUserID  pgtype.UUID             `json:"from_user_id"`
+	ToUserID    pgtype.UUID             `json:"to_user_id"`
+	Status      OwnershipTransferStatus `json:"status"`
+	ExpiresAt   pgtype.Timestamptz      `json:"expires_at"`
+	RespondedAt pgtype.Timestamptz      `json:"responded_at"`
+	CreatedAt   pgtype.Timestamptz      `json:"created_at"`
+}
+
+type CommunityVerificationRequest struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	SubmittedBy pgtype.UUID        `json:"submitted_by"`
+	Documents   []byte             `json:"documents"`
+	Comment     pgtype.Text        `json:"comment"`
+	Status      VerificationStatus `json:"status"`
+	ReviewNote  pgtype.Text        `json:"review_note"`
+	ReviewedBy  pgtype.UUID        `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Court struct {
-	ID            pgtype.UUID        `json:"id"`
-	Name          string             `json:"name"`
-	Address       string             `json:"address"`
-	District      pgtype.Text        `json:"district"`
-	Latitude      pgtype.Numeric     `json:"latitude"`
-	Longitude     pgtype.Numeric     `json:"longitude"`
-	TotalCourts   pgtype.Int2        `json:"total_courts"`
-	IndoorCourts  pgtype.Int2        `json:"indoor_courts"`
-	OutdoorCourts pgtype.Int2        `json:"outdoor_courts"`
-	Surface       NullCourtSurface   `json:"surface"`
-	PricePerHour  pgtype.Numeric     `json:"price_per_hour"`
-	Currency      pgtype.Text        `json:"currency"`
-	Phone         pgtype.Text        `json:"phone"`
-	WorkingHours  []byte             `json:"working_hours"`
-	Photos        []byte             `json:"photos"`
-	CommunityID   pgtype.UUID        `json:"community_id"`
-	IsActive      pgtype.Bool        `json:"is_active"`
-	CreatedBy     pgtype.UUID        `json:"created_by"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Name           string             `json:"name"`
+	Address        string             `json:"address"`
+	District       pgtype.Text        `json:"district"`
+	Latitude       pgtype.Numeric     `json:"latitude"`
+	Longitude      pgtype.Numeric     `json:"longitude"`
+	TotalCourts    pgtype.Int2        `json:"total_courts"`
+	IndoorCourts   pgtype.Int2        `json:"indoor_courts"`
+	OutdoorCourts  pgtype.Int2        `json:"outdoor_courts"`
+	Surface        NullCourtSurface   `json:"surface"`
+	PricePerHour   pgtype.Numeric     `json:"price_per_hour"`
+	Currency       pgtype.Text        `json:"currency"`
+	Phone          pgtype.Text        `json:"phone"`
+	WorkingHours   []byte             `json:"working_hours"`
+	Photos         []byte             `json:"photos"`
+	CommunityID    pgtype.UUID        `json:"community_id"`
+	IsActive       pgtype.Bool        `json:"is_active"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Status         NullCourtStatus    `json:"status"`
+	ModerationNote pgtype.Text        `json:"moderation_note"`
+	ModeratedBy    pgtype.UUID        `json:"moderated_by"`
+	ModeratedAt    pgtype.Timestamptz `json:"moderated_at"`
+}
+
+type CourtBooking struct {
+	ID          pgtype.UUID        `json:"id"`
+	CourtID     pgtype.UUID        `json:"court_id"`
+	CourtNumber int16              `json:"court_number"`
+	StartsAt    pgtype.Timestamptz `json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `json:"ends_at"`
+	Status      BookingStatus      `json:"status"`
+	BookedBy    pgtype.UUID        `json:"booked_by"`
+	EventID     pgtype.UUID        `json:"event_id"`
+	TotalPrice  pgtype.Numeric     `json:"total_price"`
+	Currency    pgtype.Text        `json:"currency"`
+	SplitCount  int16              `json:"split_count"`
+	Note        pgtype.Text        `json:"note"`
+	CancelledBy pgtype.UUID        `json:"cancelled_by"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CourtRating struct {
+	CourtID          pgtype.UUID        `json:"court_id"`
+	ReviewCount      int32              `json:"review_count"`
+	AvgRating        pgtype.Numeric     `json:"avg_rating"`
+	AvgSurface       pgtype.Numeric     `json:"avg_surface"`
+	AvgLighting      pgtype.Numeric     `json:"avg_lighting"`
+	AvgChangingRooms pgtype.Numeric     `json:"avg_changing_rooms"`
+	AvgValue         pgtype.Numeric     `json:"avg_value"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CourtReview struct {
+	ID                 pgtype.UUID        `json:"id"`
+	CourtID            pgtype.UUID        `json:"court_id"`
+	UserID             pgtype.UUID        `json:"user_id"`
+	SurfaceScore       pgtype.Int2        `json:"surface_score"`
+	LightingScore      pgtype.Int2        `json:"lighting_score"`
+	ChangingRoomsScore pgtype.Int2        `json:"changing_rooms_score"`
+	ValueScore         pgtype.Int2        `json:"value_score"`
+	Body               pgtype.Text        `json:"body"`
+	Status             ReviewStatus       `json:"status"`
+	ModerationNote     pgtype.Text        `json:"moderation_note"`
+	ModeratedBy        pgtype.UUID        `json:"moderated_by"`
+	ModeratedAt        pgtype.Timestamptz `json:"moderated_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Event struct {
@@ -1022,10 +1774,47 @@ type EventParticipant struct {
 }
 
 type Friend struct {
-	ID        pgtype.UUID        `json:"id"`
-	UserID    pgtype.UUID        `json:"user_id"`
-	FriendID  pgtype.UUID        `json:"friend_id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	FriendID   pgtype.UUID        `json:"friend_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	Status     FriendStatus       `json:"status"`
+	AcceptedAt pgtype.Timestamptz `json:"accepted_at"`
+}
+
+type LadderChallenge struct {
+	ID                 pgtype.UUID           `json:"id"`
+	CommunityID        pgtype.UUID           `json:"community_id"`
+	ChallengerID       pgtype.UUID           `json:"challenger_id"`
+	DefenderID         pgtype.UUID           `json:"defender_id"`
+	ChallengerPosition int32                 `json:"challenger_position"`
+	DefenderPosition   int32                 `json:"defender_position"`
+	Status             LadderChallengeStatus `json:"status"`
+	AcceptDeadline     pgtype.Timestamptz    `json:"accept_deadline"`
+	PlayDeadline       pgtype.Timestamptz    `json:"play_deadline"`
+	MatchID            pgtype.UUID           `json:"match_id"`
+	WinnerID           pgtype.UUID           `json:"winner_id"`
+	CreatedAt          pgtype.Timestamptz    `json:"created_at"`
+	RespondedAt        pgtype.Timestamptz    `json:"responded_at"`
+	ResolvedAt         pgtype.Timestamptz    `json:"resolved_at"`
+}
+
+type LadderHistory struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	OldPosition pgtype.Int4        `json:"old_position"`
+	NewPosition pgtype.Int4        `json:"new_position"`
+	Reason      LadderChangeReason `json:"reason"`
+	ChallengeID pgtype.UUID        `json:"challenge_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type LadderPosition struct {
+	CommunityID pgtype.UUID        `json:"community_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Position    int32              `json:"position"`
+	JoinedAt    pgtype.Timestamptz `json:"joined_at"`
 }
 
 type Match struct {
@@ -1058,6 +1847,39 @@ type Match struct {
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
 }
 
+type MembershipDue struct {
+	ID                pgtype.UUID        `json:"id"`
+	CommunityID       pgtype.UUID        `json:"community_id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	PlanID            pgtype.UUID        `json:"plan_id"`
+	Amount            pgtype.Numeric     `json:"amount"`
+	Currency          string             `json:"currency"`
+	PeriodStart       pgtype.Date        `json:"period_start"`
+	PeriodEnd         pgtype.Date        `json:"period_end"`
+	Status            DuesStatus         `json:"status"`
+	PaymentMethod     PaymentMethod      `json:"payment_method"`
+	PaymentReference  pgtype.Text        `json:"payment_reference"`
+	Provider          pgtype.Text        `json:"provider"`
+	ProviderPaymentID pgtype.Text        `json:"provider_payment_id"`
+	RecordedBy        pgtype.UUID        `json:"recorded_by"`
+	PaidAt            pgtype.Timestamptz `json:"paid_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+type MembershipPlan struct {
+	ID             pgtype.UUID        `json:"id"`
+	CommunityID    pgtype.UUID        `json:"community_id"`
+	Name           string             `json:"name"`
+	Period         MembershipPeriod   `json:"period"`
+	DurationMonths int16              `json:"duration_months"`
+	PriceAmount    pgtype.Numeric     `json:"price_amount"`
+	PriceCurrency  string             `json:"price_currency"`
+	IsActive       bool               `json:"is_active"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Message struct {
 	ID        pgtype.UUID        `json:"id"`
 	ChatID    pgtype.UUID        `json:"chat_id"`
@@ -1127,6 +1949,17 @@ type Post struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
 
+type PostComment struct {
+	ID        pgtype.UUID        `json:"id"`
+	PostID    pgtype.UUID        `json:"post_id"`
+	ParentID  pgtype.UUID        `json:"parent_id"`
+	AuthorID  pgtype.UUID        `json:"author_id"`
+	Content   string             `json:"content"`
+	Mentions  []pgtype.UUID      `json:"mentions"`
+	EditedAt  pgtype.Timestamptz `json:"edited_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type PostLike struct {
 	PostID    pgtype.UUID        `json:"post_id"`
 	UserID    pgtype.UUID        `json:"user_id"`
@@ -1155,6 +1988,59 @@ type RefreshToken struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type SentReminder struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	TargetType   string             `json:"target_type"`
+	TargetID     pgtype.UUID        `json:"target_id"`
+	ReminderType string             `json:"reminder_type"`
+	SentAt       pgtype.Timestamptz `json:"sent_at"`
+}
+
+type Team struct {
+	ID          pgtype.UUID        `json:"id"`
+	CommunityID pgtype.UUID        `json:"community_id"`
+	Name        string             `json:"name"`
+	CaptainID   pgtype.UUID        `json:"captain_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TeamMember struct {
+	TeamID   pgtype.UUID        `json:"team_id"`
+	UserID   pgtype.UUID        `json:"user_id"`
+	JoinedAt pgtype.Timestamptz `json:"joined_at"`
+}
+
+type TeamTie struct {
+	ID              pgtype.UUID        `json:"id"`
+	CommunityID     pgtype.UUID        `json:"community_id"`
+	EventID         pgtype.UUID        `json:"event_id"`
+	HomeTeamID      pgtype.UUID        `json:"home_team_id"`
+	AwayTeamID      pgtype.UUID        `json:"away_team_id"`
+	ScheduledTime   pgtype.Timestamptz `json:"scheduled_time"`
+	LineupsLockedAt pgtype.Timestamptz `json:"lineups_locked_at"`
+	CreatedBy       pgtype.UUID        `json:"created_by"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type TeamTieLineup struct {
+	TieID        pgtype.UUID        `json:"tie_id"`
+	TeamID       pgtype.UUID        `json:"team_id"`
+	RubberNumber int32              `json:"rubber_number"`
+	PlayerID     pgtype.UUID        `json:"player_id"`
+	PartnerID    pgtype.UUID        `json:"partner_id"`
+	SubmittedBy  pgtype.UUID        `json:"submitted_by"`
+	SubmittedAt  pgtype.Timestamptz `json:"submitted_at"`
+}
+
+type TeamTieRubber struct {
+	TieID        pgtype.UUID       `json:"tie_id"`
+	RubberNumber int32             `json:"rubber_number"`
+	Composition  PlayerComposition `json:"composition"`
+	MatchID      pgtype.UUID       `json:"match_id"`
+}
+
 type User struct {
 	ID                   pgtype.UUID        `json:"id"`
 	Phone                string             `json:"phone"`
@@ -1185,6 +2071,7 @@ type User struct {
 	LastActiveAt         pgtype.Timestamptz `json:"last_active_at"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	ShareMatchResults    bool               `json:"share_match_results"`
 }
 
 type UserBadge struct {
@@ -1194,6 +2081,13 @@ type UserBadge struct {
 	EarnedAt pgtype.Timestamptz `json:"earned_at"`
 }
 
+type UserLocation struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Latitude  float64            `json:"latitude"`
+	Longitude float64            `json:"longitude"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type VActiveEvent struct {
 	ID                   pgtype.UUID          `json:"id"`
 	Title                string               `json:"title"`
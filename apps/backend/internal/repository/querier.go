@@ -11,55 +11,186 @@ import (
 )
 
 type Querier interface {
+	AcceptFriendRequest(ctx context.Context, arg AcceptFriendRequestParams) (int64, error)
+	AcceptLadderChallenge(ctx context.Context, arg AcceptLadderChallengeParams) (LadderChallenge, error)
+	AddAcceptedFriend(ctx context.Context, arg AddAcceptedFriendParams) error
 	AddCommunityMember(ctx context.Context, arg AddCommunityMemberParams) (CommunityMember, error)
 	AddEventParticipant(ctx context.Context, arg AddEventParticipantParams) (EventParticipant, error)
+	AddImportedCommunityMember(ctx context.Context, arg AddImportedCommunityMemberParams) (int64, error)
+	AddLadderPosition(ctx context.Context, arg AddLadderPositionParams) (LadderPosition, error)
+	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) (int64, error)
 	AdminConfirmMatch(ctx context.Context, arg AdminConfirmMatchParams) (Match, error)
+	CancelCourtBooking(ctx context.Context, arg CancelCourtBookingParams) (CourtBooking, error)
+	CancelEventCourtBookings(ctx context.Context, eventID pgtype.UUID) (int64, error)
+	CancelMembershipDues(ctx context.Context, id pgtype.UUID) (int64, error)
+	CancelScheduledPost(ctx context.Context, arg CancelScheduledPostParams) ([]byte, error)
 	CheckFriendship(ctx context.Context, arg CheckFriendshipParams) (bool, error)
+	ClaimCommunityInvite(ctx context.Context, id pgtype.UUID) (int64, error)
+	ClaimPlaceholderUser(ctx context.Context, id pgtype.UUID) error
+	ClaimReminder(ctx context.Context, arg ClaimReminderParams) (int64, error)
+	CloseCommunityDeactivation(ctx context.Context, arg CloseCommunityDeactivationParams) error
+	CommitCommunityImport(ctx context.Context, arg CommitCommunityImportParams) error
+	CommunitySlugExists(ctx context.Context, arg CommunitySlugExistsParams) (bool, error)
+	CompleteCommunityExport(ctx context.Context, arg CompleteCommunityExportParams) error
 	ConfirmMatch(ctx context.Context, arg ConfirmMatchParams) (Match, error)
+	CountCommentReplies(ctx context.Context, parentID pgtype.UUID) (int64, error)
 	CountCommunities(ctx context.Context, arg CountCommunitiesParams) (int64, error)
+	CountCommunityAnnouncements(ctx context.Context, communityID pgtype.UUID) (int64, error)
+	CountCommunityAttendance(ctx context.Context, arg CountCommunityAttendanceParams) (int64, error)
+	CountCommunityDues(ctx context.Context, arg CountCommunityDuesParams) (int64, error)
+	CountCommunityMatches(ctx context.Context, communityID pgtype.UUID) (int64, error)
 	CountCommunityMembers(ctx context.Context, arg CountCommunityMembersParams) (int64, error)
+	CountCommunityTeamTies(ctx context.Context, communityID pgtype.UUID) (int64, error)
+	CountCourtReviews(ctx context.Context, courtID pgtype.UUID) (int64, error)
+	CountCourtReviewsByStatus(ctx context.Context, status ReviewStatus) (int64, error)
+	CountCourts(ctx context.Context, arg CountCourtsParams) (int64, error)
+	CountCourtsByStatus(ctx context.Context, status NullCourtStatus) (int64, error)
+	CountDeactivatedCommunities(ctx context.Context) (int64, error)
 	CountEvents(ctx context.Context, arg CountEventsParams) (int64, error)
+	CountFriends(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CountGlobalLeaderboard(ctx context.Context, minGames pgtype.Int4) (int64, error)
+	CountIncomingFriendRequests(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountLadderChallenges(ctx context.Context, arg CountLadderChallengesParams) (int64, error)
+	CountLadderHistory(ctx context.Context, arg CountLadderHistoryParams) (int64, error)
 	CountMutualCommunities(ctx context.Context, arg CountMutualCommunitiesParams) (int64, error)
+	CountMutualFriends(ctx context.Context, arg CountMutualFriendsParams) (int64, error)
 	CountMyMatches(ctx context.Context, arg CountMyMatchesParams) (int64, error)
 	CountNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountOpenLadderChallenges(ctx context.Context, arg CountOpenLadderChallengesParams) (int64, error)
+	CountOutgoingFriendRequests(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountPostComments(ctx context.Context, postID pgtype.UUID) (int64, error)
+	CountScheduledPosts(ctx context.Context, communityID pgtype.UUID) (int64, error)
 	CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error)
+	CountVerificationRequests(ctx context.Context, status VerificationStatus) (int64, error)
+	CreateAnnouncementNotifications(ctx context.Context, arg CreateAnnouncementNotificationsParams) ([]pgtype.UUID, error)
+	// Audit log queries
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
+	// Post comment queries
+	CreateComment(ctx context.Context, arg CreateCommentParams) (PostComment, error)
 	CreateCommunity(ctx context.Context, arg CreateCommunityParams) (Community, error)
+	// Community announcement queries
+	CreateCommunityAnnouncement(ctx context.Context, arg CreateCommunityAnnouncementParams) (CommunityAnnouncement, error)
 	CreateCommunityChat(ctx context.Context, arg CreateCommunityChatParams) (CreateCommunityChatRow, error)
+	CreateCommunityDeactivation(ctx context.Context, arg CreateCommunityDeactivationParams) (CommunityDeactivation, error)
+	// Community export queries
+	CreateCommunityExport(ctx context.Context, arg CreateCommunityExportParams) (CommunityExport, error)
+	// Community import queries
+	CreateCommunityImport(ctx context.Context, arg CreateCommunityImportParams) (CommunityImport, error)
+	// Community invite queries
+	CreateCommunityInvite(ctx context.Context, arg CreateCommunityInviteParams) (CommunityInvite, error)
+	CreateCommunityPostNotifications(ctx context.Context, arg CreateCommunityPostNotificationsParams) error
+	CreateCourt(ctx context.Context, arg CreateCourtParams) (Court, error)
+	// Court booking queries
+	CreateCourtBooking(ctx context.Context, arg CreateCourtBookingParams) (CourtBooking, error)
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
 	CreateEventChat(ctx context.Context, arg CreateEventChatParams) (CreateEventChatRow, error)
+	CreateFriendRequest(ctx context.Context, arg CreateFriendRequestParams) (Friend, error)
+	CreateImportInvite(ctx context.Context, arg CreateImportInviteParams) (int64, error)
+	CreateImportedMatch(ctx context.Context, arg CreateImportedMatchParams) (pgtype.UUID, error)
+	CreateLadderChallenge(ctx context.Context, arg CreateLadderChallengeParams) (LadderChallenge, error)
 	CreateMatch(ctx context.Context, arg CreateMatchParams) (Match, error)
+	CreateMatchResultPost(ctx context.Context, arg CreateMatchResultPostParams) (int64, error)
+	CreateMembershipDues(ctx context.Context, arg CreateMembershipDuesParams) (MembershipDue, error)
+	// Paid membership plans and dues ledger queries
+	CreateMembershipPlan(ctx context.Context, arg CreateMembershipPlanParams) (MembershipPlan, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	// Community moderation queries
+	CreateModerationAction(ctx context.Context, arg CreateModerationActionParams) (CommunityModerationAction, error)
 	// Notifications queries
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	// Community ownership transfer and deactivation queries
+	CreateOwnershipTransfer(ctx context.Context, arg CreateOwnershipTransferParams) (CommunityOwnershipTransfer, error)
 	CreatePersonalChat(ctx context.Context, arg CreatePersonalChatParams) (CreatePersonalChatRow, error)
+	CreatePlaceholderUser(ctx context.Context, arg CreatePlaceholderUserParams) (pgtype.UUID, error)
+	// Post queries
+	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
+	// Team competition queries
+	CreateTeam(ctx context.Context, arg CreateTeamParams) (Team, error)
+	CreateTeamLineupEntry(ctx context.Context, arg CreateTeamLineupEntryParams) error
+	CreateTeamTie(ctx context.Context, arg CreateTeamTieParams) (TeamTie, error)
+	CreateTeamTieRubber(ctx context.Context, arg CreateTeamTieRubberParams) error
 	CreateUser(ctx context.Context, phone string) (User, error)
+	// Community verification queries
+	CreateVerificationRequest(ctx context.Context, arg CreateVerificationRequestParams) (CommunityVerificationRequest, error)
+	DeactivateCourt(ctx context.Context, id pgtype.UUID) error
+	DeleteComment(ctx context.Context, id pgtype.UUID) error
 	DeleteCommunityMember(ctx context.Context, arg DeleteCommunityMemberParams) error
+	DeleteCourtReview(ctx context.Context, arg DeleteCourtReviewParams) (int64, error)
 	DeleteEvent(ctx context.Context, id pgtype.UUID) error
+	DeleteFriendRequest(ctx context.Context, arg DeleteFriendRequestParams) (int64, error)
+	DeleteFriendship(ctx context.Context, arg DeleteFriendshipParams) (int64, error)
+	DeleteLadderPosition(ctx context.Context, arg DeleteLadderPositionParams) error
 	DeleteNotification(ctx context.Context, arg DeleteNotificationParams) error
+	DeletePost(ctx context.Context, id pgtype.UUID) error
+	DeleteTeamLineup(ctx context.Context, arg DeleteTeamLineupParams) error
+	DeleteUserLocation(ctx context.Context, userID pgtype.UUID) error
 	DisputeMatch(ctx context.Context, arg DisputeMatchParams) (Match, error)
+	ExpireCommunityMember(ctx context.Context, arg ExpireCommunityMemberParams) (int64, error)
+	ExportCommunityAttendance(ctx context.Context, arg ExportCommunityAttendanceParams) ([]ExportCommunityAttendanceRow, error)
+	ExportCommunityMatches(ctx context.Context, arg ExportCommunityMatchesParams) ([]ExportCommunityMatchesRow, error)
+	ExportCommunityMembers(ctx context.Context, arg ExportCommunityMembersParams) ([]ExportCommunityMembersRow, error)
+	FailCommunityExport(ctx context.Context, arg FailCommunityExportParams) error
 	GetCalendarEvents(ctx context.Context, arg GetCalendarEventsParams) ([]GetCalendarEventsRow, error)
+	// Calendar feed queries
+	GetCalendarToken(ctx context.Context, userID pgtype.UUID) (CalendarToken, error)
+	GetCalendarTokenByToken(ctx context.Context, token string) (CalendarToken, error)
 	GetChatByID(ctx context.Context, id pgtype.UUID) (GetChatByIDRow, error)
 	GetChatMembersForCommunity(ctx context.Context, chatID pgtype.UUID) ([]pgtype.UUID, error)
 	GetChatMembersForEvent(ctx context.Context, chatID pgtype.UUID) ([]pgtype.UUID, error)
 	GetChatMembersForPersonal(ctx context.Context, id pgtype.UUID) ([]GetChatMembersForPersonalRow, error)
+	GetComment(ctx context.Context, id pgtype.UUID) (PostComment, error)
+	GetCommentView(ctx context.Context, id pgtype.UUID) (GetCommentViewRow, error)
+	GetCommunityActiveState(ctx context.Context, id pgtype.UUID) (GetCommunityActiveStateRow, error)
+	GetCommunityAnnouncement(ctx context.Context, arg GetCommunityAnnouncementParams) (GetCommunityAnnouncementRow, error)
+	GetCommunityAttendance(ctx context.Context, arg GetCommunityAttendanceParams) (GetCommunityAttendanceRow, error)
 	GetCommunityBasicInfo(ctx context.Context, id pgtype.UUID) (GetCommunityBasicInfoRow, error)
 	GetCommunityByID(ctx context.Context, id pgtype.UUID) (Community, error)
 	GetCommunityBySlug(ctx context.Context, slug pgtype.Text) (Community, error)
 	GetCommunityChatByCommunityID(ctx context.Context, communityID pgtype.UUID) (GetCommunityChatByCommunityIDRow, error)
+	GetCommunityEventSummary(ctx context.Context, arg GetCommunityEventSummaryParams) (GetCommunityEventSummaryRow, error)
+	GetCommunityExport(ctx context.Context, arg GetCommunityExportParams) (CommunityExport, error)
+	GetCommunityImport(ctx context.Context, arg GetCommunityImportParams) (CommunityImport, error)
+	GetCommunityInviteByCode(ctx context.Context, code string) (GetCommunityInviteByCodeRow, error)
+	// Community ladder queries
+	GetCommunityLadder(ctx context.Context, communityID pgtype.UUID) (CommunityLadder, error)
 	GetCommunityLeaderboard(ctx context.Context, arg GetCommunityLeaderboardParams) ([]GetCommunityLeaderboardRow, error)
+	GetCommunityMatchSummary(ctx context.Context, communityID pgtype.UUID) (GetCommunityMatchSummaryRow, error)
 	GetCommunityMember(ctx context.Context, arg GetCommunityMemberParams) (CommunityMember, error)
+	// Community admin dashboard aggregates
+	GetCommunityMemberGrowth(ctx context.Context, arg GetCommunityMemberGrowthParams) ([]GetCommunityMemberGrowthRow, error)
+	GetCommunityMemberSummary(ctx context.Context, arg GetCommunityMemberSummaryParams) (GetCommunityMemberSummaryRow, error)
+	GetCommunityNewsSetting(ctx context.Context, id pgtype.UUID) (bool, error)
+	GetCommunityRatingDistribution(ctx context.Context, arg GetCommunityRatingDistributionParams) ([]GetCommunityRatingDistributionRow, error)
+	GetCourtBookingByID(ctx context.Context, id pgtype.UUID) (CourtBooking, error)
+	GetCourtByID(ctx context.Context, id pgtype.UUID) (Court, error)
+	GetCourtRating(ctx context.Context, courtID pgtype.UUID) (CourtRating, error)
+	GetCourtReviewByID(ctx context.Context, id pgtype.UUID) (CourtReview, error)
 	GetEventBasicInfo(ctx context.Context, id pgtype.UUID) (GetEventBasicInfoRow, error)
 	GetEventByID(ctx context.Context, id pgtype.UUID) (Event, error)
 	GetEventChatByEventID(ctx context.Context, eventID pgtype.UUID) (GetEventChatByEventIDRow, error)
 	GetEventParticipant(ctx context.Context, arg GetEventParticipantParams) (EventParticipant, error)
+	// Friend queries
+	GetFriendLink(ctx context.Context, arg GetFriendLinkParams) (Friend, error)
 	GetGlobalLeaderboard(ctx context.Context, arg GetGlobalLeaderboardParams) ([]GetGlobalLeaderboardRow, error)
+	GetLadderBottom(ctx context.Context, communityID pgtype.UUID) (int32, error)
+	GetLadderChallengeByMatch(ctx context.Context, matchID pgtype.UUID) (LadderChallenge, error)
+	GetLadderPosition(ctx context.Context, arg GetLadderPositionParams) (LadderPosition, error)
+	GetLatestBan(ctx context.Context, arg GetLatestBanParams) (CommunityModerationAction, error)
+	GetLatestVerificationRequest(ctx context.Context, communityID pgtype.UUID) (CommunityVerificationRequest, error)
 	GetMatchByID(ctx context.Context, id pgtype.UUID) (Match, error)
+	GetMemberPaidUntil(ctx context.Context, arg GetMemberPaidUntilParams) (pgtype.Date, error)
+	GetMembershipDuesByProviderPayment(ctx context.Context, arg GetMembershipDuesByProviderPaymentParams) (MembershipDue, error)
+	GetMembershipPlan(ctx context.Context, arg GetMembershipPlanParams) (MembershipPlan, error)
 	GetMessageByID(ctx context.Context, id pgtype.UUID) (Message, error)
 	GetMessages(ctx context.Context, arg GetMessagesParams) ([]GetMessagesRow, error)
+	GetPendingOwnershipTransfer(ctx context.Context, communityID pgtype.UUID) (CommunityOwnershipTransfer, error)
 	GetPersonalChat(ctx context.Context, arg GetPersonalChatParams) (GetPersonalChatRow, error)
 	GetPlayerTotalGames(ctx context.Context, userID pgtype.UUID) (int32, error)
+	GetPostByID(ctx context.Context, id pgtype.UUID) (Post, error)
 	GetRatingHistory(ctx context.Context, arg GetRatingHistoryParams) ([]RatingHistory, error)
+	GetScheduledPost(ctx context.Context, arg GetScheduledPostParams) (Post, error)
+	GetTeam(ctx context.Context, id pgtype.UUID) (Team, error)
+	GetTeamTie(ctx context.Context, id pgtype.UUID) (TeamTie, error)
 	GetTotalUnreadCount(ctx context.Context, userID pgtype.UUID) (int32, error)
 	GetUnreadNotificationCount(ctx context.Context, userID pgtype.UUID) (int64, error)
 	GetUserBadges(ctx context.Context, userID pgtype.UUID) ([]GetUserBadgesRow, error)
@@ -69,14 +200,61 @@ type Querier interface {
 	GetUserCommunities(ctx context.Context, userID pgtype.UUID) ([]GetUserCommunitiesRow, error)
 	GetUserCommunityRatings(ctx context.Context, userID pgtype.UUID) ([]GetUserCommunityRatingsRow, error)
 	GetUserForRating(ctx context.Context, id pgtype.UUID) (GetUserForRatingRow, error)
+	GetUserLocation(ctx context.Context, userID pgtype.UUID) (UserLocation, error)
 	GetUserRatingPosition(ctx context.Context, userID pgtype.UUID) (GetUserRatingPositionRow, error)
 	GetUserStats(ctx context.Context, userID pgtype.UUID) (PlayerStatsGlobal, error)
+	GetUserStatusByPhone(ctx context.Context, phone string) (GetUserStatusByPhoneRow, error)
+	GetVisiblePost(ctx context.Context, arg GetVisiblePostParams) (GetVisiblePostRow, error)
+	HasPlayedAtCourt(ctx context.Context, arg HasPlayedAtCourtParams) (bool, error)
+	InsertLadderHistory(ctx context.Context, arg InsertLadderHistoryParams) error
 	InsertRatingHistory(ctx context.Context, arg InsertRatingHistoryParams) (RatingHistory, error)
 	IsUserInChat(ctx context.Context, arg IsUserInChatParams) (bool, error)
+	LikePost(ctx context.Context, arg LikePostParams) (pgtype.Int4, error)
+	ListCommentReplies(ctx context.Context, arg ListCommentRepliesParams) ([]ListCommentRepliesRow, error)
 	ListCommunities(ctx context.Context, arg ListCommunitiesParams) ([]ListCommunitiesRow, error)
+	ListCommunityAnnouncements(ctx context.Context, arg ListCommunityAnnouncementsParams) ([]ListCommunityAnnouncementsRow, error)
+	ListCommunityCalendarEvents(ctx context.Context, arg ListCommunityCalendarEventsParams) ([]ListCommunityCalendarEventsRow, error)
+	ListCommunityDues(ctx context.Context, arg ListCommunityDuesParams) ([]ListCommunityDuesRow, error)
+	ListCommunityExports(ctx context.Context, communityID pgtype.UUID) ([]CommunityExport, error)
+	ListCommunityImports(ctx context.Context, communityID pgtype.UUID) ([]CommunityImport, error)
+	ListCommunityInviteUses(ctx context.Context, arg ListCommunityInviteUsesParams) ([]ListCommunityInviteUsesRow, error)
+	ListCommunityInvites(ctx context.Context, communityID pgtype.UUID) ([]ListCommunityInvitesRow, error)
+	ListCommunityMemberPhones(ctx context.Context, communityID pgtype.UUID) ([]ListCommunityMemberPhonesRow, error)
 	ListCommunityMembers(ctx context.Context, arg ListCommunityMembersParams) ([]ListCommunityMembersRow, error)
+	ListCommunityTeamTies(ctx context.Context, arg ListCommunityTeamTiesParams) ([]ListCommunityTeamTiesRow, error)
+	ListCommunityTeams(ctx context.Context, communityID pgtype.UUID) ([]ListCommunityTeamsRow, error)
+	ListCourtBookingsForRange(ctx context.Context, arg ListCourtBookingsForRangeParams) ([]CourtBooking, error)
+	ListCourtRatings(ctx context.Context, courtIds []pgtype.UUID) ([]CourtRating, error)
+	ListCourtReviews(ctx context.Context, arg ListCourtReviewsParams) ([]ListCourtReviewsRow, error)
+	ListCourtReviewsByStatus(ctx context.Context, arg ListCourtReviewsByStatusParams) ([]ListCourtReviewsByStatusRow, error)
+	ListCourts(ctx context.Context, arg ListCourtsParams) ([]Court, error)
+	ListCourtsByStatus(ctx context.Context, arg ListCourtsByStatusParams) ([]Court, error)
+	ListCourtsInBounds(ctx context.Context, arg ListCourtsInBoundsParams) ([]ListCourtsInBoundsRow, error)
+	// Proximity search queries (earthdistance, distances in meters)
+	ListCourtsNearby(ctx context.Context, arg ListCourtsNearbyParams) ([]ListCourtsNearbyRow, error)
+	ListDeactivatedCommunities(ctx context.Context, arg ListDeactivatedCommunitiesParams) ([]ListDeactivatedCommunitiesRow, error)
+	// Game reminder queries
+	ListDueEventReminders(ctx context.Context, arg ListDueEventRemindersParams) ([]ListDueEventRemindersRow, error)
+	ListDueLadderChallenges(ctx context.Context, arg ListDueLadderChallengesParams) ([]LadderChallenge, error)
+	ListDueMatchReminders(ctx context.Context, arg ListDueMatchRemindersParams) ([]ListDueMatchRemindersRow, error)
+	ListEndingMemberships(ctx context.Context, arg ListEndingMembershipsParams) ([]ListEndingMembershipsRow, error)
 	ListEventParticipants(ctx context.Context, eventID pgtype.UUID) ([]ListEventParticipantsRow, error)
+	ListEventTeamTies(ctx context.Context, eventID pgtype.UUID) ([]ListEventTeamTiesRow, error)
 	ListEvents(ctx context.Context, arg ListEventsParams) ([]ListEventsRow, error)
+	ListEventsForLifecycle(ctx context.Context, arg ListEventsForLifecycleParams) ([]ListEventsForLifecycleRow, error)
+	ListEventsNearby(ctx context.Context, arg ListEventsNearbyParams) ([]ListEventsNearbyRow, error)
+	ListFeed(ctx context.Context, arg ListFeedParams) ([]ListFeedRow, error)
+	ListFriendSuggestions(ctx context.Context, arg ListFriendSuggestionsParams) ([]ListFriendSuggestionsRow, error)
+	ListFriends(ctx context.Context, arg ListFriendsParams) ([]ListFriendsRow, error)
+	ListIncomingFriendRequests(ctx context.Context, arg ListIncomingFriendRequestsParams) ([]ListIncomingFriendRequestsRow, error)
+	ListLadderChallenges(ctx context.Context, arg ListLadderChallengesParams) ([]ListLadderChallengesRow, error)
+	ListLadderHistory(ctx context.Context, arg ListLadderHistoryParams) ([]ListLadderHistoryRow, error)
+	ListLadderPositions(ctx context.Context, communityID pgtype.UUID) ([]ListLadderPositionsRow, error)
+	ListMatchPostPlayers(ctx context.Context, userIds []pgtype.UUID) ([]ListMatchPostPlayersRow, error)
+	ListMemberCommunityIDs(ctx context.Context, userID pgtype.UUID) ([]pgtype.UUID, error)
+	ListMemberDues(ctx context.Context, arg ListMemberDuesParams) ([]MembershipDue, error)
+	ListMembershipPlans(ctx context.Context, arg ListMembershipPlansParams) ([]MembershipPlan, error)
+	ListModerationActions(ctx context.Context, arg ListModerationActionsParams) ([]ListModerationActionsRow, error)
 	ListMyChats(ctx context.Context, userID pgtype.UUID) ([]ListMyChatsRow, error)
 	ListMyCommunities(ctx context.Context, userID pgtype.UUID) ([]ListMyCommunitiesRow, error)
 	ListMyCreatedEvents(ctx context.Context, arg ListMyCreatedEventsParams) ([]ListMyCreatedEventsRow, error)
@@ -84,25 +262,78 @@ type Querier interface {
 	ListMyMatches(ctx context.Context, arg ListMyMatchesParams) ([]Match, error)
 	ListMyPastEvents(ctx context.Context, arg ListMyPastEventsParams) ([]ListMyPastEventsRow, error)
 	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOutgoingFriendRequests(ctx context.Context, arg ListOutgoingFriendRequestsParams) ([]ListOutgoingFriendRequestsRow, error)
+	ListPlayersNearby(ctx context.Context, arg ListPlayersNearbyParams) ([]ListPlayersNearbyRow, error)
+	ListPostAudience(ctx context.Context, arg ListPostAudienceParams) ([]pgtype.UUID, error)
+	ListPostComments(ctx context.Context, arg ListPostCommentsParams) ([]ListPostCommentsRow, error)
+	ListReplyPreviews(ctx context.Context, arg ListReplyPreviewsParams) ([]ListReplyPreviewsRow, error)
+	ListScheduledPosts(ctx context.Context, arg ListScheduledPostsParams) ([]Post, error)
+	ListTeamMembers(ctx context.Context, teamID pgtype.UUID) ([]ListTeamMembersRow, error)
+	ListTeamTieLineups(ctx context.Context, tieID pgtype.UUID) ([]ListTeamTieLineupsRow, error)
+	ListTeamTieRubbers(ctx context.Context, tieID pgtype.UUID) ([]ListTeamTieRubbersRow, error)
+	ListUserCalendarEvents(ctx context.Context, arg ListUserCalendarEventsParams) ([]ListUserCalendarEventsRow, error)
+	ListUserCalendarMatches(ctx context.Context, arg ListUserCalendarMatchesParams) ([]ListUserCalendarMatchesRow, error)
+	ListUserCourtBookings(ctx context.Context, arg ListUserCourtBookingsParams) ([]ListUserCourtBookingsRow, error)
+	ListVerificationRequests(ctx context.Context, arg ListVerificationRequestsParams) ([]ListVerificationRequestsRow, error)
+	LockCommunityImport(ctx context.Context, arg LockCommunityImportParams) (CommunityImport, error)
+	LockCommunityLadder(ctx context.Context, communityID pgtype.UUID) (CommunityLadder, error)
+	LockFriendPair(ctx context.Context, arg LockFriendPairParams) error
+	LockLadderChallenge(ctx context.Context, arg LockLadderChallengeParams) (LadderChallenge, error)
+	LockMemberDues(ctx context.Context, arg LockMemberDuesParams) error
+	LockTeamTie(ctx context.Context, id pgtype.UUID) (TeamTie, error)
 	MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) error
 	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) error
+	MergeNotificationSettings(ctx context.Context, arg MergeNotificationSettingsParams) ([]byte, error)
+	ModerateCourt(ctx context.Context, arg ModerateCourtParams) (Court, error)
+	ModerateCourtReview(ctx context.Context, arg ModerateCourtReviewParams) (CourtReview, error)
+	PublishDuePosts(ctx context.Context, arg PublishDuePostsParams) ([]PublishDuePostsRow, error)
+	RecordCommunityInviteUse(ctx context.Context, arg RecordCommunityInviteUseParams) error
+	RefreshCourtRating(ctx context.Context, courtID pgtype.UUID) error
+	ReleaseReminder(ctx context.Context, arg ReleaseReminderParams) error
 	RemoveEventParticipant(ctx context.Context, arg RemoveEventParticipantParams) error
+	RemoveTeamMember(ctx context.Context, arg RemoveTeamMemberParams) (int64, error)
+	ResolveLadderChallenge(ctx context.Context, arg ResolveLadderChallengeParams) (LadderChallenge, error)
+	ReviewVerificationRequest(ctx context.Context, arg ReviewVerificationRequestParams) (CommunityVerificationRequest, error)
+	RevokeCommunityInvite(ctx context.Context, arg RevokeCommunityInviteParams) (int64, error)
 	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetAnnouncementCounts(ctx context.Context, arg SetAnnouncementCountsParams) error
+	SetCommunityActive(ctx context.Context, arg SetCommunityActiveParams) (int64, error)
+	SetCommunityChatArchived(ctx context.Context, arg SetCommunityChatArchivedParams) error
+	SetCommunityVerification(ctx context.Context, arg SetCommunityVerificationParams) error
+	SetLadderPosition(ctx context.Context, arg SetLadderPositionParams) error
+	SetOwnershipTransferStatus(ctx context.Context, arg SetOwnershipTransferStatusParams) (CommunityOwnershipTransfer, error)
+	SetTeamTieLineupsLocked(ctx context.Context, id pgtype.UUID) error
+	SetTeamTieRubberMatch(ctx context.Context, arg SetTeamTieRubberMatchParams) error
+	SettleMembershipDues(ctx context.Context, arg SettleMembershipDuesParams) (MembershipDue, error)
+	ShiftLadderPositions(ctx context.Context, arg ShiftLadderPositionsParams) error
+	StartCommunityExport(ctx context.Context, id pgtype.UUID) error
 	SubmitMatchResult(ctx context.Context, arg SubmitMatchResultParams) (Match, error)
+	TransitionEventStatus(ctx context.Context, arg TransitionEventStatusParams) (int64, error)
+	UnlikePost(ctx context.Context, arg UnlikePostParams) (pgtype.Int4, error)
 	UpdateChatLastMessage(ctx context.Context, arg UpdateChatLastMessageParams) error
 	UpdateChatMuted(ctx context.Context, arg UpdateChatMutedParams) error
+	UpdateComment(ctx context.Context, arg UpdateCommentParams) (PostComment, error)
 	UpdateCommunity(ctx context.Context, arg UpdateCommunityParams) (Community, error)
 	UpdateCommunityMemberRole(ctx context.Context, arg UpdateCommunityMemberRoleParams) (CommunityMember, error)
 	UpdateCommunityMemberStats(ctx context.Context, arg UpdateCommunityMemberStatsParams) error
 	UpdateCommunityMemberStatus(ctx context.Context, arg UpdateCommunityMemberStatusParams) (CommunityMember, error)
+	UpdateCourt(ctx context.Context, arg UpdateCourtParams) (Court, error)
 	UpdateEvent(ctx context.Context, arg UpdateEventParams) (UpdateEventRow, error)
 	UpdateEventStatus(ctx context.Context, arg UpdateEventStatusParams) (UpdateEventStatusRow, error)
+	UpdateMembershipPlan(ctx context.Context, arg UpdateMembershipPlanParams) (MembershipPlan, error)
+	UpdateScheduledPost(ctx context.Context, arg UpdateScheduledPostParams) (Post, error)
+	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserAvatarURL(ctx context.Context, arg UpdateUserAvatarURLParams) (UpdateUserAvatarURLRow, error)
 	UpdateUserNTRPLevel(ctx context.Context, arg UpdateUserNTRPLevelParams) error
 	UpdateUserRating(ctx context.Context, arg UpdateUserRatingParams) error
+	UpsertCalendarToken(ctx context.Context, arg UpsertCalendarTokenParams) (CalendarToken, error)
 	UpsertChatReadStatus(ctx context.Context, arg UpsertChatReadStatusParams) error
+	UpsertCommunityLadder(ctx context.Context, arg UpsertCommunityLadderParams) (CommunityLadder, error)
+	// Court review queries
+	UpsertCourtReview(ctx context.Context, arg UpsertCourtReviewParams) (CourtReview, error)
 	UpsertPlayerStatsGlobal(ctx context.Context, arg UpsertPlayerStatsGlobalParams) error
+	UpsertUserLocation(ctx context.Context, arg UpsertUserLocationParams) (UserLocation, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,71 @@
+-- Community announcement queries
+
+-- name: CreateCommunityAnnouncement :one
+INSERT INTO community_announcements (
+    community_id, author_id, title, body, target_role, min_ntrp, max_ntrp
+) VALUES (
+    @community_id, @author_id, @title, @body,
+    sqlc.narg('target_role'), sqlc.narg('min_ntrp'), sqlc.narg('max_ntrp')
+)
+RETURNING id, community_id, author_id, title, body, target_role, min_ntrp, max_ntrp,
+    recipient_count, push_count, created_at;
+
+-- name: CreateAnnouncementNotifications :many
+INSERT INTO notifications (user_id, type, title, body, data, is_pushed)
+SELECT cm.user_id, 'community_news', @title, @body, @data, TRUE
+FROM community_members cm
+JOIN users u ON u.id = cm.user_id
+WHERE cm.community_id = @community_id
+  AND cm.status = 'active'
+  AND u.status = 'active'
+  AND COALESCE((u.notification_settings ->> 'community_news')::boolean, TRUE)
+  AND cm.user_id != @author_id
+  AND (sqlc.narg('target_role')::community_role IS NULL OR cm.role = sqlc.narg('target_role'))
+  AND (sqlc.narg('min_ntrp')::decimal IS NULL OR u.ntrp_level >= sqlc.narg('min_ntrp'))
+  AND (sqlc.narg('max_ntrp')::decimal IS NULL OR u.ntrp_level <= sqlc.narg('max_ntrp'))
+RETURNING user_id;
+
+-- name: SetAnnouncementCounts :exec
+UPDATE community_announcements SET
+    recipient_count = @recipient_count,
+    push_count = @push_count
+WHERE id = @id;
+
+-- name: GetCommunityAnnouncement :one
+SELECT a.id, a.community_id, a.author_id, a.title, a.body, a.target_role, a.min_ntrp, a.max_ntrp,
+    a.recipient_count, a.push_count, a.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name,
+    (SELECT COUNT(*) FROM notifications n
+     WHERE n.type = 'community_news' AND n.data ->> 'announcement_id' = a.id::text AND n.is_read
+    )::int AS read_count
+FROM community_announcements a
+LEFT JOIN users u ON u.id = a.author_id
+WHERE a.id = @id AND a.community_id = @community_id;
+
+-- name: ListCommunityAnnouncements :many
+SELECT a.id, a.community_id, a.author_id, a.title, a.body, a.target_role, a.min_ntrp, a.max_ntrp,
+    a.recipient_count, a.push_count, a.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name,
+    (SELECT COUNT(*) FROM notifications n
+     WHERE n.type = 'community_news' AND n.data ->> 'announcement_id' = a.id::text AND n.is_read
+    )::int AS read_count
+FROM community_announcements a
+LEFT JOIN users u ON u.id = a.author_id
+WHERE a.community_id = @community_id
+ORDER BY a.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommunityAnnouncements :one
+SELECT COUNT(*)
+FROM community_announcements
+WHERE community_id = @community_id;
+
+-- name: GetCommunityNewsSetting :one
+SELECT COALESCE((notification_settings ->> 'community_news')::boolean, TRUE)::boolean AS community_news
+FROM users
+WHERE id = @id;
+
+-- name: ListMemberCommunityIDs :many
+SELECT community_id
+FROM community_members
+WHERE user_id = @user_id AND status = 'active';
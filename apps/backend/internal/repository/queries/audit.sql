@@ -0,0 +1,5 @@
+-- Audit log queries
+
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (actor_id, action, entity_type, entity_id, details)
+VALUES ($1, $2, $3, $4, $5);
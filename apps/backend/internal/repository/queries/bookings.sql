@@ -0,0 +1,59 @@
+-- Court booking queries
+
+-- name: CreateCourtBooking :one
+INSERT INTO court_bookings (
+    court_id, court_number, starts_at, ends_at,
+    booked_by, event_id, total_price, split_count, note
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, court_id, court_number, starts_at, ends_at, status,
+    booked_by, event_id, total_price, currency, split_count, note,
+    cancelled_by, cancelled_at, created_at, updated_at;
+
+-- name: GetCourtBookingByID :one
+SELECT id, court_id, court_number, starts_at, ends_at, status,
+    booked_by, event_id, total_price, currency, split_count, note,
+    cancelled_by, cancelled_at, created_at, updated_at
+FROM court_bookings
+WHERE id = $1;
+
+-- name: ListCourtBookingsForRange :many
+SELECT id, court_id, court_number, starts_at, ends_at, status,
+    booked_by, event_id, total_price, currency, split_count, note,
+    cancelled_by, cancelled_at, created_at, updated_at
+FROM court_bookings
+WHERE court_id = @court_id
+  AND status = 'confirmed'
+  AND starts_at < @range_end
+  AND ends_at > @range_start
+ORDER BY court_number ASC, starts_at ASC;
+
+-- name: ListUserCourtBookings :many
+SELECT b.id, b.court_id, b.court_number, b.starts_at, b.ends_at, b.status,
+       b.event_id, b.total_price, b.currency, b.split_count, b.note,
+       c.name AS court_name, c.address AS court_address
+FROM court_bookings b
+JOIN courts c ON c.id = b.court_id
+WHERE b.booked_by = @user_id
+  AND b.ends_at >= @since
+ORDER BY b.starts_at ASC
+LIMIT 100;
+
+-- name: CancelCourtBooking :one
+UPDATE court_bookings SET
+    status = 'cancelled',
+    cancelled_by = @cancelled_by,
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id AND status = 'confirmed'
+RETURNING id, court_id, court_number, starts_at, ends_at, status,
+    booked_by, event_id, total_price, currency, split_count, note,
+    cancelled_by, cancelled_at, created_at, updated_at;
+
+-- name: CancelEventCourtBookings :execrows
+UPDATE court_bookings SET
+    status = 'cancelled',
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE event_id = $1 AND status = 'confirmed';
@@ -0,0 +1,56 @@
+-- Calendar feed queries
+
+-- name: GetCalendarToken :one
+SELECT user_id, token, created_at
+FROM calendar_tokens
+WHERE user_id = $1;
+
+-- name: GetCalendarTokenByToken :one
+SELECT user_id, token, created_at
+FROM calendar_tokens
+WHERE token = $1;
+
+-- name: UpsertCalendarToken :one
+INSERT INTO calendar_tokens (user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+    token = EXCLUDED.token,
+    created_at = NOW()
+RETURNING user_id, token, created_at;
+
+-- name: ListUserCalendarEvents :many
+SELECT e.id, e.title, e.description, e.status,
+    e.location_name, e.location_address,
+    e.start_time, e.end_time, e.updated_at
+FROM events e
+LEFT JOIN event_participants ep ON ep.event_id = e.id
+    AND ep.user_id = @user_id
+    AND ep.status IN ('registered', 'confirmed', 'checked_in')
+WHERE (e.created_by = @user_id OR ep.id IS NOT NULL)
+  AND e.status != 'draft'
+  AND e.start_time >= @since::timestamptz
+ORDER BY e.start_time ASC;
+
+-- name: ListUserCalendarMatches :many
+SELECT m.id, m.scheduled_time, m.court_number, m.result_status, m.updated_at,
+    u1.first_name AS player1_first_name, u1.last_name AS player1_last_name,
+    u2.first_name AS player2_first_name, u2.last_name AS player2_last_name,
+    e.title AS event_title, e.location_name
+FROM matches m
+JOIN users u1 ON m.player1_id = u1.id
+JOIN users u2 ON m.player2_id = u2.id
+LEFT JOIN events e ON m.event_id = e.id
+WHERE @user_id IN (m.player1_id, m.player2_id, m.player1_partner_id, m.player2_partner_id)
+  AND m.scheduled_time IS NOT NULL
+  AND m.scheduled_time >= @since::timestamptz
+ORDER BY m.scheduled_time ASC;
+
+-- name: ListCommunityCalendarEvents :many
+SELECT id, title, description, status,
+    location_name, location_address,
+    start_time, end_time, updated_at
+FROM events
+WHERE community_id = @community_id
+  AND status != 'draft'
+  AND start_time >= @since::timestamptz
+ORDER BY start_time ASC;
@@ -0,0 +1,79 @@
+-- Post comment queries
+
+-- name: CreateComment :one
+INSERT INTO post_comments (post_id, parent_id, author_id, content, mentions)
+VALUES (@post_id, sqlc.narg('parent_id'), @author_id, @content, @mentions::uuid[])
+RETURNING id, post_id, parent_id, author_id, content, mentions, edited_at, created_at;
+
+-- name: GetComment :one
+SELECT id, post_id, parent_id, author_id, content, mentions, edited_at, created_at
+FROM post_comments
+WHERE id = @id;
+
+-- name: GetCommentView :one
+SELECT pc.id, pc.post_id, pc.parent_id, pc.author_id, pc.content, pc.mentions, pc.edited_at, pc.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url
+FROM post_comments pc
+JOIN users u ON u.id = pc.author_id
+WHERE pc.id = @id;
+
+-- name: UpdateComment :one
+UPDATE post_comments SET content = @content, mentions = @mentions::uuid[], edited_at = NOW()
+WHERE id = @id
+RETURNING id, post_id, parent_id, author_id, content, mentions, edited_at, created_at;
+
+-- name: DeleteComment :exec
+DELETE FROM post_comments WHERE id = @id;
+
+-- name: ListPostComments :many
+SELECT pc.id, pc.post_id, pc.parent_id, pc.author_id, pc.content, pc.mentions, pc.edited_at, pc.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url,
+    (SELECT COUNT(*) FROM post_comments r WHERE r.parent_id = pc.id) AS reply_count
+FROM post_comments pc
+JOIN users u ON u.id = pc.author_id
+WHERE pc.post_id = @post_id AND pc.parent_id IS NULL
+ORDER BY pc.created_at, pc.id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountPostComments :one
+SELECT COUNT(*) FROM post_comments
+WHERE post_id = @post_id AND parent_id IS NULL;
+
+-- name: ListCommentReplies :many
+SELECT pc.id, pc.post_id, pc.parent_id, pc.author_id, pc.content, pc.mentions, pc.edited_at, pc.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url
+FROM post_comments pc
+JOIN users u ON u.id = pc.author_id
+WHERE pc.parent_id = @parent_id
+ORDER BY pc.created_at, pc.id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommentReplies :one
+SELECT COUNT(*) FROM post_comments
+WHERE parent_id = @parent_id;
+
+-- name: ListReplyPreviews :many
+SELECT pc.id, pc.post_id, pc.parent_id, pc.author_id, pc.content, pc.mentions, pc.edited_at, pc.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url
+FROM (
+    SELECT c.*, ROW_NUMBER() OVER (PARTITION BY c.parent_id ORDER BY c.created_at, c.id) AS rn
+    FROM post_comments c
+    WHERE c.parent_id = ANY(@parent_ids::uuid[])
+) pc
+JOIN users u ON u.id = pc.author_id
+WHERE pc.rn <= @per_parent::int
+ORDER BY pc.parent_id, pc.created_at, pc.id;
+
+-- name: ListPostAudience :many
+SELECT u.id
+FROM users u
+JOIN posts p ON p.id = @post_id
+WHERE u.id = ANY(@user_ids::uuid[])
+  AND (
+    (p.author_type = 'user' AND (u.id = p.author_user_id OR EXISTS (
+        SELECT 1 FROM friends f
+        WHERE f.user_id = u.id AND f.friend_id = p.author_user_id AND f.status = 'accepted')))
+    OR (p.author_type = 'community' AND EXISTS (
+        SELECT 1 FROM community_members cm
+        WHERE cm.community_id = p.author_community_id AND cm.user_id = u.id AND cm.status = 'active'))
+  );
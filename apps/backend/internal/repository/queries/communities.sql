@@ -48,6 +48,7 @@ WHERE is_active = TRUE
   AND (sqlc.narg('district')::text IS NULL OR district = sqlc.narg('district'))
   AND (sqlc.narg('query')::text IS NULL OR name ILIKE '%' || sqlc.narg('query') || '%')
 ORDER BY
+    verification_status = 'verified' DESC NULLS LAST,
     CASE WHEN @sort_by::text = 'members' THEN member_count END DESC,
     CASE WHEN @sort_by::text = 'activity' THEN event_count END DESC,
     CASE WHEN @sort_by::text = 'name' THEN 1 END ASC,
@@ -68,6 +69,7 @@ WHERE is_active = TRUE
 -- name: UpdateCommunity :one
 UPDATE communities SET
     name              = COALESCE(sqlc.narg('name'), name),
+    slug              = COALESCE(sqlc.narg('slug'), slug),
     description       = COALESCE(sqlc.narg('description'), description),
     rules             = COALESCE(sqlc.narg('rules'), rules),
     access_level      = COALESCE(sqlc.narg('access_level'), access_level),
@@ -88,6 +90,13 @@ RETURNING id, name, slug, description, rules, community_type, access_level,
     member_count, event_count, is_active,
     created_by, created_at, updated_at;
 
+-- name: CommunitySlugExists :one
+SELECT EXISTS (
+    SELECT 1 FROM communities
+    WHERE slug = @slug
+      AND (sqlc.narg('exclude_id')::uuid IS NULL OR id <> sqlc.narg('exclude_id'))
+) AS slug_exists;
+
 -- name: ListMyCommunities :many
 SELECT c.id, c.name, c.slug, c.description, c.community_type, c.access_level,
     c.verification_status, c.logo_url, c.district,
@@ -186,7 +195,7 @@ SELECT
     END as win_rate
 FROM community_members cm
 JOIN users u ON cm.user_id = u.id
-WHERE cm.community_id = $1 AND cm.status = 'active' AND u.status = 'active'
+WHERE cm.community_id = $1 AND cm.status = 'active' AND u.status IN ('active', 'placeholder')
   AND cm.community_games_count >= $2
 ORDER BY cm.community_rating DESC
 LIMIT $3 OFFSET $4;
@@ -0,0 +1,139 @@
+-- name: CreateCourt :one
+INSERT INTO courts (
+    name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, phone,
+    working_hours, photos,
+    community_id, status, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+RETURNING id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at;
+
+-- name: GetCourtByID :one
+SELECT id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at
+FROM courts
+WHERE id = $1 AND is_active = TRUE;
+
+-- name: ListCourts :many
+SELECT id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at
+FROM courts
+WHERE is_active = TRUE
+  AND status = 'approved'
+  AND (sqlc.narg('district')::text IS NULL OR district = sqlc.narg('district'))
+  AND (sqlc.narg('surface')::court_surface IS NULL OR surface = sqlc.narg('surface'))
+  AND (sqlc.narg('indoor')::boolean IS NULL
+       OR (sqlc.narg('indoor')::boolean = TRUE AND indoor_courts > 0)
+       OR (sqlc.narg('indoor')::boolean = FALSE AND outdoor_courts > 0))
+  AND (sqlc.narg('min_price')::float8 IS NULL OR price_per_hour >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::float8 IS NULL OR price_per_hour <= sqlc.narg('max_price'))
+  AND (sqlc.narg('query')::text IS NULL OR name ILIKE '%' || sqlc.narg('query') || '%' OR address ILIKE '%' || sqlc.narg('query') || '%')
+  AND (sqlc.narg('open_at')::timestamptz IS NULL OR court_is_open(working_hours, sqlc.narg('open_at')))
+ORDER BY
+    CASE WHEN @sort_by::text = 'rating' THEN (
+        SELECT cr.avg_rating FROM court_ratings cr WHERE cr.court_id = courts.id
+    ) END DESC NULLS LAST,
+    name ASC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCourts :one
+SELECT COUNT(*)
+FROM courts
+WHERE is_active = TRUE
+  AND status = 'approved'
+  AND (sqlc.narg('district')::text IS NULL OR district = sqlc.narg('district'))
+  AND (sqlc.narg('surface')::court_surface IS NULL OR surface = sqlc.narg('surface'))
+  AND (sqlc.narg('indoor')::boolean IS NULL
+       OR (sqlc.narg('indoor')::boolean = TRUE AND indoor_courts > 0)
+       OR (sqlc.narg('indoor')::boolean = FALSE AND outdoor_courts > 0))
+  AND (sqlc.narg('min_price')::float8 IS NULL OR price_per_hour >= sqlc.narg('min_price'))
+  AND (sqlc.narg('max_price')::float8 IS NULL OR price_per_hour <= sqlc.narg('max_price'))
+  AND (sqlc.narg('query')::text IS NULL OR name ILIKE '%' || sqlc.narg('query') || '%' OR address ILIKE '%' || sqlc.narg('query') || '%')
+  AND (sqlc.narg('open_at')::timestamptz IS NULL OR court_is_open(working_hours, sqlc.narg('open_at')));
+
+-- name: ListCourtsInBounds :many
+SELECT id, name, latitude, longitude, total_courts, surface
+FROM courts
+WHERE is_active = TRUE
+  AND status = 'approved'
+  AND latitude BETWEEN @min_lat::float8 AND @max_lat::float8
+  AND longitude BETWEEN @min_lng::float8 AND @max_lng::float8
+ORDER BY name ASC
+LIMIT 500;
+
+-- name: ListCourtsByStatus :many
+SELECT id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at
+FROM courts
+WHERE status = @status AND is_active = TRUE
+ORDER BY created_at ASC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCourtsByStatus :one
+SELECT COUNT(*)
+FROM courts
+WHERE status = @status AND is_active = TRUE;
+
+-- name: UpdateCourt :one
+UPDATE courts SET
+    name           = COALESCE(sqlc.narg('name'), name),
+    address        = COALESCE(sqlc.narg('address'), address),
+    district       = COALESCE(sqlc.narg('district'), district),
+    latitude       = COALESCE(sqlc.narg('latitude'), latitude),
+    longitude      = COALESCE(sqlc.narg('longitude'), longitude),
+    total_courts   = COALESCE(sqlc.narg('total_courts'), total_courts),
+    indoor_courts  = COALESCE(sqlc.narg('indoor_courts'), indoor_courts),
+    outdoor_courts = COALESCE(sqlc.narg('outdoor_courts'), outdoor_courts),
+    surface        = COALESCE(sqlc.narg('surface'), surface),
+    price_per_hour = COALESCE(sqlc.narg('price_per_hour'), price_per_hour),
+    phone          = COALESCE(sqlc.narg('phone'), phone),
+    working_hours  = COALESCE(sqlc.narg('working_hours'), working_hours),
+    photos         = COALESCE(sqlc.narg('photos'), photos),
+    updated_at     = NOW()
+WHERE id = @id AND is_active = TRUE
+RETURNING id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at;
+
+-- name: ModerateCourt :one
+UPDATE courts SET
+    status = @status,
+    moderation_note = sqlc.narg('moderation_note'),
+    moderated_by = @moderated_by,
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id AND is_active = TRUE
+RETURNING id, name, address, district, latitude, longitude,
+    total_courts, indoor_courts, outdoor_courts,
+    surface, price_per_hour, currency, phone,
+    working_hours, photos, community_id, is_active,
+    created_by, created_at, updated_at,
+    status, moderation_note, moderated_by, moderated_at;
+
+-- name: DeactivateCourt :exec
+UPDATE courts SET
+    is_active = FALSE,
+    updated_at = NOW()
+WHERE id = $1;
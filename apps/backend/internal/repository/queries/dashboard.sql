@@ -0,0 +1,71 @@
+-- Community admin dashboard aggregates
+
+-- name: GetCommunityMemberGrowth :many
+SELECT date_trunc('month', joined_at AT TIME ZONE 'Asia/Almaty')::date AS month,
+    COUNT(*) AS joined,
+    COUNT(*) FILTER (WHERE status = 'active') AS still_active
+FROM community_members
+WHERE community_id = @community_id
+  AND status <> 'pending'
+  AND joined_at >= @since
+GROUP BY 1
+ORDER BY 1;
+
+-- name: GetCommunityMemberSummary :one
+SELECT
+    COUNT(*) FILTER (WHERE cm.status = 'active') AS active_members,
+    COUNT(*) FILTER (WHERE cm.status = 'active' AND cm.joined_at < @since) AS active_before,
+    COUNT(*) FILTER (WHERE cm.status = 'pending') AS pending_requests,
+    COUNT(*) FILTER (WHERE cm.status = 'active' AND (
+        EXISTS (
+            SELECT 1 FROM rating_history rh
+            WHERE rh.community_id = cm.community_id AND rh.user_id = cm.user_id
+              AND rh.created_at >= @active_since
+        )
+        OR EXISTS (
+            SELECT 1 FROM event_participants ep
+            JOIN events e ON e.id = ep.event_id
+            WHERE e.community_id = cm.community_id AND ep.user_id = cm.user_id
+              AND ep.status <> 'cancelled' AND e.start_time >= @active_since
+        )
+    )) AS recently_active,
+    COALESCE(AVG(cm.community_rating) FILTER (WHERE cm.status = 'active'), 0)::float8 AS average_rating
+FROM community_members cm
+WHERE cm.community_id = @community_id;
+
+-- name: GetCommunityEventSummary :one
+SELECT
+    COUNT(*) FILTER (WHERE status IN ('in_progress', 'completed', 'archived')) AS held,
+    COUNT(*) FILTER (WHERE start_time >= @now
+        AND status IN ('published', 'registration_open', 'registration_closed')) AS upcoming,
+    COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled
+FROM events
+WHERE community_id = @community_id;
+
+-- name: GetCommunityMatchSummary :one
+SELECT
+    COUNT(*) FILTER (WHERE result_status IN ('confirmed', 'admin_confirmed')) AS played,
+    COUNT(*) FILTER (WHERE result_status = 'disputed') AS disputed,
+    COUNT(*) FILTER (WHERE result_status = 'pending') AS pending
+FROM matches
+WHERE community_id = @community_id;
+
+-- name: GetCommunityRatingDistribution :many
+SELECT (FLOOR(community_rating / @bucket_size::int) * @bucket_size::int)::int AS bucket_start,
+    COUNT(*) AS members
+FROM community_members
+WHERE community_id = @community_id AND status = 'active'
+  AND community_rating IS NOT NULL
+GROUP BY 1
+ORDER BY 1;
+
+-- name: GetCommunityAttendance :one
+SELECT
+    COUNT(*) FILTER (WHERE ep.status = 'checked_in') AS attended,
+    COUNT(*) FILTER (WHERE ep.status = 'no_show') AS no_shows,
+    COUNT(*) FILTER (WHERE ep.status IN ('registered', 'confirmed')) AS unmarked
+FROM event_participants ep
+JOIN events e ON e.id = ep.event_id
+WHERE e.community_id = @community_id
+  AND e.start_time < @now
+  AND e.status <> 'cancelled';
@@ -0,0 +1,141 @@
+-- Paid membership plans and dues ledger queries
+
+-- name: CreateMembershipPlan :one
+INSERT INTO membership_plans (
+    community_id, name, period, duration_months, price_amount, price_currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, community_id, name, period, duration_months,
+    price_amount, price_currency, is_active, created_at, updated_at;
+
+-- name: GetMembershipPlan :one
+SELECT id, community_id, name, period, duration_months,
+    price_amount, price_currency, is_active, created_at, updated_at
+FROM membership_plans
+WHERE id = @id AND community_id = @community_id;
+
+-- name: ListMembershipPlans :many
+SELECT id, community_id, name, period, duration_months,
+    price_amount, price_currency, is_active, created_at, updated_at
+FROM membership_plans
+WHERE community_id = @community_id
+  AND (is_active OR @include_inactive::boolean)
+ORDER BY is_active DESC, duration_months ASC, price_amount ASC;
+
+-- name: UpdateMembershipPlan :one
+UPDATE membership_plans SET
+    name = COALESCE(sqlc.narg('name'), name),
+    price_amount = COALESCE(sqlc.narg('price_amount'), price_amount),
+    is_active = COALESCE(sqlc.narg('is_active'), is_active),
+    updated_at = NOW()
+WHERE id = @id AND community_id = @community_id
+RETURNING id, community_id, name, period, duration_months,
+    price_amount, price_currency, is_active, created_at, updated_at;
+
+-- name: CreateMembershipDues :one
+INSERT INTO membership_dues (
+    community_id, user_id, plan_id, amount, currency,
+    period_start, period_end, status, payment_method,
+    payment_reference, provider, provider_payment_id, recorded_by, paid_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+RETURNING id, community_id, user_id, plan_id, amount, currency,
+    period_start, period_end, status, payment_method,
+    payment_reference, provider, provider_payment_id, recorded_by,
+    paid_at, created_at, updated_at;
+
+-- name: GetMembershipDuesByProviderPayment :one
+SELECT id, community_id, user_id, plan_id, amount, currency,
+    period_start, period_end, status, payment_method,
+    payment_reference, provider, provider_payment_id, recorded_by,
+    paid_at, created_at, updated_at
+FROM membership_dues
+WHERE provider = @provider AND provider_payment_id = @provider_payment_id;
+
+-- name: SettleMembershipDues :one
+UPDATE membership_dues SET
+    status = 'paid',
+    period_start = @period_start,
+    period_end = @period_end,
+    paid_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id AND status = 'pending'
+RETURNING id, community_id, user_id, plan_id, amount, currency,
+    period_start, period_end, status, payment_method,
+    payment_reference, provider, provider_payment_id, recorded_by,
+    paid_at, created_at, updated_at;
+
+-- name: CancelMembershipDues :execrows
+UPDATE membership_dues SET
+    status = 'cancelled',
+    updated_at = NOW()
+WHERE id = @id AND status = 'pending';
+
+-- name: LockMemberDues :exec
+SELECT pg_advisory_xact_lock(hashtextextended(@community_id::uuid::text || ':' || @user_id::uuid::text, 0));
+
+-- name: GetMemberPaidUntil :one
+SELECT MAX(period_end)::date AS paid_until
+FROM membership_dues
+WHERE community_id = @community_id AND user_id = @user_id AND status = 'paid';
+
+-- name: ListMemberDues :many
+SELECT id, community_id, user_id, plan_id, amount, currency,
+    period_start, period_end, status, payment_method,
+    payment_reference, provider, provider_payment_id, recorded_by,
+    paid_at, created_at, updated_at
+FROM membership_dues
+WHERE community_id = @community_id AND user_id = @user_id
+ORDER BY created_at DESC
+LIMIT 50;
+
+-- name: ListCommunityDues :many
+SELECT d.id, d.community_id, d.user_id, d.plan_id, d.amount, d.currency,
+    d.period_start, d.period_end, d.status, d.payment_method,
+    d.payment_reference, d.recorded_by, d.paid_at, d.created_at,
+    u.first_name, u.last_name, p.name AS plan_name
+FROM membership_dues d
+JOIN users u ON u.id = d.user_id
+LEFT JOIN membership_plans p ON p.id = d.plan_id
+WHERE d.community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR d.user_id = sqlc.narg('user_id'))
+ORDER BY d.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommunityDues :one
+SELECT COUNT(*)
+FROM membership_dues
+WHERE community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'));
+
+-- name: ListEndingMemberships :many
+SELECT d.id, d.community_id, d.user_id, d.period_end, c.name AS community_name
+FROM membership_dues d
+JOIN communities c ON c.id = d.community_id
+JOIN community_members cm ON cm.community_id = d.community_id AND cm.user_id = d.user_id
+WHERE d.status = 'paid'
+  AND d.period_end BETWEEN @window_start::date AND @window_end::date
+  AND cm.status = 'active'
+  AND cm.role = 'member'
+  AND c.access_level = 'paid'
+  AND c.is_active = TRUE
+  AND NOT EXISTS (
+      SELECT 1 FROM membership_dues n
+      WHERE n.community_id = d.community_id AND n.user_id = d.user_id
+        AND n.status = 'paid' AND n.period_end > d.period_end
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM sent_reminders sr
+      WHERE sr.user_id = d.user_id AND sr.target_id = d.id
+        AND sr.reminder_type = @reminder_type
+  )
+ORDER BY d.period_end ASC
+LIMIT @batch_limit;
+
+-- name: ExpireCommunityMember :execrows
+UPDATE community_members SET
+    status = 'expired',
+    updated_at = NOW()
+WHERE community_id = @community_id AND user_id = @user_id AND status = 'active';
@@ -221,7 +221,7 @@ ORDER BY cm.joined_at DESC;
 
 -- name: CheckFriendship :one
 SELECT EXISTS(
-    SELECT 1 FROM friends WHERE user_id = $1 AND friend_id = $2
+    SELECT 1 FROM friends WHERE user_id = $1 AND friend_id = $2 AND status = 'accepted'
 ) as is_friend;
 
 -- name: CountMutualCommunities :one
@@ -237,3 +237,24 @@ UPDATE users SET
     updated_at = NOW()
 WHERE id = $1 AND status != 'deleted'
 RETURNING id, avatar_url;
+
+-- name: ListEventsForLifecycle :many
+SELECT id, title, status, start_time, end_time, registration_deadline,
+    min_participants, current_participants
+FROM events
+WHERE (status IN ('published', 'registration_open', 'registration_closed')
+        AND start_time <= @cancel_horizon::timestamptz
+        AND COALESCE(current_participants, 0) < min_participants)
+   OR (status IN ('published', 'registration_open') AND (registration_deadline <= @now::timestamptz OR start_time <= @now::timestamptz))
+   OR (status = 'registration_closed' AND start_time <= @now::timestamptz)
+   OR (status = 'in_progress' AND end_time <= @now::timestamptz)
+   OR (status = 'in_progress' AND end_time IS NULL AND start_time <= @started_before::timestamptz)
+   OR (status = 'completed' AND COALESCE(end_time, start_time) <= @archive_before::timestamptz)
+ORDER BY start_time ASC
+LIMIT @batch_limit;
+
+-- name: TransitionEventStatus :execrows
+UPDATE events SET
+    status = @to_status,
+    updated_at = NOW()
+WHERE id = @id AND status = @from_status;
@@ -0,0 +1,91 @@
+-- Community export queries
+
+-- name: CreateCommunityExport :one
+INSERT INTO community_exports (
+    community_id, requested_by, dataset, format
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, community_id, requested_by, dataset, format, status, row_count, file_url, error, created_at, completed_at;
+
+-- name: GetCommunityExport :one
+SELECT id, community_id, requested_by, dataset, format, status, row_count, file_url, error, created_at, completed_at
+FROM community_exports
+WHERE id = @id AND community_id = @community_id;
+
+-- name: ListCommunityExports :many
+SELECT id, community_id, requested_by, dataset, format, status, row_count, file_url, error, created_at, completed_at
+FROM community_exports
+WHERE community_id = @community_id
+ORDER BY created_at DESC
+LIMIT 20;
+
+-- name: StartCommunityExport :exec
+UPDATE community_exports SET status = 'running'
+WHERE id = @id;
+
+-- name: CompleteCommunityExport :exec
+UPDATE community_exports SET
+    status = 'completed',
+    row_count = @row_count,
+    file_url = @file_url,
+    completed_at = NOW()
+WHERE id = @id;
+
+-- name: FailCommunityExport :exec
+UPDATE community_exports SET
+    status = 'failed',
+    error = @error,
+    completed_at = NOW()
+WHERE id = @id;
+
+-- name: ExportCommunityMembers :many
+SELECT cm.user_id, u.first_name, u.last_name, u.ntrp_level,
+    cm.role, cm.status, cm.joined_at,
+    cm.community_rating, cm.community_games_count, cm.community_wins, cm.community_losses
+FROM community_members cm
+JOIN users u ON u.id = cm.user_id
+WHERE cm.community_id = @community_id
+ORDER BY cm.joined_at, cm.user_id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: ExportCommunityMatches :many
+SELECT m.id, COALESCE(m.played_at, m.confirmed_at, m.created_at)::timestamptz AS played_at,
+    e.title AS event_title, m.composition,
+    m.player1_id, p1.first_name AS player1_first_name, p1.last_name AS player1_last_name,
+    m.player2_id, p2.first_name AS player2_first_name, p2.last_name AS player2_last_name,
+    m.score, m.winner_id, m.result_status,
+    m.player1_rating_before, m.player1_rating_after,
+    m.player2_rating_before, m.player2_rating_after
+FROM matches m
+JOIN users p1 ON p1.id = m.player1_id
+JOIN users p2 ON p2.id = m.player2_id
+LEFT JOIN events e ON e.id = m.event_id
+WHERE m.community_id = @community_id
+ORDER BY m.created_at, m.id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommunityMatches :one
+SELECT COUNT(*)
+FROM matches
+WHERE community_id = @community_id;
+
+-- name: ExportCommunityAttendance :many
+SELECT e.id AS event_id, e.title, e.start_time,
+    ep.user_id, u.first_name, u.last_name, ep.status
+FROM event_participants ep
+JOIN events e ON e.id = ep.event_id
+JOIN users u ON u.id = ep.user_id
+WHERE e.community_id = @community_id
+  AND e.start_time < @now
+  AND e.status <> 'cancelled'
+ORDER BY e.start_time, e.id, ep.user_id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommunityAttendance :one
+SELECT COUNT(*)
+FROM event_participants ep
+JOIN events e ON e.id = ep.event_id
+WHERE e.community_id = @community_id
+  AND e.start_time < @now
+  AND e.status <> 'cancelled';
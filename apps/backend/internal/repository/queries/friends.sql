@@ -0,0 +1,127 @@
+-- Friend queries
+
+-- name: GetFriendLink :one
+SELECT id, user_id, friend_id, created_at, status, accepted_at
+FROM friends
+WHERE user_id = @user_id AND friend_id = @friend_id;
+
+-- name: LockFriendPair :exec
+SELECT pg_advisory_xact_lock(hashtextextended(
+    LEAST(@user_id::uuid, @friend_id::uuid)::text || ':' || GREATEST(@user_id::uuid, @friend_id::uuid)::text, 0));
+
+-- name: CreateFriendRequest :one
+INSERT INTO friends (user_id, friend_id, status)
+VALUES (@user_id, @friend_id, 'pending')
+RETURNING id, user_id, friend_id, created_at, status, accepted_at;
+
+-- name: AcceptFriendRequest :execrows
+UPDATE friends SET status = 'accepted', accepted_at = NOW()
+WHERE user_id = @user_id AND friend_id = @friend_id AND status = 'pending';
+
+-- name: AddAcceptedFriend :exec
+INSERT INTO friends (user_id, friend_id, status, accepted_at)
+VALUES (@user_id, @friend_id, 'accepted', NOW())
+ON CONFLICT (user_id, friend_id) DO UPDATE SET status = 'accepted', accepted_at = NOW();
+
+-- name: DeleteFriendRequest :execrows
+DELETE FROM friends
+WHERE user_id = @user_id AND friend_id = @friend_id AND status = 'pending';
+
+-- name: DeleteFriendship :execrows
+DELETE FROM friends
+WHERE status = 'accepted'
+  AND ((user_id = @user_id AND friend_id = @friend_id) OR (user_id = @friend_id AND friend_id = @user_id));
+
+-- name: ListFriends :many
+SELECT f.id, f.friend_id, f.accepted_at,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level,
+    (SELECT COUNT(*) FROM friends mine
+     JOIN friends theirs ON theirs.friend_id = mine.friend_id AND theirs.status = 'accepted'
+     WHERE mine.user_id = f.user_id AND mine.status = 'accepted' AND theirs.user_id = f.friend_id) AS mutual_friends
+FROM friends f
+JOIN users u ON u.id = f.friend_id
+WHERE f.user_id = @user_id AND f.status = 'accepted'
+ORDER BY u.first_name, u.last_name
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountFriends :one
+SELECT COUNT(*) FROM friends
+WHERE user_id = @user_id AND status = 'accepted';
+
+-- name: ListIncomingFriendRequests :many
+SELECT f.id, f.user_id AS other_id, f.created_at,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level,
+    (SELECT COUNT(*) FROM friends mine
+     JOIN friends theirs ON theirs.friend_id = mine.friend_id AND theirs.status = 'accepted'
+     WHERE mine.user_id = f.friend_id AND mine.status = 'accepted' AND theirs.user_id = f.user_id) AS mutual_friends
+FROM friends f
+JOIN users u ON u.id = f.user_id
+WHERE f.friend_id = @user_id AND f.status = 'pending'
+ORDER BY f.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountIncomingFriendRequests :one
+SELECT COUNT(*) FROM friends
+WHERE friend_id = @user_id AND status = 'pending';
+
+-- name: ListOutgoingFriendRequests :many
+SELECT f.id, f.friend_id AS other_id, f.created_at,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level,
+    (SELECT COUNT(*) FROM friends mine
+     JOIN friends theirs ON theirs.friend_id = mine.friend_id AND theirs.status = 'accepted'
+     WHERE mine.user_id = f.user_id AND mine.status = 'accepted' AND theirs.user_id = f.friend_id) AS mutual_friends
+FROM friends f
+JOIN users u ON u.id = f.friend_id
+WHERE f.user_id = @user_id AND f.status = 'pending'
+ORDER BY f.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountOutgoingFriendRequests :one
+SELECT COUNT(*) FROM friends
+WHERE user_id = @user_id AND status = 'pending';
+
+-- name: CountMutualFriends :one
+SELECT COUNT(*) FROM friends mine
+JOIN friends theirs ON theirs.friend_id = mine.friend_id AND theirs.status = 'accepted'
+WHERE mine.user_id = @user_id AND mine.status = 'accepted' AND theirs.user_id = @other_id;
+
+-- name: ListFriendSuggestions :many
+WITH candidates AS (
+    SELECT cm2.user_id AS candidate_id, 'community' AS source
+    FROM community_members cm1
+    JOIN community_members cm2 ON cm2.community_id = cm1.community_id
+    WHERE cm1.user_id = @user_id AND cm1.status = 'active' AND cm2.status = 'active'
+    UNION ALL
+    SELECT CASE WHEN m.player1_id = @user_id THEN m.player2_id ELSE m.player1_id END, 'opponent'
+    FROM matches m
+    WHERE (m.player1_id = @user_id OR m.player2_id = @user_id)
+      AND m.result_status IN ('confirmed', 'admin_confirmed')
+    UNION ALL
+    SELECT ep2.user_id, 'event'
+    FROM event_participants ep1
+    JOIN event_participants ep2 ON ep2.event_id = ep1.event_id
+    JOIN events e ON e.id = ep1.event_id
+    WHERE ep1.user_id = @user_id
+      AND e.status IN ('completed', 'archived')
+      AND ep1.status IN ('confirmed', 'checked_in')
+      AND ep2.status IN ('confirmed', 'checked_in')
+)
+SELECT c.candidate_id AS user_id,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level,
+    COUNT(*) FILTER (WHERE c.source = 'community') AS shared_communities,
+    COUNT(*) FILTER (WHERE c.source = 'opponent') AS matches_played,
+    COUNT(*) FILTER (WHERE c.source = 'event') AS shared_events,
+    (SELECT COUNT(*) FROM friends mine
+     JOIN friends theirs ON theirs.friend_id = mine.friend_id AND theirs.status = 'accepted'
+     WHERE mine.user_id = @user_id AND mine.status = 'accepted' AND theirs.user_id = c.candidate_id) AS mutual_friends
+FROM candidates c
+JOIN users u ON u.id = c.candidate_id AND u.status = 'active'
+WHERE c.candidate_id != @user_id
+  AND NOT EXISTS (
+      SELECT 1 FROM friends f
+      WHERE (f.user_id = @user_id AND f.friend_id = c.candidate_id)
+         OR (f.user_id = c.candidate_id AND f.friend_id = @user_id)
+  )
+GROUP BY c.candidate_id, u.first_name, u.last_name, u.avatar_url, u.ntrp_level
+ORDER BY COUNT(*) DESC
+LIMIT @candidate_limit;
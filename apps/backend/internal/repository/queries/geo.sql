@@ -0,0 +1,86 @@
+-- Proximity search queries (earthdistance, distances in meters)
+
+-- name: ListCourtsNearby :many
+SELECT c.id, c.name, c.address, c.district, c.latitude, c.longitude,
+    c.total_courts, c.surface, c.price_per_hour, c.currency,
+    earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(c.latitude::float8, c.longitude::float8)
+    )::float8 AS distance_meters
+FROM courts c
+WHERE c.is_active = TRUE
+  AND c.status = 'approved'
+  AND c.latitude IS NOT NULL AND c.longitude IS NOT NULL
+  AND earth_box(ll_to_earth(@lat::float8, @lng::float8), @radius_meters::float8)
+      @> ll_to_earth(c.latitude::float8, c.longitude::float8)
+  AND earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(c.latitude::float8, c.longitude::float8)
+      ) <= @radius_meters::float8
+ORDER BY distance_meters ASC
+LIMIT @result_limit;
+
+-- name: ListEventsNearby :many
+SELECT e.id, e.title, e.event_type, e.status, e.player_composition,
+    e.start_time, e.end_time,
+    e.max_participants, e.current_participants,
+    e.min_level, e.max_level,
+    e.community_id, e.court_id,
+    c.name AS court_name, c.address AS court_address,
+    earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(c.latitude::float8, c.longitude::float8)
+    )::float8 AS distance_meters
+FROM events e
+JOIN courts c ON c.id = e.court_id
+WHERE e.status IN ('published', 'registration_open')
+  AND e.start_time >= @starts_after
+  AND c.latitude IS NOT NULL AND c.longitude IS NOT NULL
+  AND earth_box(ll_to_earth(@lat::float8, @lng::float8), @radius_meters::float8)
+      @> ll_to_earth(c.latitude::float8, c.longitude::float8)
+  AND earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(c.latitude::float8, c.longitude::float8)
+      ) <= @radius_meters::float8
+ORDER BY distance_meters ASC, e.start_time ASC
+LIMIT @result_limit;
+
+-- name: ListPlayersNearby :many
+SELECT u.id, u.first_name, u.last_name, u.avatar_url,
+    u.ntrp_level, u.level_label, u.global_rating, u.district,
+    earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(ul.latitude, ul.longitude)
+    )::float8 AS distance_meters
+FROM user_locations ul
+JOIN users u ON u.id = ul.user_id
+WHERE u.status = 'active'
+  AND u.is_profile_complete = TRUE
+  AND COALESCE(u.profile_visibility, 'all') = 'all'
+  AND u.id <> @exclude_user_id
+  AND earth_box(ll_to_earth(@lat::float8, @lng::float8), @radius_meters::float8)
+      @> ll_to_earth(ul.latitude, ul.longitude)
+  AND earth_distance(
+        ll_to_earth(@lat::float8, @lng::float8),
+        ll_to_earth(ul.latitude, ul.longitude)
+      ) <= @radius_meters::float8
+ORDER BY distance_meters ASC
+LIMIT @result_limit;
+
+-- name: GetUserLocation :one
+SELECT user_id, latitude, longitude, updated_at
+FROM user_locations
+WHERE user_id = $1;
+
+-- name: UpsertUserLocation :one
+INSERT INTO user_locations (user_id, latitude, longitude)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE SET
+    latitude = EXCLUDED.latitude,
+    longitude = EXCLUDED.longitude,
+    updated_at = NOW()
+RETURNING user_id, latitude, longitude, updated_at;
+
+-- name: DeleteUserLocation :exec
+DELETE FROM user_locations
+WHERE user_id = $1;
@@ -0,0 +1,100 @@
+-- Community import queries
+
+-- name: CreateCommunityImport :one
+INSERT INTO community_imports (
+    community_id, created_by, kind, total_rows, valid_rows, row_errors
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, community_id, created_by, kind, status, total_rows, valid_rows, row_errors,
+    replay_ratings, result, committed_by, committed_at, created_at;
+
+-- name: GetCommunityImport :one
+SELECT id, community_id, created_by, kind, status, total_rows, valid_rows, row_errors,
+    replay_ratings, result, committed_by, committed_at, created_at
+FROM community_imports
+WHERE id = @id AND community_id = @community_id;
+
+-- name: LockCommunityImport :one
+SELECT id, community_id, created_by, kind, status, total_rows, valid_rows, row_errors,
+    replay_ratings, result, committed_by, committed_at, created_at
+FROM community_imports
+WHERE id = @id AND community_id = @community_id
+FOR UPDATE;
+
+-- name: ListCommunityImports :many
+SELECT id, community_id, created_by, kind, status, total_rows, valid_rows, row_errors,
+    replay_ratings, result, committed_by, committed_at, created_at
+FROM community_imports
+WHERE community_id = @community_id
+ORDER BY created_at DESC
+LIMIT 20;
+
+-- name: CommitCommunityImport :exec
+UPDATE community_imports SET
+    status = 'committed',
+    replay_ratings = @replay_ratings,
+    result = @result,
+    committed_by = @committed_by,
+    committed_at = NOW()
+WHERE id = @id;
+
+-- name: GetUserStatusByPhone :one
+SELECT id, status
+FROM users
+WHERE phone = @phone;
+
+-- name: CreatePlaceholderUser :one
+INSERT INTO users (
+    phone, first_name, last_name, ntrp_level, status
+) VALUES (
+    @phone, sqlc.narg('first_name'), sqlc.narg('last_name'), sqlc.narg('ntrp_level'), 'placeholder'
+)
+RETURNING id;
+
+-- name: ClaimPlaceholderUser :exec
+UPDATE users SET
+    status = 'active',
+    phone_verified = TRUE,
+    updated_at = NOW()
+WHERE id = @id AND status = 'placeholder';
+
+-- name: AddImportedCommunityMember :execrows
+INSERT INTO community_members (
+    community_id, user_id, role, status
+) VALUES (
+    @community_id, @user_id, 'member', 'active'
+)
+ON CONFLICT (community_id, user_id) DO NOTHING;
+
+-- name: CreateImportInvite :execrows
+INSERT INTO community_invites (
+    community_id, code, role, max_uses, expires_at, created_by
+) VALUES (
+    @community_id, @code, 'member', 1, @expires_at, @created_by
+)
+ON CONFLICT (code) DO NOTHING;
+
+-- name: ListCommunityMemberPhones :many
+SELECT cm.user_id, u.phone, u.status
+FROM community_members cm
+JOIN users u ON u.id = cm.user_id
+WHERE cm.community_id = @community_id AND cm.status = 'active';
+
+-- name: CreateImportedMatch :one
+INSERT INTO matches (
+    community_id, player1_id, player2_id, composition,
+    score, winner_id, result_status,
+    submitted_by, confirmed_by, submitted_at, confirmed_at,
+    player1_rating_before, player1_rating_after,
+    player2_rating_before, player2_rating_after,
+    played_at
+) VALUES (
+    @community_id, @player1_id, @player2_id, 'singles',
+    @score, @winner_id, 'confirmed',
+    @imported_by, @imported_by, NOW(), NOW(),
+    sqlc.narg('player1_rating_before'), sqlc.narg('player1_rating_after'),
+    sqlc.narg('player2_rating_before'), sqlc.narg('player2_rating_after'),
+    @played_at
+)
+RETURNING id;
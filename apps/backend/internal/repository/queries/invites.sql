@@ -0,0 +1,56 @@
+-- Community invite queries
+
+-- name: CreateCommunityInvite :one
+INSERT INTO community_invites (
+    community_id, code, role, max_uses, expires_at, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, community_id, code, role, max_uses, use_count,
+    expires_at, created_by, revoked_at, created_at;
+
+-- name: GetCommunityInviteByCode :one
+SELECT i.id, i.community_id, i.code, i.role, i.max_uses, i.use_count,
+    i.expires_at, i.created_by, i.revoked_at, i.created_at,
+    c.name AS community_name, c.slug AS community_slug, c.logo_url,
+    c.access_level, c.member_count, c.is_active AS community_is_active
+FROM community_invites i
+JOIN communities c ON c.id = i.community_id
+WHERE i.code = @code;
+
+-- name: ListCommunityInvites :many
+SELECT i.id, i.community_id, i.code, i.role, i.max_uses, i.use_count,
+    i.expires_at, i.created_by, i.revoked_at, i.created_at,
+    u.first_name AS creator_first_name, u.last_name AS creator_last_name
+FROM community_invites i
+JOIN users u ON u.id = i.created_by
+WHERE i.community_id = @community_id
+ORDER BY i.created_at DESC;
+
+-- name: ClaimCommunityInvite :execrows
+UPDATE community_invites SET
+    use_count = use_count + 1
+WHERE id = @id
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses);
+
+-- name: RevokeCommunityInvite :execrows
+UPDATE community_invites SET
+    revoked_at = NOW()
+WHERE id = @id AND community_id = @community_id AND revoked_at IS NULL;
+
+-- name: RecordCommunityInviteUse :exec
+INSERT INTO community_invite_uses (invite_id, user_id, community_id)
+VALUES (@invite_id, @user_id, @community_id)
+ON CONFLICT (invite_id, user_id) DO NOTHING;
+
+-- name: ListCommunityInviteUses :many
+SELECT iu.invite_id, iu.user_id, iu.joined_at,
+    u.first_name, u.last_name, u.avatar_url,
+    cm.role, cm.status
+FROM community_invite_uses iu
+JOIN users u ON u.id = iu.user_id
+LEFT JOIN community_members cm ON cm.community_id = iu.community_id AND cm.user_id = iu.user_id
+WHERE iu.invite_id = @invite_id AND iu.community_id = @community_id
+ORDER BY iu.joined_at DESC;
@@ -0,0 +1,167 @@
+-- Community ladder queries
+
+-- name: GetCommunityLadder :one
+SELECT community_id, challenge_range, accept_hours, play_days, decline_penalty, is_active, created_at, updated_at
+FROM community_ladders
+WHERE community_id = @community_id;
+
+-- name: LockCommunityLadder :one
+SELECT community_id, challenge_range, accept_hours, play_days, decline_penalty, is_active, created_at, updated_at
+FROM community_ladders
+WHERE community_id = @community_id
+FOR UPDATE;
+
+-- name: UpsertCommunityLadder :one
+INSERT INTO community_ladders (
+    community_id, challenge_range, accept_hours, play_days, decline_penalty, is_active
+) VALUES (
+    @community_id, @challenge_range, @accept_hours, @play_days, @decline_penalty, @is_active
+)
+ON CONFLICT (community_id) DO UPDATE SET
+    challenge_range = EXCLUDED.challenge_range,
+    accept_hours = EXCLUDED.accept_hours,
+    play_days = EXCLUDED.play_days,
+    decline_penalty = EXCLUDED.decline_penalty,
+    is_active = EXCLUDED.is_active
+RETURNING community_id, challenge_range, accept_hours, play_days, decline_penalty, is_active, created_at, updated_at;
+
+-- name: ListLadderPositions :many
+SELECT lp.position, lp.user_id, lp.joined_at,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level
+FROM ladder_positions lp
+JOIN users u ON u.id = lp.user_id
+WHERE lp.community_id = @community_id
+ORDER BY lp.position;
+
+-- name: GetLadderPosition :one
+SELECT community_id, user_id, position, joined_at
+FROM ladder_positions
+WHERE community_id = @community_id AND user_id = @user_id;
+
+-- name: GetLadderBottom :one
+SELECT COALESCE(MAX(position), 0)::int AS bottom
+FROM ladder_positions
+WHERE community_id = @community_id;
+
+-- name: AddLadderPosition :one
+INSERT INTO ladder_positions (community_id, user_id, position)
+VALUES (@community_id, @user_id, @position)
+RETURNING community_id, user_id, position, joined_at;
+
+-- name: DeleteLadderPosition :exec
+DELETE FROM ladder_positions
+WHERE community_id = @community_id AND user_id = @user_id;
+
+-- name: SetLadderPosition :exec
+UPDATE ladder_positions SET position = @position
+WHERE community_id = @community_id AND user_id = @user_id;
+
+-- name: ShiftLadderPositions :exec
+WITH shifted AS (
+    UPDATE ladder_positions SET position = position + @delta
+    WHERE community_id = @community_id AND position BETWEEN @from_position AND @to_position
+    RETURNING user_id, position
+)
+INSERT INTO ladder_history (community_id, user_id, old_position, new_position, reason, challenge_id)
+SELECT @community_id, user_id, position - @delta, position, 'shifted'::ladder_change_reason, sqlc.narg('challenge_id')::uuid
+FROM shifted;
+
+-- name: InsertLadderHistory :exec
+INSERT INTO ladder_history (
+    community_id, user_id, old_position, new_position, reason, challenge_id
+) VALUES (
+    @community_id, @user_id, sqlc.narg('old_position'), sqlc.narg('new_position'), @reason, sqlc.narg('challenge_id')
+);
+
+-- name: ListLadderHistory :many
+SELECT lh.id, lh.user_id, lh.old_position, lh.new_position, lh.reason, lh.challenge_id, lh.created_at,
+    u.first_name, u.last_name
+FROM ladder_history lh
+JOIN users u ON u.id = lh.user_id
+WHERE lh.community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR lh.user_id = sqlc.narg('user_id'))
+ORDER BY lh.created_at DESC, lh.id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountLadderHistory :one
+SELECT COUNT(*) FROM ladder_history
+WHERE community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'));
+
+-- name: CreateLadderChallenge :one
+INSERT INTO ladder_challenges (
+    community_id, challenger_id, defender_id, challenger_position, defender_position, accept_deadline
+) VALUES (
+    @community_id, @challenger_id, @defender_id, @challenger_position, @defender_position, @accept_deadline
+)
+RETURNING id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at;
+
+-- name: LockLadderChallenge :one
+SELECT id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at
+FROM ladder_challenges
+WHERE id = @id AND community_id = @community_id
+FOR UPDATE;
+
+-- name: GetLadderChallengeByMatch :one
+SELECT id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at
+FROM ladder_challenges
+WHERE match_id = @match_id AND status = 'accepted';
+
+-- name: CountOpenLadderChallenges :one
+SELECT COUNT(*) FROM ladder_challenges
+WHERE community_id = @community_id
+  AND status IN ('pending', 'accepted')
+  AND (challenger_id = @user_id OR defender_id = @user_id);
+
+-- name: AcceptLadderChallenge :one
+UPDATE ladder_challenges SET
+    status = 'accepted',
+    match_id = @match_id,
+    play_deadline = @play_deadline,
+    responded_at = NOW()
+WHERE id = @id
+RETURNING id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at;
+
+-- name: ResolveLadderChallenge :one
+UPDATE ladder_challenges SET
+    status = @status,
+    winner_id = sqlc.narg('winner_id'),
+    responded_at = CASE WHEN @status = 'declined' THEN NOW() ELSE responded_at END,
+    resolved_at = NOW()
+WHERE id = @id
+RETURNING id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at;
+
+-- name: ListLadderChallenges :many
+SELECT lc.id, lc.community_id, lc.challenger_id, lc.defender_id, lc.challenger_position, lc.defender_position,
+    lc.status, lc.accept_deadline, lc.play_deadline, lc.match_id, lc.winner_id,
+    lc.created_at, lc.responded_at, lc.resolved_at,
+    c.first_name AS challenger_first_name, c.last_name AS challenger_last_name,
+    d.first_name AS defender_first_name, d.last_name AS defender_last_name
+FROM ladder_challenges lc
+JOIN users c ON c.id = lc.challenger_id
+JOIN users d ON d.id = lc.defender_id
+WHERE lc.community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR lc.challenger_id = sqlc.narg('user_id') OR lc.defender_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('status')::ladder_challenge_status IS NULL OR lc.status = sqlc.narg('status'))
+ORDER BY lc.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountLadderChallenges :one
+SELECT COUNT(*) FROM ladder_challenges
+WHERE community_id = @community_id
+  AND (sqlc.narg('user_id')::uuid IS NULL OR challenger_id = sqlc.narg('user_id') OR defender_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('status')::ladder_challenge_status IS NULL OR status = sqlc.narg('status'));
+
+-- name: ListDueLadderChallenges :many
+SELECT id, community_id, challenger_id, defender_id, challenger_position, defender_position, status,
+    accept_deadline, play_deadline, match_id, winner_id, created_at, responded_at, resolved_at
+FROM ladder_challenges
+WHERE (status = 'pending' AND accept_deadline < @now)
+   OR (status = 'accepted' AND play_deadline < @now)
+ORDER BY created_at
+LIMIT @batch_limit;
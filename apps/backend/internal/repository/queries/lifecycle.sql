@@ -0,0 +1,69 @@
+-- Community ownership transfer and deactivation queries
+
+-- name: CreateOwnershipTransfer :one
+INSERT INTO community_ownership_transfers (
+    community_id, from_user_id, to_user_id, expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, community_id, from_user_id, to_user_id, status, expires_at, responded_at, created_at;
+
+-- name: GetPendingOwnershipTransfer :one
+SELECT id, community_id, from_user_id, to_user_id, status, expires_at, responded_at, created_at
+FROM community_ownership_transfers
+WHERE community_id = @community_id AND status = 'pending'
+FOR UPDATE;
+
+-- name: SetOwnershipTransferStatus :one
+UPDATE community_ownership_transfers SET
+    status = @status,
+    responded_at = NOW()
+WHERE id = @id AND status = 'pending'
+RETURNING id, community_id, from_user_id, to_user_id, status, expires_at, responded_at, created_at;
+
+-- name: GetCommunityActiveState :one
+SELECT id, name, is_active
+FROM communities
+WHERE id = @id;
+
+-- name: SetCommunityActive :execrows
+UPDATE communities SET
+    is_active = @is_active,
+    updated_at = NOW()
+WHERE id = @id AND is_active IS DISTINCT FROM @is_active;
+
+-- name: SetCommunityChatArchived :exec
+UPDATE chats SET
+    is_archived = @is_archived,
+    updated_at = NOW()
+WHERE chat_type = 'community' AND community_id = @community_id;
+
+-- name: CreateCommunityDeactivation :one
+INSERT INTO community_deactivations (
+    community_id, deactivated_by, reason
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, community_id, deactivated_by, reason, reactivated_by, reactivated_at, created_at;
+
+-- name: CloseCommunityDeactivation :exec
+UPDATE community_deactivations SET
+    reactivated_by = @reactivated_by,
+    reactivated_at = NOW()
+WHERE community_id = @community_id AND reactivated_at IS NULL;
+
+-- name: ListDeactivatedCommunities :many
+SELECT c.id, c.name, c.slug, c.logo_url, c.member_count,
+    d.deactivated_by, d.reason, d.created_at AS deactivated_at,
+    u.first_name AS deactivated_by_first_name, u.last_name AS deactivated_by_last_name
+FROM communities c
+LEFT JOIN community_deactivations d ON d.community_id = c.id AND d.reactivated_at IS NULL
+LEFT JOIN users u ON u.id = d.deactivated_by
+WHERE c.is_active = FALSE
+ORDER BY d.created_at DESC NULLS LAST, c.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountDeactivatedCommunities :one
+SELECT COUNT(*)
+FROM communities
+WHERE is_active = FALSE;
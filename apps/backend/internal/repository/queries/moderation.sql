@@ -0,0 +1,25 @@
+-- Community moderation queries
+
+-- name: CreateModerationAction :one
+INSERT INTO community_moderation_actions (
+    community_id, user_id, actor_id, action, reason, banned_until
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING id, community_id, user_id, actor_id, action, reason, banned_until, created_at;
+
+-- name: GetLatestBan :one
+SELECT id, community_id, user_id, actor_id, action, reason, banned_until, created_at
+FROM community_moderation_actions
+WHERE community_id = @community_id AND user_id = @user_id AND action = 'ban'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListModerationActions :many
+SELECT a.id, a.community_id, a.user_id, a.actor_id, a.action, a.reason,
+    a.banned_until, a.created_at,
+    u.first_name AS actor_first_name, u.last_name AS actor_last_name
+FROM community_moderation_actions a
+LEFT JOIN users u ON u.id = a.actor_id
+WHERE a.community_id = @community_id AND a.user_id = @user_id
+ORDER BY a.created_at DESC;
@@ -0,0 +1,146 @@
+-- Post queries
+
+-- name: CreatePost :one
+INSERT INTO posts (author_type, author_user_id, author_community_id, content, photos, is_match_result, match_id, is_published, scheduled_at)
+VALUES (@author_type, @author_user_id, sqlc.narg('author_community_id'), @content, @photos, @is_match_result, sqlc.narg('match_id'),
+    @is_published, sqlc.narg('scheduled_at'))
+RETURNING id, author_type, author_user_id, author_community_id, content, photos, like_count, comment_count,
+    is_match_result, match_id, is_published, scheduled_at, created_at, updated_at;
+
+-- name: GetPostByID :one
+SELECT id, author_type, author_user_id, author_community_id, content, photos, like_count, comment_count,
+    is_match_result, match_id, is_published, scheduled_at, created_at, updated_at
+FROM posts
+WHERE id = @id;
+
+-- name: DeletePost :exec
+DELETE FROM posts WHERE id = @id;
+
+-- name: GetVisiblePost :one
+SELECT p.id, p.author_type, p.author_user_id, p.author_community_id, p.content, p.photos,
+    p.like_count, p.comment_count, p.is_match_result, p.match_id, p.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url,
+    c.name AS community_name, c.logo_url AS community_logo_url,
+    EXISTS (SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = @user_id) AS liked_by_me
+FROM posts p
+LEFT JOIN users u ON u.id = p.author_user_id
+LEFT JOIN communities c ON c.id = p.author_community_id
+WHERE p.id = @id
+  AND p.is_published = TRUE
+  AND (
+    (p.author_type = 'user' AND (p.author_user_id = @user_id OR EXISTS (
+        SELECT 1 FROM friends f
+        WHERE f.user_id = @user_id AND f.friend_id = p.author_user_id AND f.status = 'accepted')))
+    OR (p.author_type = 'community' AND EXISTS (
+        SELECT 1 FROM community_members cm
+        WHERE cm.community_id = p.author_community_id AND cm.user_id = @user_id AND cm.status = 'active'))
+  );
+
+-- name: ListFeed :many
+SELECT p.id, p.author_type, p.author_user_id, p.author_community_id, p.content, p.photos,
+    p.like_count, p.comment_count, p.is_match_result, p.match_id, p.created_at,
+    u.first_name AS author_first_name, u.last_name AS author_last_name, u.avatar_url AS author_avatar_url,
+    c.name AS community_name, c.logo_url AS community_logo_url,
+    EXISTS (SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = @user_id) AS liked_by_me
+FROM posts p
+LEFT JOIN users u ON u.id = p.author_user_id
+LEFT JOIN communities c ON c.id = p.author_community_id
+WHERE p.is_published = TRUE
+  AND (
+    (p.author_type = 'user' AND (p.author_user_id = @user_id OR EXISTS (
+        SELECT 1 FROM friends f
+        WHERE f.user_id = @user_id AND f.friend_id = p.author_user_id AND f.status = 'accepted')))
+    OR (p.author_type = 'community' AND EXISTS (
+        SELECT 1 FROM community_members cm
+        WHERE cm.community_id = p.author_community_id AND cm.user_id = @user_id AND cm.status = 'active'))
+  )
+  AND (sqlc.narg('before_time')::timestamptz IS NULL
+    OR (p.created_at, p.id) < (sqlc.narg('before_time')::timestamptz, sqlc.narg('before_id')::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT @result_limit;
+
+-- name: LikePost :one
+WITH inserted AS (
+    INSERT INTO post_likes (post_id, user_id)
+    VALUES (@post_id, @user_id)
+    ON CONFLICT (post_id, user_id) DO NOTHING
+    RETURNING post_id
+)
+UPDATE posts SET like_count = like_count + (SELECT COUNT(*) FROM inserted)
+WHERE id = @post_id
+RETURNING like_count;
+
+-- name: UnlikePost :one
+WITH deleted AS (
+    DELETE FROM post_likes
+    WHERE post_id = @post_id AND user_id = @user_id
+    RETURNING post_id
+)
+UPDATE posts SET like_count = GREATEST(like_count - (SELECT COUNT(*) FROM deleted), 0)
+WHERE id = @post_id
+RETURNING like_count;
+
+-- name: ListScheduledPosts :many
+SELECT id, author_type, author_user_id, author_community_id, content, photos, like_count, comment_count,
+    is_match_result, match_id, is_published, scheduled_at, created_at, updated_at
+FROM posts
+WHERE author_community_id = @community_id AND is_published = FALSE
+ORDER BY scheduled_at, id
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountScheduledPosts :one
+SELECT COUNT(*) FROM posts
+WHERE author_community_id = @community_id AND is_published = FALSE;
+
+-- name: UpdateScheduledPost :one
+UPDATE posts SET content = @content, scheduled_at = @scheduled_at
+WHERE id = @id AND author_community_id = @community_id AND is_published = FALSE
+RETURNING id, author_type, author_user_id, author_community_id, content, photos, like_count, comment_count,
+    is_match_result, match_id, is_published, scheduled_at, created_at, updated_at;
+
+-- name: GetScheduledPost :one
+SELECT id, author_type, author_user_id, author_community_id, content, photos, like_count, comment_count,
+    is_match_result, match_id, is_published, scheduled_at, created_at, updated_at
+FROM posts
+WHERE id = @id AND author_community_id = @community_id AND is_published = FALSE;
+
+-- name: CancelScheduledPost :one
+DELETE FROM posts
+WHERE id = @id AND author_community_id = @community_id AND is_published = FALSE
+RETURNING photos;
+
+-- name: PublishDuePosts :many
+UPDATE posts p SET is_published = TRUE, created_at = NOW()
+FROM communities c
+WHERE c.id = p.author_community_id
+  AND c.is_active = TRUE
+  AND p.id IN (
+    SELECT id FROM posts
+    WHERE is_published = FALSE AND scheduled_at <= @now
+      AND author_community_id IN (SELECT id FROM communities WHERE is_active = TRUE)
+    ORDER BY scheduled_at
+    LIMIT @batch_limit
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING p.id, p.author_community_id, p.author_user_id, p.content, c.name AS community_name;
+
+-- name: CreateCommunityPostNotifications :exec
+INSERT INTO notifications (user_id, type, title, body, data, is_pushed)
+SELECT cm.user_id, 'community_news', @title, @body, @data, TRUE
+FROM community_members cm
+JOIN users u ON u.id = cm.user_id
+WHERE cm.community_id = @community_id
+  AND cm.status = 'active'
+  AND u.status = 'active'
+  AND COALESCE((u.notification_settings ->> 'community_news')::boolean, TRUE)
+  AND cm.user_id != @author_id;
+
+-- name: ListMatchPostPlayers :many
+SELECT id, first_name, last_name, share_match_results
+FROM users
+WHERE id = ANY(@user_ids::uuid[]);
+
+-- name: CreateMatchResultPost :execrows
+INSERT INTO posts (author_type, author_user_id, author_community_id, content, is_match_result, match_id)
+VALUES (@author_type, sqlc.narg('author_user_id'), sqlc.narg('author_community_id'), @content, TRUE, @match_id)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,48 @@
+-- Game reminder queries
+
+-- name: ListDueEventReminders :many
+SELECT e.id AS event_id, e.title, e.start_time, e.location_name, ep.user_id
+FROM events e
+JOIN event_participants ep ON ep.event_id = e.id
+JOIN users u ON u.id = ep.user_id
+WHERE e.status IN ('published', 'registration_open', 'registration_closed')
+  AND e.start_time > @window_start::timestamptz
+  AND e.start_time <= @window_end::timestamptz
+  AND ep.status IN ('registered', 'confirmed')
+  AND u.status = 'active'
+  AND COALESCE((u.notification_settings ->> @reminder_type::text)::boolean, TRUE)
+  AND NOT EXISTS (
+      SELECT 1 FROM sent_reminders sr
+      WHERE sr.user_id = ep.user_id
+        AND sr.target_id = e.id
+        AND sr.reminder_type = @reminder_type::text
+  )
+ORDER BY e.start_time ASC
+LIMIT @batch_limit;
+
+-- name: ListDueMatchReminders :many
+SELECT m.id AS match_id, m.scheduled_time, m.court_number, u.id AS user_id
+FROM matches m
+JOIN users u ON u.id IN (m.player1_id, m.player2_id, m.player1_partner_id, m.player2_partner_id)
+WHERE m.result_status = 'pending'
+  AND m.scheduled_time > @window_start::timestamptz
+  AND m.scheduled_time <= @window_end::timestamptz
+  AND u.status = 'active'
+  AND COALESCE((u.notification_settings ->> @reminder_type::text)::boolean, TRUE)
+  AND NOT EXISTS (
+      SELECT 1 FROM sent_reminders sr
+      WHERE sr.user_id = u.id
+        AND sr.target_id = m.id
+        AND sr.reminder_type = @reminder_type::text
+  )
+ORDER BY m.scheduled_time ASC
+LIMIT @batch_limit;
+
+-- name: ClaimReminder :execrows
+INSERT INTO sent_reminders (user_id, target_type, target_id, reminder_type)
+VALUES (@user_id, @target_type, @target_id, @reminder_type)
+ON CONFLICT (user_id, target_id, reminder_type) DO NOTHING;
+
+-- name: ReleaseReminder :exec
+DELETE FROM sent_reminders
+WHERE user_id = @user_id AND target_id = @target_id AND reminder_type = @reminder_type;
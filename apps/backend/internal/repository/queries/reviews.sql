@@ -0,0 +1,142 @@
+-- Court review queries
+
+-- name: UpsertCourtReview :one
+INSERT INTO court_reviews (
+    court_id, user_id,
+    surface_score, lighting_score, changing_rooms_score, value_score,
+    body
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (court_id, user_id) DO UPDATE SET
+    surface_score = EXCLUDED.surface_score,
+    lighting_score = EXCLUDED.lighting_score,
+    changing_rooms_score = EXCLUDED.changing_rooms_score,
+    value_score = EXCLUDED.value_score,
+    body = EXCLUDED.body,
+    updated_at = NOW()
+RETURNING id, court_id, user_id,
+    surface_score, lighting_score, changing_rooms_score, value_score,
+    body, status, moderation_note, moderated_by, moderated_at,
+    created_at, updated_at;
+
+-- name: GetCourtReviewByID :one
+SELECT id, court_id, user_id,
+    surface_score, lighting_score, changing_rooms_score, value_score,
+    body, status, moderation_note, moderated_by, moderated_at,
+    created_at, updated_at
+FROM court_reviews
+WHERE id = $1;
+
+-- name: ListCourtReviews :many
+SELECT r.id, r.court_id, r.user_id,
+    r.surface_score, r.lighting_score, r.changing_rooms_score, r.value_score,
+    r.body, r.created_at, r.updated_at,
+    u.first_name, u.last_name, u.avatar_url
+FROM court_reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.court_id = @court_id AND r.status = 'published'
+ORDER BY r.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCourtReviews :one
+SELECT COUNT(*)
+FROM court_reviews
+WHERE court_id = $1 AND status = 'published';
+
+-- name: ListCourtReviewsByStatus :many
+SELECT r.id, r.court_id, r.user_id,
+    r.surface_score, r.lighting_score, r.changing_rooms_score, r.value_score,
+    r.body, r.status, r.moderation_note, r.moderated_by, r.moderated_at,
+    r.created_at, r.updated_at,
+    c.name AS court_name, u.first_name, u.last_name
+FROM court_reviews r
+JOIN courts c ON c.id = r.court_id
+JOIN users u ON u.id = r.user_id
+WHERE r.status = @status
+ORDER BY r.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCourtReviewsByStatus :one
+SELECT COUNT(*)
+FROM court_reviews
+WHERE status = $1;
+
+-- name: ModerateCourtReview :one
+UPDATE court_reviews SET
+    status = @status,
+    moderation_note = @moderation_note,
+    moderated_by = @moderated_by,
+    moderated_at = NOW(),
+    updated_at = NOW()
+WHERE id = @id
+RETURNING id, court_id, user_id,
+    surface_score, lighting_score, changing_rooms_score, value_score,
+    body, status, moderation_note, moderated_by, moderated_at,
+    created_at, updated_at;
+
+-- name: DeleteCourtReview :execrows
+DELETE FROM court_reviews
+WHERE id = @id AND user_id = @user_id;
+
+-- name: HasPlayedAtCourt :one
+SELECT EXISTS (
+    SELECT 1
+    FROM events e
+    JOIN event_participants ep ON ep.event_id = e.id
+    WHERE e.court_id = @court_id
+      AND ep.user_id = @user_id
+      AND ep.status NOT IN ('cancelled', 'no_show')
+      AND e.status NOT IN ('draft', 'cancelled')
+      AND e.start_time < NOW()
+) OR EXISTS (
+    SELECT 1
+    FROM matches m
+    JOIN events e ON e.id = m.event_id
+    WHERE e.court_id = @court_id
+      AND @user_id IN (m.player1_id, m.player2_id, m.player1_partner_id, m.player2_partner_id)
+      AND m.result_status IN ('confirmed', 'admin_confirmed')
+) AS played;
+
+-- name: RefreshCourtRating :exec
+INSERT INTO court_ratings (
+    court_id, review_count, avg_rating,
+    avg_surface, avg_lighting, avg_changing_rooms, avg_value, updated_at
+)
+SELECT
+    @court_id::uuid,
+    COUNT(*),
+    ROUND(AVG((
+        COALESCE(surface_score, 0) + COALESCE(lighting_score, 0)
+        + COALESCE(changing_rooms_score, 0) + COALESCE(value_score, 0)
+    )::numeric / (
+        (surface_score IS NOT NULL)::int + (lighting_score IS NOT NULL)::int
+        + (changing_rooms_score IS NOT NULL)::int + (value_score IS NOT NULL)::int
+    )), 2),
+    ROUND(AVG(surface_score), 2),
+    ROUND(AVG(lighting_score), 2),
+    ROUND(AVG(changing_rooms_score), 2),
+    ROUND(AVG(value_score), 2),
+    NOW()
+FROM court_reviews
+WHERE court_id = @court_id::uuid AND status = 'published'
+ON CONFLICT (court_id) DO UPDATE SET
+    review_count = EXCLUDED.review_count,
+    avg_rating = EXCLUDED.avg_rating,
+    avg_surface = EXCLUDED.avg_surface,
+    avg_lighting = EXCLUDED.avg_lighting,
+    avg_changing_rooms = EXCLUDED.avg_changing_rooms,
+    avg_value = EXCLUDED.avg_value,
+    updated_at = NOW();
+
+-- name: GetCourtRating :one
+SELECT court_id, review_count, avg_rating,
+    avg_surface, avg_lighting, avg_changing_rooms, avg_value, updated_at
+FROM court_ratings
+WHERE court_id = $1;
+
+-- name: ListCourtRatings :many
+SELECT court_id, review_count, avg_rating,
+    avg_surface, avg_lighting, avg_changing_rooms, avg_value, updated_at
+FROM court_ratings
+WHERE court_id = ANY(@court_ids::uuid[]);
@@ -0,0 +1,151 @@
+-- Team competition queries
+
+-- name: CreateTeam :one
+INSERT INTO teams (
+    community_id, name, captain_id
+) VALUES (
+    @community_id, @name, sqlc.narg('captain_id')
+)
+RETURNING id, community_id, name, captain_id, created_at, updated_at;
+
+-- name: GetTeam :one
+SELECT id, community_id, name, captain_id, created_at, updated_at
+FROM teams
+WHERE id = @id;
+
+-- name: UpdateTeam :one
+UPDATE teams SET
+    name = COALESCE(sqlc.narg('name'), name),
+    captain_id = COALESCE(sqlc.narg('captain_id'), captain_id)
+WHERE id = @id
+RETURNING id, community_id, name, captain_id, created_at, updated_at;
+
+-- name: ListCommunityTeams :many
+SELECT t.id, t.community_id, t.name, t.captain_id, t.created_at, t.updated_at,
+    u.first_name AS captain_first_name, u.last_name AS captain_last_name,
+    (SELECT COUNT(*) FROM team_members tm WHERE tm.team_id = t.id) AS member_count
+FROM teams t
+LEFT JOIN users u ON u.id = t.captain_id
+WHERE t.community_id = @community_id
+ORDER BY t.name;
+
+-- name: AddTeamMember :execrows
+INSERT INTO team_members (team_id, user_id)
+VALUES (@team_id, @user_id)
+ON CONFLICT (team_id, user_id) DO NOTHING;
+
+-- name: RemoveTeamMember :execrows
+DELETE FROM team_members
+WHERE team_id = @team_id AND user_id = @user_id;
+
+-- name: ListTeamMembers :many
+SELECT tm.user_id, tm.joined_at,
+    u.first_name, u.last_name, u.avatar_url, u.ntrp_level
+FROM team_members tm
+JOIN users u ON u.id = tm.user_id
+WHERE tm.team_id = @team_id
+ORDER BY u.last_name, u.first_name;
+
+-- name: CreateTeamTie :one
+INSERT INTO team_ties (
+    community_id, event_id, home_team_id, away_team_id, scheduled_time, created_by
+) VALUES (
+    @community_id, sqlc.narg('event_id'), @home_team_id, @away_team_id, sqlc.narg('scheduled_time'), @created_by
+)
+RETURNING id, community_id, event_id, home_team_id, away_team_id, scheduled_time,
+    lineups_locked_at, created_by, created_at;
+
+-- name: CreateTeamTieRubber :exec
+INSERT INTO team_tie_rubbers (tie_id, rubber_number, composition)
+VALUES (@tie_id, @rubber_number, @composition);
+
+-- name: GetTeamTie :one
+SELECT id, community_id, event_id, home_team_id, away_team_id, scheduled_time,
+    lineups_locked_at, created_by, created_at
+FROM team_ties
+WHERE id = @id;
+
+-- name: LockTeamTie :one
+SELECT id, community_id, event_id, home_team_id, away_team_id, scheduled_time,
+    lineups_locked_at, created_by, created_at
+FROM team_ties
+WHERE id = @id
+FOR UPDATE;
+
+-- name: SetTeamTieLineupsLocked :exec
+UPDATE team_ties SET lineups_locked_at = NOW()
+WHERE id = @id;
+
+-- name: ListTeamTieRubbers :many
+SELECT r.rubber_number, r.composition, r.match_id,
+    m.player1_id, m.player1_partner_id, m.player2_id, m.player2_partner_id,
+    m.score, m.winner_id, m.result_status
+FROM team_tie_rubbers r
+LEFT JOIN matches m ON m.id = r.match_id
+WHERE r.tie_id = @tie_id
+ORDER BY r.rubber_number;
+
+-- name: SetTeamTieRubberMatch :exec
+UPDATE team_tie_rubbers SET match_id = @match_id
+WHERE tie_id = @tie_id AND rubber_number = @rubber_number;
+
+-- name: DeleteTeamLineup :exec
+DELETE FROM team_tie_lineups
+WHERE tie_id = @tie_id AND team_id = @team_id;
+
+-- name: CreateTeamLineupEntry :exec
+INSERT INTO team_tie_lineups (
+    tie_id, team_id, rubber_number, player_id, partner_id, submitted_by
+) VALUES (
+    @tie_id, @team_id, @rubber_number, @player_id, sqlc.narg('partner_id'), @submitted_by
+);
+
+-- name: ListTeamTieLineups :many
+SELECT l.team_id, l.rubber_number, l.player_id, l.partner_id, l.submitted_by, l.submitted_at,
+    p.first_name AS player_first_name, p.last_name AS player_last_name,
+    pp.first_name AS partner_first_name, pp.last_name AS partner_last_name
+FROM team_tie_lineups l
+JOIN users p ON p.id = l.player_id
+LEFT JOIN users pp ON pp.id = l.partner_id
+WHERE l.tie_id = @tie_id
+ORDER BY l.team_id, l.rubber_number;
+
+-- name: ListCommunityTeamTies :many
+SELECT t.id, t.community_id, t.event_id, t.home_team_id, t.away_team_id, t.scheduled_time,
+    t.lineups_locked_at, t.created_by, t.created_at,
+    ht.name AS home_team_name, at.name AS away_team_name,
+    (SELECT COUNT(*) FROM team_tie_rubbers r WHERE r.tie_id = t.id)::int AS rubber_count,
+    (SELECT COUNT(*) FROM team_tie_rubbers r JOIN matches m ON m.id = r.match_id
+     WHERE r.tie_id = t.id AND m.result_status IN ('confirmed', 'admin_confirmed')
+       AND m.winner_id IN (m.player1_id, m.player1_partner_id))::int AS home_points,
+    (SELECT COUNT(*) FROM team_tie_rubbers r JOIN matches m ON m.id = r.match_id
+     WHERE r.tie_id = t.id AND m.result_status IN ('confirmed', 'admin_confirmed')
+       AND m.winner_id IN (m.player2_id, m.player2_partner_id))::int AS away_points
+FROM team_ties t
+JOIN teams ht ON ht.id = t.home_team_id
+JOIN teams at ON at.id = t.away_team_id
+WHERE t.community_id = @community_id
+ORDER BY t.scheduled_time DESC NULLS LAST, t.created_at DESC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountCommunityTeamTies :one
+SELECT COUNT(*)
+FROM team_ties
+WHERE community_id = @community_id;
+
+-- name: ListEventTeamTies :many
+SELECT t.id, t.community_id, t.event_id, t.home_team_id, t.away_team_id, t.scheduled_time,
+    t.lineups_locked_at, t.created_by, t.created_at,
+    ht.name AS home_team_name, at.name AS away_team_name,
+    (SELECT COUNT(*) FROM team_tie_rubbers r WHERE r.tie_id = t.id)::int AS rubber_count,
+    (SELECT COUNT(*) FROM team_tie_rubbers r JOIN matches m ON m.id = r.match_id
+     WHERE r.tie_id = t.id AND m.result_status IN ('confirmed', 'admin_confirmed')
+       AND m.winner_id IN (m.player1_id, m.player1_partner_id))::int AS home_points,
+    (SELECT COUNT(*) FROM team_tie_rubbers r JOIN matches m ON m.id = r.match_id
+     WHERE r.tie_id = t.id AND m.result_status IN ('confirmed', 'admin_confirmed')
+       AND m.winner_id IN (m.player2_id, m.player2_partner_id))::int AS away_points
+FROM team_ties t
+JOIN teams ht ON ht.id = t.home_team_id
+JOIN teams at ON at.id = t.away_team_id
+WHERE t.event_id = @event_id
+ORDER BY t.scheduled_time, t.created_at;
@@ -12,7 +12,7 @@ RETURNING id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at;
+    created_at, updated_at, share_match_results;
 
 -- name: GetUserByID :one
 SELECT id, phone, phone_verified,
@@ -23,7 +23,7 @@ SELECT id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 FROM users
 WHERE id = $1 AND status != 'deleted';
 
@@ -36,7 +36,7 @@ SELECT id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 FROM users
 WHERE phone = $1 AND status != 'deleted';
 
@@ -64,6 +64,7 @@ UPDATE users SET
     notification_settings = COALESCE(sqlc.narg('notification_settings'), notification_settings),
     is_profile_complete  = COALESCE(sqlc.narg('is_profile_complete'), is_profile_complete),
     last_active_at       = COALESCE(sqlc.narg('last_active_at'), last_active_at),
+    share_match_results  = COALESCE(sqlc.narg('share_match_results'), share_match_results),
     updated_at           = NOW()
 WHERE id = @id AND status != 'deleted'
 RETURNING id, phone, phone_verified,
@@ -74,7 +75,14 @@ RETURNING id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at;
+    created_at, updated_at, share_match_results;
+
+-- name: MergeNotificationSettings :one
+UPDATE users SET
+    notification_settings = COALESCE(notification_settings, '{}'::jsonb) || @settings::jsonb,
+    updated_at            = NOW()
+WHERE id = @id AND status != 'deleted'
+RETURNING notification_settings;
 
 -- name: SearchUsers :many
 SELECT id, phone, phone_verified,
@@ -0,0 +1,54 @@
+-- Community verification queries
+
+-- name: CreateVerificationRequest :one
+INSERT INTO community_verification_requests (
+    community_id, submitted_by, documents, comment
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING id, community_id, submitted_by, documents, comment,
+    status, review_note, reviewed_by, reviewed_at, created_at;
+
+-- name: GetLatestVerificationRequest :one
+SELECT id, community_id, submitted_by, documents, comment,
+    status, review_note, reviewed_by, reviewed_at, created_at
+FROM community_verification_requests
+WHERE community_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListVerificationRequests :many
+SELECT r.id, r.community_id, r.submitted_by, r.documents, r.comment,
+    r.status, r.review_note, r.reviewed_by, r.reviewed_at, r.created_at,
+    c.name AS community_name, c.slug AS community_slug,
+    c.community_type, c.logo_url, c.member_count,
+    u.first_name AS submitter_first_name, u.last_name AS submitter_last_name
+FROM community_verification_requests r
+JOIN communities c ON c.id = r.community_id
+JOIN users u ON u.id = r.submitted_by
+WHERE r.status = @status
+ORDER BY r.created_at ASC
+LIMIT @result_limit OFFSET @result_offset;
+
+-- name: CountVerificationRequests :one
+SELECT COUNT(*)
+FROM community_verification_requests
+WHERE status = $1;
+
+-- name: ReviewVerificationRequest :one
+UPDATE community_verification_requests SET
+    status = @status,
+    review_note = @review_note,
+    reviewed_by = @reviewed_by,
+    reviewed_at = NOW()
+WHERE community_id = @community_id AND status = 'pending'
+RETURNING id, community_id, submitted_by, documents, comment,
+    status, review_note, reviewed_by, reviewed_at, created_at;
+
+-- name: SetCommunityVerification :exec
+UPDATE communities SET
+    verification_status = @verification_status,
+    verified_at = CASE WHEN @verification_status = 'verified' THEN NOW() ELSE NULL END,
+    verification_documents = @verification_documents,
+    updated_at = NOW()
+WHERE id = @id;
@@ -58,7 +58,7 @@ RETURNING id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 `
 
 func (q *Queries) CreateUser(ctx context.Context, phone string) (User, error) {
@@ -94,6 +94,7 @@ func (q *Queries) CreateUser(ctx context.Context, phone string) (User, error) {
 		&i.LastActiveAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShareMatchResults,
 	)
 	return i, err
 }
@@ -107,7 +108,7 @@ SELECT id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 FROM users
 WHERE id = $1 AND status != 'deleted'
 `
@@ -145,6 +146,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.LastActiveAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShareMatchResults,
 	)
 	return i, err
 }
@@ -158,7 +160,7 @@ SELECT id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 FROM users
 WHERE phone = $1 AND status != 'deleted'
 `
@@ -196,10 +198,31 @@ func (q *Queries) GetUserByPhone(ctx context.Context, phone string) (User, error
 		&i.LastActiveAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShareMatchResults,
 	)
 	return i, err
 }
 
+const mergeNotificationSettings = `-- name: MergeNotificationSettings :one
+UPDATE users SET
+    notification_settings = COALESCE(notification_settings, '{}'::jsonb) || $1::jsonb,
+    updated_at            = NOW()
+WHERE id = $2 AND status != 'deleted'
+RETURNING notification_settings
+`
+
+type MergeNotificationSettingsParams struct {
+	Settings []byte      `json:"settings"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) MergeNotificationSettings(ctx context.Context, arg MergeNotificationSettingsParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, mergeNotificationSettings, arg.Settings, arg.ID)
+	var notification_settings []byte
+	err := row.Scan(&notification_settings)
+	return notification_settings, err
+}
+
 const searchUsers = `-- name: SearchUsers :many
 SELECT id, phone, phone_verified,
     first_name, last_name, gender, birth_year, city, district, avatar_url, bio,
@@ -344,8 +367,9 @@ UPDATE users SET
     notification_settings = COALESCE($20, notification_settings),
     is_profile_complete  = COALESCE($21, is_profile_complete),
     last_active_at       = COALESCE($22, last_active_at),
+    share_match_results  = COALESCE($23, share_match_results),
     updated_at           = NOW()
-WHERE id = $23 AND status != 'deleted'
+WHERE id = $24 AND status != 'deleted'
 RETURNING id, phone, phone_verified,
     first_name, last_name, gender, birth_year, city, district, avatar_url, bio,
     ntrp_level, level_label, quiz_completed,
@@ -354,7 +378,7 @@ RETURNING id, phone, phone_verified,
     profile_visibility, allow_messages_from, show_stats,
     notification_settings,
     status, is_profile_complete, last_active_at,
-    created_at, updated_at
+    created_at, updated_at, share_match_results
 `
 
 type UpdateUserParams struct {
@@ -380,6 +404,7 @@ type UpdateUserParams struct {
 	NotificationSettings []byte             `json:"notification_settings"`
 	IsProfileComplete    pgtype.Bool        `json:"is_profile_complete"`
 	LastActiveAt         pgtype.Timestamptz `json:"last_active_at"`
+	ShareMatchResults    pgtype.Bool        `json:"share_match_results"`
 	ID                   pgtype.UUID        `json:"id"`
 }
 
@@ -407,6 +432,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.NotificationSettings,
 		arg.IsProfileComplete,
 		arg.LastActiveAt,
+		arg.ShareMatchResults,
 		arg.ID,
 	)
 	var i User
@@ -440,6 +466,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.LastActiveAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShareMatchResults,
 	)
 	return i, err
 }
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	announcementMaxTitle = 200
+	announcementMaxBody  = 4000
+	// announcementPushQueue is how many filtered announcements may wait for their pushes
+	announcementPushQueue = 100
+)
+
+// AnnouncementService sends community announcements. Like the game reminders,
+// announcements skip members who turned community_news off: they get neither
+// an in-app notification nor a push. Announcements to all members are pushed
+// once through the community news topic; filtered ones are pushed to each
+// recipient by Run, in the background.
+type AnnouncementService struct {
+	repo     *repository.Queries
+	pool     txStarter
+	firebase *FirebaseService
+	pushes   chan announcementPush
+}
+
+// announcementPush is a filtered announcement waiting for its pushes
+type announcementPush struct {
+	userIDs []uuid.UUID
+	title   string
+	body    string
+	data    map[string]any
+}
+
+// NewAnnouncementService creates a new AnnouncementService
+func NewAnnouncementService(repo *repository.Queries, pool txStarter, firebase *FirebaseService) *AnnouncementService {
+	return &AnnouncementService{
+		repo:     repo,
+		pool:     pool,
+		firebase: firebase,
+		pushes:   make(chan announcementPush, announcementPushQueue),
+	}
+}
+
+// Run pushes queued filtered announcements until ctx is cancelled
+func (s *AnnouncementService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p := <-s.pushes:
+			s.push(ctx, p)
+		}
+	}
+}
+
+// CreateAnnouncementInput is an announcement and its optional audience filter
+type CreateAnnouncementInput struct {
+	Title      string   `json:"title"`
+	Body       string   `json:"body"`
+	TargetRole string   `json:"target_role"`
+	MinNTRP    *float64 `json:"min_ntrp"`
+	MaxNTRP    *float64 `json:"max_ntrp"`
+}
+
+// filtered reports whether the audience is narrower than all members
+func (in CreateAnnouncementInput) filtered() bool {
+	return in.TargetRole != "" || in.MinNTRP != nil || in.MaxNTRP != nil
+}
+
+func (in *CreateAnnouncementInput) validate() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Body = strings.TrimSpace(in.Body)
+
+	if in.Title == "" {
+		return ErrValidation.WithMessage("title is required")
+	}
+	if utf8.RuneCountInString(in.Title) > announcementMaxTitle {
+		return ErrValidation.WithMessage(fmt.Sprintf("title must be at most %d characters", announcementMaxTitle))
+	}
+	if in.Body == "" {
+		return ErrValidation.WithMessage("body is required")
+	}
+	if utf8.RuneCountInString(in.Body) > announcementMaxBody {
+		return ErrValidation.WithMessage(fmt.Sprintf("body must be at most %d characters", announcementMaxBody))
+	}
+
+	switch repository.CommunityRole(in.TargetRole) {
+	case "", repository.CommunityRoleOwner, repository.CommunityRoleAdmin,
+		repository.CommunityRoleModerator, repository.CommunityRoleMember:
+	default:
+		return ErrValidation.WithMessage("target_role must be owner, admin, moderator or member")
+	}
+
+	for _, level := range []*float64{in.MinNTRP, in.MaxNTRP} {
+		if level != nil && (*level < 1.0 || *level > 7.0) {
+			return ErrValidation.WithMessage("NTRP level must be between 1.0 and 7.0")
+		}
+	}
+	if in.MinNTRP != nil && in.MaxNTRP != nil && *in.MinNTRP > *in.MaxNTRP {
+		return ErrValidation.WithMessage("min_ntrp must not exceed max_ntrp")
+	}
+	return nil
+}
+
+// Create stores an announcement, creates the in-app notifications and sends
+// the pushes. The author gets no notification of their own announcement.
+func (s *AnnouncementService) Create(ctx context.Context, authorID, communityID uuid.UUID, input CreateAnnouncementInput) (map[string]interface{}, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	community, err := s.repo.GetCommunityActiveState(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+	if !community.IsActive.Bool {
+		return nil, ErrForbidden.WithMessage("Community is deactivated")
+	}
+
+	targetRole := repository.NullCommunityRole{}
+	if input.TargetRole != "" {
+		targetRole = repository.NullCommunityRole{CommunityRole: repository.CommunityRole(input.TargetRole), Valid: true}
+	}
+	minNTRP, maxNTRP := pgtype.Numeric{}, pgtype.Numeric{}
+	if input.MinNTRP != nil {
+		minNTRP = floatToNumeric(*input.MinNTRP)
+	}
+	if input.MaxNTRP != nil {
+		maxNTRP = floatToNumeric(*input.MaxNTRP)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	announcement, err := qtx.CreateCommunityAnnouncement(ctx, repository.CreateCommunityAnnouncementParams{
+		CommunityID: community.ID,
+		AuthorID:    uuidToPgtype(authorID),
+		Title:       input.Title,
+		Body:        input.Body,
+		TargetRole:  targetRole,
+		MinNtrp:     minNTRP,
+		MaxNtrp:     maxNTRP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create announcement: %w", err)
+	}
+
+	data := announcementData(announcement.ID, community.ID, community.Name)
+	dataJSON, _ := json.Marshal(data)
+
+	recipients, err := qtx.CreateAnnouncementNotifications(ctx, repository.CreateAnnouncementNotificationsParams{
+		Title:       input.Title,
+		Body:        input.Body,
+		Data:        dataJSON,
+		CommunityID: community.ID,
+		AuthorID:    uuidToPgtype(authorID),
+		TargetRole:  targetRole,
+		MinNtrp:     minNTRP,
+		MaxNtrp:     maxNTRP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create notifications: %w", err)
+	}
+
+	pushTo := make([]uuid.UUID, 0, len(recipients))
+	for _, r := range recipients {
+		pushTo = append(pushTo, uuid.UUID(r.Bytes))
+	}
+
+	announcement.RecipientCount = int32(len(recipients))
+	announcement.PushCount = int32(len(pushTo))
+	if err := qtx.SetAnnouncementCounts(ctx, repository.SetAnnouncementCountsParams{
+		RecipientCount: announcement.RecipientCount,
+		PushCount:      announcement.PushCount,
+		ID:             announcement.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("set announcement counts: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	switch {
+	case !input.filtered():
+		topic := CommunityNewsTopic(communityID)
+		if err := s.firebase.SendToTopic(ctx, topic, input.Title, input.Body, data); err != nil {
+			slog.Warn("failed to send announcement to topic", "topic", topic, "error", err)
+		}
+	case len(pushTo) > 0:
+		select {
+		case s.pushes <- announcementPush{userIDs: pushTo, title: input.Title, body: input.Body, data: data}:
+		default:
+			slog.Warn("announcement push queue is full, pushes dropped", "announcement_id", pgtypeUUIDToStringRequired(announcement.ID))
+		}
+	}
+
+	return announcementResponse(repository.GetCommunityAnnouncementRow{
+		ID:             announcement.ID,
+		CommunityID:    announcement.CommunityID,
+		AuthorID:       announcement.AuthorID,
+		Title:          announcement.Title,
+		Body:           announcement.Body,
+		TargetRole:     announcement.TargetRole,
+		MinNtrp:        announcement.MinNtrp,
+		MaxNtrp:        announcement.MaxNtrp,
+		RecipientCount: announcement.RecipientCount,
+		PushCount:      announcement.PushCount,
+		CreatedAt:      announcement.CreatedAt,
+	}), nil
+}
+
+// push delivers a filtered announcement to each recipient, stopping early on shutdown
+func (s *AnnouncementService) push(ctx context.Context, p announcementPush) {
+	for _, userID := range p.userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.firebase.SendToUser(ctx, userID, p.title, p.body, p.data); err != nil {
+			slog.Warn("failed to send announcement push", "user_id", userID, "error", err)
+		}
+	}
+}
+
+// Get returns an announcement with its delivery and read counts
+func (s *AnnouncementService) Get(ctx context.Context, communityID, announcementID uuid.UUID) (map[string]interface{}, error) {
+	a, err := s.repo.GetCommunityAnnouncement(ctx, repository.GetCommunityAnnouncementParams{
+		ID:          uuidToPgtype(announcementID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get announcement: %w", err)
+	}
+	return announcementResponse(a), nil
+}
+
+// List returns the community's announcements, newest first
+func (s *AnnouncementService) List(ctx context.Context, communityID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	rows, err := s.repo.ListCommunityAnnouncements(ctx, repository.ListCommunityAnnouncementsParams{
+		CommunityID:  uuidToPgtype(communityID),
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list announcements: %w", err)
+	}
+
+	total, err := s.repo.CountCommunityAnnouncements(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count announcements: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, announcementResponse(repository.GetCommunityAnnouncementRow(r)))
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// announcementData is the payload of the notification and the push
+func announcementData(announcementID, communityID pgtype.UUID, communityName string) map[string]any {
+	return map[string]any{
+		"announcement_id": pgtypeUUIDToStringRequired(announcementID),
+		"community_id":    pgtypeUUIDToStringRequired(communityID),
+		"community_name":  communityName,
+	}
+}
+
+func announcementResponse(a repository.GetCommunityAnnouncementRow) map[string]interface{} {
+	audience := map[string]interface{}{}
+	if a.TargetRole.Valid {
+		audience["role"] = string(a.TargetRole.CommunityRole)
+	}
+	if a.MinNtrp.Valid {
+		audience["min_ntrp"] = numericToFloat(a.MinNtrp)
+	}
+	if a.MaxNtrp.Valid {
+		audience["max_ntrp"] = numericToFloat(a.MaxNtrp)
+	}
+
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(a.ID),
+		"community_id": pgtypeUUIDToStringRequired(a.CommunityID),
+		"title":        a.Title,
+		"body":         a.Body,
+		"audience":     audience,
+		"stats": map[string]interface{}{
+			"recipients": a.RecipientCount,
+			"pushed":     a.PushCount,
+			"read":       a.ReadCount,
+			"read_rate":  percentage(int64(a.ReadCount), int64(a.RecipientCount)),
+		},
+		"created_at": a.CreatedAt.Time,
+	}
+	if a.AuthorID.Valid {
+		result["author"] = map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(a.AuthorID),
+			"first_name": a.AuthorFirstName.String,
+			"last_name":  a.AuthorLastName.String,
+		}
+	}
+	return result
+}
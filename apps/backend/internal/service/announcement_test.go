@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCreateAnnouncementInputValidate(t *testing.T) {
+	level := func(f float64) *float64 { return &f }
+
+	valid := CreateAnnouncementInput{Title: "  Турнир в субботу ", Body: "Регистрация открыта"}
+	if err := valid.validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if valid.Title != "Турнир в субботу" {
+		t.Errorf("title was not trimmed: %q", valid.Title)
+	}
+
+	tests := []CreateAnnouncementInput{
+		{Body: "body"},
+		{Title: "title"},
+		{Title: strings.Repeat("а", announcementMaxTitle+1), Body: "body"},
+		{Title: "title", Body: "body", TargetRole: "coach"},
+		{Title: "title", Body: "body", MinNTRP: level(0.5)},
+		{Title: "title", Body: "body", MinNTRP: level(4.0), MaxNTRP: level(3.0)},
+	}
+	for i, in := range tests {
+		if err := in.validate(); err == nil {
+			t.Errorf("case %d: expected a validation error", i)
+		}
+	}
+
+	filtered := CreateAnnouncementInput{Title: "title", Body: "body", TargetRole: "admin", MinNTRP: level(3.0)}
+	if err := filtered.validate(); err != nil {
+		t.Errorf("expected a valid filtered announcement, got err %v", err)
+	}
+	if !filtered.filtered() || valid.filtered() {
+		t.Error("only announcements with a role or NTRP filter should count as filtered")
+	}
+}
+
+func TestCreateAnnouncementPushesToAllMembersThroughTopic(t *testing.T) {
+	ctx := context.Background()
+	db, communityID := newAnnouncementDB()
+	authorID := uuid.New()
+	db.rows("CreateAnnouncementNotifications", []any{uuidToPgtype(uuid.New())}, []any{uuidToPgtype(uuid.New())})
+
+	service := NewAnnouncementService(db.queries(), db, nil)
+	result, err := service.Create(ctx, authorID, communityID, CreateAnnouncementInput{Title: "Турнир в субботу", Body: "Регистрация открыта"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !db.committed() {
+		t.Fatal("create did not commit")
+	}
+
+	notifications := db.called("CreateAnnouncementNotifications")[0]
+	if author := notifications.Args[4]; author != uuidToPgtype(authorID) {
+		t.Errorf("notifications excluded %v, want the author", author)
+	}
+	if !strings.Contains(notifications.SQL, "AND COALESCE((u.notification_settings ->> 'community_news')::boolean, TRUE)") {
+		t.Error("notifications do not skip members who turned community_news off")
+	}
+	counts := db.called("SetAnnouncementCounts")
+	if len(counts) != 1 || counts[0].Args[0] != int32(2) || counts[0].Args[1] != int32(2) {
+		t.Errorf("counts = %+v, want 2 recipients and 2 pushes", counts)
+	}
+	stats := result["stats"].(map[string]interface{})
+	if stats["recipients"] != int32(2) || stats["pushed"] != int32(2) {
+		t.Errorf("stats = %v, want 2 recipients and 2 pushes", stats)
+	}
+	if len(service.pushes) != 0 {
+		t.Errorf("queued %d per-user pushes, want the topic only", len(service.pushes))
+	}
+}
+
+func TestCreateAnnouncementQueuesFilteredPushes(t *testing.T) {
+	ctx := context.Background()
+	db, communityID := newAnnouncementDB()
+	admin := uuid.New()
+	db.rows("CreateAnnouncementNotifications", []any{uuidToPgtype(admin)})
+
+	service := NewAnnouncementService(db.queries(), db, nil)
+	input := CreateAnnouncementInput{Title: "Сбор админов", Body: "В пятницу в 19:00", TargetRole: "admin"}
+	if _, err := service.Create(ctx, uuid.New(), communityID, input); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if len(service.pushes) != 1 {
+		t.Fatalf("queued %d announcements, want 1", len(service.pushes))
+	}
+	if p := <-service.pushes; len(p.userIDs) != 1 || p.userIDs[0] != admin {
+		t.Errorf("queued pushes to %v, want only %v", p.userIDs, admin)
+	}
+}
+
+func newAnnouncementDB() (*fakeDB, uuid.UUID) {
+	db := newFakeDB()
+	communityID := uuid.New()
+	db.rows("GetCommunityActiveState", []any{repository.GetCommunityActiveStateRow{
+		ID:       uuidToPgtype(communityID),
+		Name:     "Теннисный клуб Алматы",
+		IsActive: pgtype.Bool{Bool: true, Valid: true},
+	}})
+	db.on("CreateCommunityAnnouncement", func(args []any) ([][]any, error) {
+		return [][]any{{repository.CommunityAnnouncement{
+			ID:          uuidToPgtype(uuid.New()),
+			CommunityID: args[0].(pgtype.UUID),
+			AuthorID:    args[1].(pgtype.UUID),
+			Title:       args[2].(string),
+			Body:        args[3].(string),
+		}}}, nil
+	})
+	db.rows("SetAnnouncementCounts", []any{})
+	return db, communityID
+}
@@ -24,6 +24,7 @@ type AuthService struct {
 	repo         *repository.Queries
 	redis        *redis.Client
 	tokenService *TokenService
+	news         *CommunityNews
 	environment  string
 }
 
@@ -48,12 +49,14 @@ func NewAuthService(
 	repo *repository.Queries,
 	redis *redis.Client,
 	tokenService *TokenService,
+	news *CommunityNews,
 	environment string,
 ) *AuthService {
 	return &AuthService{
 		repo:         repo,
 		redis:        redis,
 		tokenService: tokenService,
+		news:         news,
 		environment:  environment,
 	}
 }
@@ -180,6 +183,33 @@ func (s *AuthService) VerifyOTP(ctx context.Context, sessionID, code string) (*A
 		return nil, fmt.Errorf("parse user UUID: %w", err)
 	}
 
+	// Placeholder created by a community import - the first login claims it
+	// and goes through profile setup like a new user
+	if user.Status.Valid && user.Status.UserStatus == repository.UserStatusPlaceholder {
+		if err := s.repo.ClaimPlaceholderUser(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("claim placeholder user: %w", err)
+		}
+		// Imported memberships were created before the user had any devices
+		s.news.Sync(ctx, userUUID)
+
+		tempToken, err := s.tokenService.GenerateAccessToken(userUUID, "user")
+		if err != nil {
+			return nil, fmt.Errorf("generate temp token: %w", err)
+		}
+
+		return &AuthResult{
+			IsNewUser: true,
+			TempToken: tempToken,
+			User: map[string]interface{}{
+				"id":                  userUUID.String(),
+				"phone":               maskPhone(user.Phone),
+				"first_name":          user.FirstName.String,
+				"last_name":           user.LastName.String,
+				"is_profile_complete": false,
+			},
+		}, nil
+	}
+
 	// Existing user - generate tokens
 	accessToken, err := s.tokenService.GenerateAccessToken(userUUID, "user")
 	if err != nil {
@@ -285,7 +315,9 @@ func (s *AuthService) ProfileSetup(ctx context.Context, userID uuid.UUID, input
 		return nil, fmt.Errorf("get user: %w", err)
 	}
 
-	if user.FirstName.Valid && user.LastName.Valid {
+	// Imported users arrive with a name, so the flag rather than the name decides;
+	// migration 000025 set it for registered users who already had a name
+	if user.IsProfileComplete.Bool {
 		return nil, ErrProfileAlreadySet
 	}
 
@@ -36,6 +36,7 @@ func setupTestAuthService(t *testing.T) (*AuthService, *redis.Client, func()) {
 		nil, // repo
 		rdb,
 		tokenService,
+		nil, // news
 		"development",
 	)
 
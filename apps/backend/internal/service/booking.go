@@ -0,0 +1,558 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/workinghours"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// bookingSlot is the granularity of player bookings
+	bookingSlot = 30 * time.Minute
+	// maxBookingDuration is the longest single booking a player can make
+	maxBookingDuration = 4 * time.Hour
+	// bookingCancelCutoff is how long before start the booker can still cancel
+	bookingCancelCutoff = 2 * time.Hour
+	// defaultBookingDuration is reserved for events without an end_time
+	defaultBookingDuration = 2 * time.Hour
+
+	// Bookable hours of the day (local time) for courts without working hours
+	bookingDayStart = 6 * time.Hour
+	bookingDayEnd   = 24 * time.Hour
+
+	// pgExclusionViolation is raised by the court_bookings_no_overlap constraint
+	pgExclusionViolation = "23P01"
+)
+
+// BookingService handles court time reservations
+type BookingService struct {
+	repo     *repository.Queries
+	location *time.Location
+}
+
+// NewBookingService creates a new BookingService
+func NewBookingService(repo *repository.Queries) *BookingService {
+	return &BookingService{
+		repo:     repo,
+		location: almatyLocation(),
+	}
+}
+
+// Availability returns busy and free intervals of every court number for one local day
+func (s *BookingService) Availability(ctx context.Context, courtID uuid.UUID, date string) (map[string]interface{}, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, s.location)
+	if err != nil {
+		return nil, ErrValidation.WithMessage("date must be in YYYY-MM-DD format")
+	}
+
+	court, err := s.getBookableCourt(ctx, s.repo, uuidToPgtype(courtID))
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := openingRanges(court, day)
+	dayStart, dayEnd := day, day.AddDate(0, 0, 1)
+	if len(ranges) > 0 {
+		dayStart, dayEnd = ranges[0].start, ranges[len(ranges)-1].end
+	}
+	bookings, err := s.repo.ListCourtBookingsForRange(ctx, repository.ListCourtBookingsForRangeParams{
+		CourtID:    court.ID,
+		RangeStart: pgtype.Timestamptz{Time: dayStart, Valid: true},
+		RangeEnd:   pgtype.Timestamptz{Time: dayEnd, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list court bookings: %w", err)
+	}
+
+	busy := busyByCourtNumber(bookings)
+	courts := make([]map[string]interface{}, 0, court.TotalCourts.Int16)
+	for n := int16(1); n <= courtCount(court); n++ {
+		busyList := make([]map[string]interface{}, 0, len(busy[n]))
+		for _, iv := range busy[n] {
+			busyList = append(busyList, map[string]interface{}{"starts_at": iv.start, "ends_at": iv.end})
+		}
+		freeList := []map[string]interface{}{}
+		for _, r := range ranges {
+			for _, iv := range freeIntervals(r.start, r.end, busy[n]) {
+				freeList = append(freeList, map[string]interface{}{"starts_at": iv.start, "ends_at": iv.end})
+			}
+		}
+		courts = append(courts, map[string]interface{}{
+			"court_number": n,
+			"busy":         busyList,
+			"free":         freeList,
+		})
+	}
+
+	hours := make([]map[string]interface{}, 0, len(ranges))
+	for _, r := range ranges {
+		hours = append(hours, map[string]interface{}{"opens_at": r.start, "closes_at": r.end})
+	}
+
+	result := map[string]interface{}{
+		"court_id":       pgtypeUUIDToStringRequired(court.ID),
+		"date":           date,
+		"is_closed":      len(ranges) == 0,
+		"opening_hours":  hours,
+		"price_per_hour": numericToFloat(court.PricePerHour),
+		"currency":       court.Currency.String,
+		"courts":         courts,
+	}
+	if len(ranges) > 0 {
+		result["opens_at"] = dayStart
+		result["closes_at"] = dayEnd
+	}
+
+	return result, nil
+}
+
+// CreateBookingInput represents input for booking a court
+type CreateBookingInput struct {
+	CourtNumber  *int16    `json:"court_number"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	SplitBetween int16     `json:"split_between"`
+	Note         string    `json:"note"`
+}
+
+// Create books a court for a time range.
+// When court_number is omitted the first free court is chosen.
+func (s *BookingService) Create(ctx context.Context, userID, courtID uuid.UUID, input CreateBookingInput) (map[string]interface{}, error) {
+	start, end := input.StartsAt, input.EndsAt
+	if !start.After(time.Now()) {
+		return nil, ErrValidation.WithMessage("starts_at must be in the future")
+	}
+	if !end.After(start) {
+		return nil, ErrValidation.WithMessage("ends_at must be after starts_at")
+	}
+	if end.Sub(start) > maxBookingDuration {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("A booking can not be longer than %d hours", int(maxBookingDuration.Hours())))
+	}
+	if start.Sub(start.Truncate(bookingSlot)) != 0 || end.Sub(end.Truncate(bookingSlot)) != 0 {
+		return nil, ErrValidation.WithMessage("Bookings must start and end on a 30-minute boundary")
+	}
+	if input.SplitBetween < 1 {
+		input.SplitBetween = 1
+	}
+
+	court, err := s.getBookableCourt(ctx, s.repo, uuidToPgtype(courtID))
+	if err != nil {
+		return nil, err
+	}
+	if !isBookable(court, start.In(s.location), end.In(s.location)) {
+		return nil, ErrValidation.WithMessage("The booking is outside of the court's opening hours")
+	}
+
+	booking, err := s.reserve(ctx, s.repo, court, input.CourtNumber, start, end, reservation{
+		bookedBy:   uuidToPgtype(userID),
+		splitCount: input.SplitBetween,
+		note:       input.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildBookingResponse(booking, court.Name), nil
+}
+
+// ReserveForEvent books the event's court for its time range. Only approved
+// directory courts take bookings; an event at a court that is not approved is
+// kept without one and the returned booking is nil.
+// It must be called with the same Queries as the event insert, so that a
+// conflicting booking rolls back the event as well.
+func (s *BookingService) ReserveForEvent(ctx context.Context, q *repository.Queries, event repository.Event) (map[string]interface{}, error) {
+	court, err := q.GetCourtByID(ctx, event.CourtID)
+	if err == pgx.ErrNoRows {
+		return nil, ErrCourtNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get court: %w", err)
+	}
+	if court.Status.CourtStatus != repository.CourtStatusApproved {
+		return nil, nil
+	}
+
+	end := eventEnd(event)
+
+	split := int16(1)
+	if event.MaxParticipants.Valid && event.MaxParticipants.Int32 > 1 && event.MaxParticipants.Int32 <= math.MaxInt16 {
+		split = int16(event.MaxParticipants.Int32)
+	}
+
+	booking, err := s.reserve(ctx, q, court, nil, event.StartTime.Time, end, reservation{
+		bookedBy:   event.CreatedBy,
+		eventID:    event.ID,
+		splitCount: split,
+		note:       event.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildBookingResponse(booking, court.Name), nil
+}
+
+// ReleaseForEvent cancels the court booking of a cancelled event.
+// It must be called with the same Queries as the status change, so that the
+// slot is freed exactly when the cancellation is committed.
+func (s *BookingService) ReleaseForEvent(ctx context.Context, q *repository.Queries, eventID pgtype.UUID) error {
+	if _, err := q.CancelEventCourtBookings(ctx, eventID); err != nil {
+		return fmt.Errorf("cancel event court bookings: %w", err)
+	}
+	return nil
+}
+
+// ListMy returns the user's current and upcoming bookings
+func (s *BookingService) ListMy(ctx context.Context, userID uuid.UUID) ([]map[string]interface{}, error) {
+	rows, err := s.repo.ListUserCourtBookings(ctx, repository.ListUserCourtBookingsParams{
+		UserID: uuidToPgtype(userID),
+		Since:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user court bookings: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, b := range rows {
+		item := map[string]interface{}{
+			"id":            pgtypeUUIDToStringRequired(b.ID),
+			"court_id":      pgtypeUUIDToStringRequired(b.CourtID),
+			"court_name":    b.CourtName,
+			"court_address": b.CourtAddress,
+			"court_number":  b.CourtNumber,
+			"starts_at":     b.StartsAt.Time,
+			"ends_at":       b.EndsAt.Time,
+			"status":        string(b.Status),
+			"note":          b.Note.String,
+		}
+		addBookingPrice(item, b.TotalPrice, b.Currency, b.SplitCount)
+		if b.EventID.Valid {
+			item["event_id"] = pgtypeUUIDToStringRequired(b.EventID)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// Cancel cancels a booking.
+// The booker can cancel up to 2 hours before start; owners/admins of the court's
+// community and superadmins can cancel until the booking ends. Bookings made
+// for an event are released by cancelling the event.
+func (s *BookingService) Cancel(ctx context.Context, userID uuid.UUID, isSuperadmin bool, bookingID uuid.UUID) (map[string]interface{}, error) {
+	booking, err := s.repo.GetCourtBookingByID(ctx, uuidToPgtype(bookingID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get court booking: %w", err)
+	}
+
+	if booking.Status != repository.BookingStatusConfirmed {
+		return nil, ErrValidation.WithMessage("Booking is already cancelled")
+	}
+	if booking.EventID.Valid {
+		return nil, ErrValidation.WithMessage("This booking belongs to an event, cancel the event instead")
+	}
+
+	now := time.Now()
+	if !booking.EndsAt.Time.After(now) {
+		return nil, ErrValidation.WithMessage("Past bookings can not be cancelled")
+	}
+
+	court, err := s.repo.GetCourtByID(ctx, booking.CourtID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get court: %w", err)
+	}
+
+	isManager := isSuperadmin || s.isCourtManager(ctx, userID, court)
+	isBooker := pgtypeUUIDToStringRequired(booking.BookedBy) == userID.String()
+
+	switch {
+	case isManager:
+	case isBooker:
+		if booking.StartsAt.Time.Sub(now) < bookingCancelCutoff {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf(
+				"Bookings can only be cancelled at least %d hours before start", int(bookingCancelCutoff.Hours()),
+			))
+		}
+	default:
+		return nil, ErrForbidden.WithMessage("Only the booker or the court's community admins can cancel this booking")
+	}
+
+	cancelled, err := s.repo.CancelCourtBooking(ctx, repository.CancelCourtBookingParams{
+		CancelledBy: uuidToPgtype(userID),
+		ID:          booking.ID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrValidation.WithMessage("Booking is already cancelled")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cancel court booking: %w", err)
+	}
+
+	return buildBookingResponse(cancelled, court.Name), nil
+}
+
+// reservation holds the booking fields that do not depend on the court
+type reservation struct {
+	bookedBy   pgtype.UUID
+	eventID    pgtype.UUID
+	splitCount int16
+	note       string
+}
+
+// reserve inserts the booking on the requested court number or the first free one.
+// The exclusion constraint is the final arbiter: a concurrent overlapping insert
+// fails with ErrBookingConflict.
+func (s *BookingService) reserve(
+	ctx context.Context,
+	q *repository.Queries,
+	court repository.Court,
+	courtNumber *int16,
+	start, end time.Time,
+	r reservation,
+) (repository.CourtBooking, error) {
+	number, err := s.pickCourtNumber(ctx, q, court, courtNumber, start, end)
+	if err != nil {
+		return repository.CourtBooking{}, err
+	}
+
+	var price pgtype.Numeric
+	if court.PricePerHour.Valid {
+		price = floatToNumeric(bookingCost(numericToFloat(court.PricePerHour), start, end))
+	}
+
+	booking, err := q.CreateCourtBooking(ctx, repository.CreateCourtBookingParams{
+		CourtID:     court.ID,
+		CourtNumber: number,
+		StartsAt:    pgtype.Timestamptz{Time: start, Valid: true},
+		EndsAt:      pgtype.Timestamptz{Time: end, Valid: true},
+		BookedBy:    r.bookedBy,
+		EventID:     r.eventID,
+		TotalPrice:  price,
+		SplitCount:  r.splitCount,
+		Note:        pgtype.Text{String: r.note, Valid: r.note != ""},
+	})
+	if isExclusionViolation(err) {
+		return repository.CourtBooking{}, ErrBookingConflict.WithMessage("The court is already booked for this time")
+	}
+	if err != nil {
+		return repository.CourtBooking{}, fmt.Errorf("create court booking: %w", err)
+	}
+
+	return booking, nil
+}
+
+func (s *BookingService) pickCourtNumber(
+	ctx context.Context,
+	q *repository.Queries,
+	court repository.Court,
+	requested *int16,
+	start, end time.Time,
+) (int16, error) {
+	total := courtCount(court)
+	if requested != nil && (*requested < 1 || *requested > total) {
+		return 0, ErrValidation.WithMessage(fmt.Sprintf("court_number must be between 1 and %d", total))
+	}
+
+	bookings, err := q.ListCourtBookingsForRange(ctx, repository.ListCourtBookingsForRangeParams{
+		CourtID:    court.ID,
+		RangeStart: pgtype.Timestamptz{Time: start, Valid: true},
+		RangeEnd:   pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list court bookings: %w", err)
+	}
+
+	taken := make(map[int16]bool, len(bookings))
+	for _, b := range bookings {
+		taken[b.CourtNumber] = true
+	}
+
+	if requested != nil {
+		if taken[*requested] {
+			return 0, ErrBookingConflict.WithMessage("The court is already booked for this time")
+		}
+		return *requested, nil
+	}
+
+	for n := int16(1); n <= total; n++ {
+		if !taken[n] {
+			return n, nil
+		}
+	}
+	return 0, ErrBookingConflict.WithMessage("All courts are booked for this time")
+}
+
+func (s *BookingService) getBookableCourt(ctx context.Context, q *repository.Queries, courtID pgtype.UUID) (repository.Court, error) {
+	court, err := q.GetCourtByID(ctx, courtID)
+	if err == pgx.ErrNoRows {
+		return repository.Court{}, ErrCourtNotFound
+	}
+	if err != nil {
+		return repository.Court{}, fmt.Errorf("get court: %w", err)
+	}
+	if court.Status.CourtStatus != repository.CourtStatusApproved {
+		return repository.Court{}, ErrCourtNotFound
+	}
+	return court, nil
+}
+
+// isCourtManager reports whether the user is an active owner/admin of the court's community
+func (s *BookingService) isCourtManager(ctx context.Context, userID uuid.UUID, court repository.Court) bool {
+	if !court.CommunityID.Valid {
+		return false
+	}
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: court.CommunityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err != nil || member.Status.MemberStatus != repository.MemberStatusActive {
+		return false
+	}
+	return member.Role.CommunityRole == repository.CommunityRoleOwner || member.Role.CommunityRole == repository.CommunityRoleAdmin
+}
+
+type timeInterval struct {
+	start time.Time
+	end   time.Time
+}
+
+// bookingWindow returns the default bookable hours of the local day containing t
+func bookingWindow(t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.Add(bookingDayStart), day.Add(bookingDayEnd)
+}
+
+// openingRanges returns the court's opening intervals on the local day of day,
+// falling back to the default window when the court has no working hours
+func openingRanges(c repository.Court, day time.Time) []timeInterval {
+	schedule, err := workinghours.Parse(c.WorkingHours)
+	if err != nil || schedule == nil {
+		opens, closes := bookingWindow(day)
+		return []timeInterval{{start: opens, end: closes}}
+	}
+
+	ranges := schedule.Ranges(day)
+	result := make([]timeInterval, 0, len(ranges))
+	for _, r := range ranges {
+		result = append(result, timeInterval{start: r.Start, end: r.End})
+	}
+	return result
+}
+
+// isBookable reports whether [start, end) lies within the court's opening hours
+func isBookable(c repository.Court, start, end time.Time) bool {
+	schedule, err := workinghours.Parse(c.WorkingHours)
+	if err != nil || schedule == nil {
+		opens, closes := bookingWindow(start)
+		return !start.Before(opens) && !end.After(closes)
+	}
+	return schedule.Covers(start, end)
+}
+
+// eventEnd is the event's end_time, or start plus the default booking length when unset
+func eventEnd(e repository.Event) time.Time {
+	if e.EndTime.Valid && e.EndTime.Time.After(e.StartTime.Time) {
+		return e.EndTime.Time
+	}
+	return e.StartTime.Time.Add(defaultBookingDuration)
+}
+
+func courtCount(c repository.Court) int16 {
+	if !c.TotalCourts.Valid || c.TotalCourts.Int16 < 1 {
+		return 1
+	}
+	return c.TotalCourts.Int16
+}
+
+// busyByCourtNumber groups bookings (ordered by court number and start) into intervals
+func busyByCourtNumber(bookings []repository.CourtBooking) map[int16][]timeInterval {
+	busy := make(map[int16][]timeInterval)
+	for _, b := range bookings {
+		busy[b.CourtNumber] = append(busy[b.CourtNumber], timeInterval{start: b.StartsAt.Time, end: b.EndsAt.Time})
+	}
+	return busy
+}
+
+// freeIntervals returns the gaps between sorted busy intervals inside [from, to)
+func freeIntervals(from, to time.Time, busy []timeInterval) []timeInterval {
+	var free []timeInterval
+	cursor := from
+	for _, b := range busy {
+		if b.start.After(cursor) {
+			end := b.start
+			if end.After(to) {
+				end = to
+			}
+			free = append(free, timeInterval{start: cursor, end: end})
+		}
+		if b.end.After(cursor) {
+			cursor = b.end
+		}
+		if !cursor.Before(to) {
+			return free
+		}
+	}
+	if cursor.Before(to) {
+		free = append(free, timeInterval{start: cursor, end: to})
+	}
+	return free
+}
+
+// bookingCost is the hourly price pro-rated to the booking length, rounded to 2 decimals
+func bookingCost(pricePerHour float64, start, end time.Time) float64 {
+	return math.Round(pricePerHour*end.Sub(start).Hours()*100) / 100
+}
+
+func isExclusionViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgExclusionViolation
+}
+
+func addBookingPrice(result map[string]interface{}, total pgtype.Numeric, currency pgtype.Text, splitCount int16) {
+	if !total.Valid {
+		return
+	}
+	amount := numericToFloat(total)
+	if splitCount < 1 {
+		splitCount = 1
+	}
+	result["total_price"] = amount
+	result["currency"] = currency.String
+	result["split_count"] = splitCount
+	result["price_per_person"] = math.Ceil(amount/float64(splitCount)*100) / 100
+}
+
+func buildBookingResponse(b repository.CourtBooking, courtName string) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(b.ID),
+		"court_id":     pgtypeUUIDToStringRequired(b.CourtID),
+		"court_name":   courtName,
+		"court_number": b.CourtNumber,
+		"starts_at":    b.StartsAt.Time,
+		"ends_at":      b.EndsAt.Time,
+		"status":       string(b.Status),
+		"booked_by":    pgtypeUUIDToStringRequired(b.BookedBy),
+		"note":         b.Note.String,
+		"created_at":   b.CreatedAt.Time,
+	}
+	addBookingPrice(result, b.TotalPrice, b.Currency, b.SplitCount)
+	if b.EventID.Valid {
+		result["event_id"] = pgtypeUUIDToStringRequired(b.EventID)
+	}
+	if b.CancelledAt.Valid {
+		result["cancelled_at"] = b.CancelledAt.Time
+	}
+	return result
+}
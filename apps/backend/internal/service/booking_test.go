@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestFreeIntervals(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	tests := []struct {
+		name     string
+		busy     []timeInterval
+		expected []timeInterval
+	}{
+		{"no bookings", nil, []timeInterval{{at(6, 0), at(24, 0)}}},
+		{
+			"gaps between bookings",
+			[]timeInterval{{at(8, 0), at(10, 0)}, {at(12, 30), at(14, 0)}},
+			[]timeInterval{{at(6, 0), at(8, 0)}, {at(10, 0), at(12, 30)}, {at(14, 0), at(24, 0)}},
+		},
+		{
+			"adjacent and overlapping window edges",
+			[]timeInterval{{at(5, 0), at(7, 0)}, {at(7, 0), at(9, 0)}, {at(23, 0), at(25, 0)}},
+			[]timeInterval{{at(9, 0), at(23, 0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := freeIntervals(at(6, 0), at(24, 0), tt.busy)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Expected %d free intervals, got %d: %v", len(tt.expected), len(got), got)
+			}
+			for i := range got {
+				if !got[i].start.Equal(tt.expected[i].start) || !got[i].end.Equal(tt.expected[i].end) {
+					t.Errorf("Interval %d: expected %v-%v, got %v-%v",
+						i, tt.expected[i].start, tt.expected[i].end, got[i].start, got[i].end)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingCost(t *testing.T) {
+	start := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	if got := bookingCost(3000, start, start.Add(90*time.Minute)); got != 4500 {
+		t.Errorf("Expected 4500, got %v", got)
+	}
+	if got := bookingCost(3500, start, start.Add(30*time.Minute)); got != 1750 {
+		t.Errorf("Expected 1750, got %v", got)
+	}
+}
+
+func TestCreateEventAtUnapprovedCourt(t *testing.T) {
+	db := newFakeDB()
+	court := repository.Court{
+		ID:     uuidToPgtype(uuid.New()),
+		Name:   "Корт у дома",
+		Status: repository.NullCourtStatus{CourtStatus: repository.CourtStatusPending, Valid: true},
+	}
+	start := time.Now().Add(48 * time.Hour)
+	db.rows("GetCourtByID", []any{court})
+	db.rows("CreateEvent", []any{repository.Event{
+		ID:        uuidToPgtype(uuid.New()),
+		Title:     "Вечерняя игра",
+		CourtID:   court.ID,
+		StartTime: pgtype.Timestamptz{Time: start, Valid: true},
+	}})
+	db.rows("AddEventParticipant", []any{repository.EventParticipant{}})
+
+	events := &EventService{repo: db.queries(), pool: db, bookings: NewBookingService(db.queries())}
+	courtID := pgtypeUUIDToStringRequired(court.ID)
+	result, err := events.Create(context.Background(), uuid.New(), CreateEventInput{
+		Title:           "Вечерняя игра",
+		EventType:       "casual",
+		CourtID:         &courtID,
+		StartTime:       start,
+		MaxParticipants: 4,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, ok := result["court_booking"]; ok {
+		t.Errorf("court_booking = %v, want none at a court that is not approved", result["court_booking"])
+	}
+	if !db.committed() {
+		t.Error("event was not committed")
+	}
+}
+
+// fakeCancellableEvent serves a published event at a court created by userID
+func fakeCancellableEvent(db *fakeDB, userID uuid.UUID) uuid.UUID {
+	eventID := uuid.New()
+	db.rows("GetEventByID", []any{repository.Event{
+		ID:        uuidToPgtype(eventID),
+		Status:    repository.NullEventStatus{EventStatus: repository.EventStatusPublished, Valid: true},
+		CourtID:   uuidToPgtype(uuid.New()),
+		CreatedBy: uuidToPgtype(userID),
+	}})
+	db.on("UpdateEventStatus", func(args []any) ([][]any, error) {
+		return [][]any{{repository.UpdateEventStatusRow{ID: args[0].(pgtype.UUID), Status: args[1].(repository.NullEventStatus)}}}, nil
+	})
+	return eventID
+}
+
+func TestCancelEventReleasesBookingInTransaction(t *testing.T) {
+	userID := uuid.New()
+	db := newFakeDB()
+	eventID := fakeCancellableEvent(db, userID)
+	db.rows("CancelEventCourtBookings", []any{})
+
+	events := &EventService{repo: db.queries(), pool: db, bookings: NewBookingService(db.queries())}
+	if _, err := events.UpdateStatus(context.Background(), userID, eventID, "cancelled"); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	want := []string{"GetEventByID", "UpdateEventStatus", "CancelEventCourtBookings", "COMMIT"}
+	if got := db.names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("queries = %v, want %v", got, want)
+	}
+}
+
+func TestCancelEventRollsBackWhenReleaseFails(t *testing.T) {
+	userID := uuid.New()
+	db := newFakeDB()
+	eventID := fakeCancellableEvent(db, userID)
+	db.on("CancelEventCourtBookings", func([]any) ([][]any, error) {
+		return nil, errors.New("connection reset")
+	})
+
+	events := &EventService{repo: db.queries(), pool: db, bookings: NewBookingService(db.queries())}
+	if _, err := events.UpdateStatus(context.Background(), userID, eventID, "cancelled"); err == nil {
+		t.Fatal("cancel succeeded, want error")
+	}
+	if db.committed() {
+		t.Error("cancellation was committed without releasing the booking")
+	}
+}
+
+func TestSchedulerCancelReleasesBookingInTransaction(t *testing.T) {
+	for _, affected := range []int{0, 1} {
+		db := newFakeDB()
+		db.rows("TransitionEventStatus", make([][]any, affected)...)
+		db.rows("CancelEventCourtBookings", []any{})
+
+		scheduler := &EventScheduler{repo: db.queries(), pool: db}
+		got, err := scheduler.transition(context.Background(), uuidToPgtype(uuid.New()),
+			repository.EventStatusRegistrationOpen, repository.EventStatusCancelled)
+		if err != nil || got != int64(affected) {
+			t.Fatalf("transition = %d, %v, want %d", got, err, affected)
+		}
+
+		want := []string{"TransitionEventStatus", "ROLLBACK"}
+		if affected > 0 {
+			want = []string{"TransitionEventStatus", "CancelEventCourtBookings", "COMMIT"}
+		}
+		if names := db.names(); !reflect.DeepEqual(names, want) {
+			t.Errorf("affected %d: queries = %v, want %v", affected, names, want)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/ical"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// calendarHistory is how far back feeds include past events and matches.
+const calendarHistory = 30 * 24 * time.Hour
+
+// sequenceEpoch is the reference point for ICS SEQUENCE values.
+// SEQUENCE is derived from updated_at so any change to an event or match
+// produces a higher value, which calendar clients treat as a revision.
+var sequenceEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// almatyLocation returns the Asia/Almaty zone, falling back to a fixed
+// UTC+5 offset when tzdata is not available.
+func almatyLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Almaty")
+	if err != nil {
+		return time.FixedZone("Asia/Almaty", 5*60*60)
+	}
+	return loc
+}
+
+// CalendarService builds ICS feeds for calendar subscriptions
+type CalendarService struct {
+	repo     *repository.Queries
+	baseURL  string
+	location *time.Location
+}
+
+// NewCalendarService creates a new CalendarService
+func NewCalendarService(repo *repository.Queries, baseURL string) *CalendarService {
+	return &CalendarService{
+		repo:     repo,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		location: almatyLocation(),
+	}
+}
+
+// GetFeeds returns the user's subscription URLs, creating a token on first use
+func (s *CalendarService) GetFeeds(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
+	token, err := s.repo.GetCalendarToken(ctx, uuidToPgtype(userID))
+	if err == pgx.ErrNoRows {
+		return s.RotateToken(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get calendar token: %w", err)
+	}
+
+	return s.buildFeedsResponse(token.Token), nil
+}
+
+// RotateToken issues a new secret token, invalidating previously shared feed URLs
+func (s *CalendarService) RotateToken(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
+	secret, err := generateCalendarToken()
+	if err != nil {
+		return nil, fmt.Errorf("generate calendar token: %w", err)
+	}
+
+	token, err := s.repo.UpsertCalendarToken(ctx, repository.UpsertCalendarTokenParams{
+		UserID: uuidToPgtype(userID),
+		Token:  secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert calendar token: %w", err)
+	}
+
+	return s.buildFeedsResponse(token.Token), nil
+}
+
+// PersonalFeed returns an ICS feed with the user's joined/created events and scheduled matches
+func (s *CalendarService) PersonalFeed(ctx context.Context, token string) ([]byte, error) {
+	userID, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	since := pgtype.Timestamptz{Time: now.Add(-calendarHistory), Valid: true}
+
+	events, err := s.repo.ListUserCalendarEvents(ctx, repository.ListUserCalendarEventsParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list calendar events: %w", err)
+	}
+
+	matches, err := s.repo.ListUserCalendarMatches(ctx, repository.ListUserCalendarMatchesParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list calendar matches: %w", err)
+	}
+
+	cal := ical.Calendar{Name: "TennisApp — мои игры", Location: s.location}
+	for _, e := range events {
+		cal.Events = append(cal.Events, buildCalendarEvent(
+			e.ID, e.Title, e.Description, e.Status, e.LocationName, e.LocationAddress,
+			e.StartTime, e.EndTime, e.UpdatedAt,
+		))
+	}
+	for _, m := range matches {
+		cal.Events = append(cal.Events, buildCalendarMatch(m))
+	}
+
+	return cal.Build(now), nil
+}
+
+// CommunityFeed returns an ICS feed with a community's events.
+// Closed communities are only available to their active members.
+func (s *CalendarService) CommunityFeed(ctx context.Context, token string, communityID uuid.UUID) ([]byte, error) {
+	userID, err := s.resolveToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	if community.AccessLevel.CommunityAccess != repository.CommunityAccessOpen {
+		member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+			CommunityID: community.ID,
+			UserID:      userID,
+		})
+		if err != nil || member.Status.MemberStatus != repository.MemberStatusActive {
+			return nil, ErrNotCommunityMember
+		}
+	}
+
+	now := time.Now()
+	events, err := s.repo.ListCommunityCalendarEvents(ctx, repository.ListCommunityCalendarEventsParams{
+		CommunityID: community.ID,
+		Since:       pgtype.Timestamptz{Time: now.Add(-calendarHistory), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list community calendar events: %w", err)
+	}
+
+	cal := ical.Calendar{Name: community.Name, Location: s.location}
+	for _, e := range events {
+		cal.Events = append(cal.Events, buildCalendarEvent(
+			e.ID, e.Title, e.Description, e.Status, e.LocationName, e.LocationAddress,
+			e.StartTime, e.EndTime, e.UpdatedAt,
+		))
+	}
+
+	return cal.Build(now), nil
+}
+
+func (s *CalendarService) resolveToken(ctx context.Context, token string) (pgtype.UUID, error) {
+	if token == "" {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	t, err := s.repo.GetCalendarTokenByToken(ctx, token)
+	if err == pgx.ErrNoRows {
+		return pgtype.UUID{}, ErrNotFound
+	}
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("get calendar token: %w", err)
+	}
+	return t.UserID, nil
+}
+
+func (s *CalendarService) buildFeedsResponse(token string) map[string]interface{} {
+	base := s.baseURL + "/v1/calendar/" + token
+	return map[string]interface{}{
+		"personal_url":           base + "/personal.ics",
+		"community_url_template": base + "/communities/{community_id}.ics",
+	}
+}
+
+func generateCalendarToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func buildCalendarEvent(
+	id pgtype.UUID,
+	title string,
+	description pgtype.Text,
+	status repository.NullEventStatus,
+	locationName, locationAddress pgtype.Text,
+	start, end, updatedAt pgtype.Timestamptz,
+) ical.Event {
+	e := ical.Event{
+		UID:          "event-" + pgtypeUUIDToStringRequired(id) + "@tennisapp",
+		Summary:      title,
+		Description:  description.String,
+		Location:     joinLocation(locationName.String, locationAddress.String),
+		Start:        start.Time,
+		Status:       ical.StatusConfirmed,
+		Sequence:     calendarSequence(updatedAt),
+		LastModified: updatedAt.Time,
+	}
+	if end.Valid {
+		e.End = end.Time
+	}
+	if status.EventStatus == repository.EventStatusCancelled {
+		e.Status = ical.StatusCancelled
+	}
+	return e
+}
+
+func buildCalendarMatch(m repository.ListUserCalendarMatchesRow) ical.Event {
+	summary := fmt.Sprintf("Матч: %s %s — %s %s",
+		m.Player1FirstName.String, m.Player1LastName.String,
+		m.Player2FirstName.String, m.Player2LastName.String,
+	)
+
+	var location string
+	if m.LocationName.Valid {
+		location = m.LocationName.String
+	}
+	if m.CourtNumber.Valid {
+		location = joinLocation(location, fmt.Sprintf("корт №%d", m.CourtNumber.Int32))
+	}
+
+	return ical.Event{
+		UID:          "match-" + pgtypeUUIDToStringRequired(m.ID) + "@tennisapp",
+		Summary:      summary,
+		Description:  m.EventTitle.String,
+		Location:     location,
+		Start:        m.ScheduledTime.Time,
+		Status:       ical.StatusConfirmed,
+		Sequence:     calendarSequence(m.UpdatedAt),
+		LastModified: m.UpdatedAt.Time,
+	}
+}
+
+func calendarSequence(updatedAt pgtype.Timestamptz) int {
+	if !updatedAt.Valid || updatedAt.Time.Before(sequenceEpoch) {
+		return 0
+	}
+	return int(updatedAt.Time.Sub(sequenceEpoch) / time.Second)
+}
+
+func joinLocation(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
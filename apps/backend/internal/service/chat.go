@@ -333,6 +333,14 @@ func (s *ChatService) SendMessage(ctx context.Context, userID, chatID uuid.UUID,
 		return nil, ErrForbidden.WithMessage("You are not a member of this chat")
 	}
 
+	chat, err := s.repo.GetChatByID(ctx, uuidToPgtype(chatID))
+	if err != nil {
+		return nil, fmt.Errorf("get chat: %w", err)
+	}
+	if chat.IsArchived.Bool {
+		return nil, ErrForbidden.WithMessage("This chat is archived")
+	}
+
 	if content == "" {
 		return nil, ErrValidation.WithMessage("Message content cannot be empty")
 	}
@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	commentMaxContent  = 2000
+	commentMaxMentions = 20
+	// commentReplyPreview is how many replies each top-level comment carries in the list
+	commentReplyPreview = 3
+	// notificationExcerptLength bounds the comment or post text quoted in notifications
+	notificationExcerptLength = 100
+
+	// pgForeignKeyViolation is raised when the post or parent comment was deleted concurrently
+	pgForeignKeyViolation = "23503"
+)
+
+// CommentService manages comments on posts. Replies are one level deep: a
+// reply to a reply is attached to the top-level comment of its thread.
+// Anyone who can see a post can comment on it; posts.comment_count is kept
+// by a database trigger.
+type CommentService struct {
+	repo          *repository.Queries
+	notifications *NotificationService
+}
+
+// NewCommentService creates a new CommentService
+func NewCommentService(repo *repository.Queries, notifications *NotificationService) *CommentService {
+	return &CommentService{
+		repo:          repo,
+		notifications: notifications,
+	}
+}
+
+// CommentInput is a new or edited comment. Mentions are the IDs of the users
+// tagged in the text; ParentID is ignored on edit.
+type CommentInput struct {
+	Content  string      `json:"content"`
+	ParentID *uuid.UUID  `json:"parent_id"`
+	Mentions []uuid.UUID `json:"mentions"`
+}
+
+func (in *CommentInput) validate() error {
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" {
+		return ErrValidation.WithMessage("content is required")
+	}
+	if utf8.RuneCountInString(in.Content) > commentMaxContent {
+		return ErrValidation.WithMessage(fmt.Sprintf("content must be at most %d characters", commentMaxContent))
+	}
+	if len(in.Mentions) > commentMaxMentions {
+		return ErrValidation.WithMessage(fmt.Sprintf("at most %d users can be mentioned", commentMaxMentions))
+	}
+	return nil
+}
+
+// Create adds a comment or a reply and notifies the mentioned users
+func (s *CommentService) Create(ctx context.Context, userID, postID uuid.UUID, input CommentInput) (map[string]interface{}, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.requireVisiblePost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	parentID := pgtype.UUID{}
+	if input.ParentID != nil {
+		parent, err := s.getPostComment(ctx, postID, *input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = parent.ID
+		if parent.ParentID.Valid {
+			parentID = parent.ParentID
+		}
+	}
+
+	mentions, err := s.mentionable(ctx, userID, postID, input.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.repo.CreateComment(ctx, repository.CreateCommentParams{
+		PostID:   uuidToPgtype(postID),
+		ParentID: parentID,
+		AuthorID: uuidToPgtype(userID),
+		Content:  input.Content,
+		Mentions: mentions,
+	})
+	if isForeignKeyViolation(err) {
+		if parentID.Valid {
+			return nil, ErrCommentNotFound.WithMessage("Parent comment was deleted")
+		}
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create comment: %w", err)
+	}
+
+	view, err := s.view(ctx, comment.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyMentions(ctx, view, mentions)
+	return commentResponse(view), nil
+}
+
+// Update edits the author's own comment. Only users newly mentioned by the
+// edit are notified.
+func (s *CommentService) Update(ctx context.Context, userID, postID, commentID uuid.UUID, input CommentInput) (map[string]interface{}, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.requireVisiblePost(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID.Bytes != userID {
+		return nil, ErrForbidden.WithMessage("You can only edit your own comments")
+	}
+
+	mentions, err := s.mentionable(ctx, userID, postID, input.Mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateComment(ctx, repository.UpdateCommentParams{
+		Content:  input.Content,
+		Mentions: mentions,
+		ID:       comment.ID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update comment: %w", err)
+	}
+
+	view, err := s.view(ctx, updated.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyMentions(ctx, view, newMentions(comment.Mentions, mentions))
+	return commentResponse(view), nil
+}
+
+// Delete removes a comment together with its replies. Authors delete their
+// own comments; on community posts moderators and above delete any comment.
+func (s *CommentService) Delete(ctx context.Context, userID, postID, commentID uuid.UUID) error {
+	comment, err := s.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID.Bytes != userID {
+		canModerate, err := s.canModerate(ctx, userID, postID)
+		if err != nil {
+			return err
+		}
+		if !canModerate {
+			return ErrForbidden.WithMessage("You can only delete your own comments")
+		}
+	}
+
+	if err := s.repo.DeleteComment(ctx, comment.ID); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
+
+// List returns the post's top-level comments, oldest first, each with its
+// reply count and first replies
+func (s *CommentService) List(ctx context.Context, userID, postID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if err := s.requireVisiblePost(ctx, userID, postID); err != nil {
+		return nil, nil, err
+	}
+
+	page, perPage = commentPage(page, perPage)
+	rows, err := s.repo.ListPostComments(ctx, repository.ListPostCommentsParams{
+		PostID:       uuidToPgtype(postID),
+		ResultOffset: int32((page - 1) * perPage),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list comments: %w", err)
+	}
+
+	total, err := s.repo.CountPostComments(ctx, uuidToPgtype(postID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count comments: %w", err)
+	}
+
+	var threads []pgtype.UUID
+	for _, r := range rows {
+		if r.ReplyCount > 0 {
+			threads = append(threads, r.ID)
+		}
+	}
+	replies := map[[16]byte][]map[string]interface{}{}
+	if len(threads) > 0 {
+		previews, err := s.repo.ListReplyPreviews(ctx, repository.ListReplyPreviewsParams{
+			ParentIds: threads,
+			PerParent: commentReplyPreview,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("list reply previews: %w", err)
+		}
+		for _, p := range previews {
+			replies[p.ParentID.Bytes] = append(replies[p.ParentID.Bytes], commentResponse(repository.GetCommentViewRow(p)))
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		c := commentResponse(repository.GetCommentViewRow{
+			ID:              r.ID,
+			PostID:          r.PostID,
+			ParentID:        r.ParentID,
+			AuthorID:        r.AuthorID,
+			Content:         r.Content,
+			Mentions:        r.Mentions,
+			EditedAt:        r.EditedAt,
+			CreatedAt:       r.CreatedAt,
+			AuthorFirstName: r.AuthorFirstName,
+			AuthorLastName:  r.AuthorLastName,
+			AuthorAvatarUrl: r.AuthorAvatarUrl,
+		})
+		preview := replies[r.ID.Bytes]
+		if preview == nil {
+			preview = []map[string]interface{}{}
+		}
+		c["reply_count"] = r.ReplyCount
+		c["replies"] = preview
+		result = append(result, c)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Replies returns the replies to a top-level comment, oldest first
+func (s *CommentService) Replies(ctx context.Context, userID, postID, commentID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if err := s.requireVisiblePost(ctx, userID, postID); err != nil {
+		return nil, nil, err
+	}
+
+	parent, err := s.getPostComment(ctx, postID, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent.ParentID.Valid {
+		return nil, nil, ErrValidation.WithMessage("Replies are listed on top-level comments")
+	}
+
+	page, perPage = commentPage(page, perPage)
+	rows, err := s.repo.ListCommentReplies(ctx, repository.ListCommentRepliesParams{
+		ParentID:     parent.ID,
+		ResultOffset: int32((page - 1) * perPage),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list replies: %w", err)
+	}
+
+	total, err := s.repo.CountCommentReplies(ctx, parent.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count replies: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, commentResponse(repository.GetCommentViewRow(r)))
+	}
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+func (s *CommentService) requireVisiblePost(ctx context.Context, userID, postID uuid.UUID) error {
+	_, err := s.repo.GetVisiblePost(ctx, repository.GetVisiblePostParams{
+		UserID: uuidToPgtype(userID),
+		ID:     uuidToPgtype(postID),
+	})
+	if err == pgx.ErrNoRows {
+		return ErrPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get post: %w", err)
+	}
+	return nil
+}
+
+// getPostComment loads a comment and checks it belongs to the post
+func (s *CommentService) getPostComment(ctx context.Context, postID, commentID uuid.UUID) (repository.PostComment, error) {
+	comment, err := s.repo.GetComment(ctx, uuidToPgtype(commentID))
+	if err == pgx.ErrNoRows || (err == nil && comment.PostID.Bytes != postID) {
+		return repository.PostComment{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return repository.PostComment{}, fmt.Errorf("get comment: %w", err)
+	}
+	return comment, nil
+}
+
+// canModerate reports whether the user moderates the community that published the post
+func (s *CommentService) canModerate(ctx context.Context, userID, postID uuid.UUID) (bool, error) {
+	post, err := s.repo.GetPostByID(ctx, uuidToPgtype(postID))
+	if err == pgx.ErrNoRows {
+		return false, ErrPostNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("get post: %w", err)
+	}
+	if post.AuthorType != repository.PostAuthorTypeCommunity {
+		return false, nil
+	}
+
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: post.AuthorCommunityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get community member: %w", err)
+	}
+	return member.Status.MemberStatus == repository.MemberStatusActive &&
+		communityRoleRank(member.Role.CommunityRole) >= communityRoleRank(repository.CommunityRoleModerator), nil
+}
+
+// mentionable keeps the mentioned users who can see the post, dropping the
+// author and duplicates, so a mention never reveals a post to an outsider
+func (s *CommentService) mentionable(ctx context.Context, authorID, postID uuid.UUID, mentions []uuid.UUID) ([]pgtype.UUID, error) {
+	candidates := uniqueMentions(authorID, mentions)
+	if len(candidates) == 0 {
+		return []pgtype.UUID{}, nil
+	}
+
+	visible, err := s.repo.ListPostAudience(ctx, repository.ListPostAudienceParams{
+		PostID:  uuidToPgtype(postID),
+		UserIds: candidates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list post audience: %w", err)
+	}
+	return visible, nil
+}
+
+func (s *CommentService) view(ctx context.Context, commentID pgtype.UUID) (repository.GetCommentViewRow, error) {
+	view, err := s.repo.GetCommentView(ctx, commentID)
+	if err == pgx.ErrNoRows {
+		return repository.GetCommentViewRow{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return repository.GetCommentViewRow{}, fmt.Errorf("get comment: %w", err)
+	}
+	return view, nil
+}
+
+// notifyMentions sends a comment_mention notification to each user, best effort
+func (s *CommentService) notifyMentions(ctx context.Context, c repository.GetCommentViewRow, userIDs []pgtype.UUID) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	body := fmt.Sprintf("%s: %s", fullName(c.AuthorFirstName, c.AuthorLastName), notificationExcerpt(c.Content))
+	data := map[string]any{
+		"post_id":    pgtypeUUIDToStringRequired(c.PostID),
+		"comment_id": pgtypeUUIDToStringRequired(c.ID),
+	}
+	for _, id := range userIDs {
+		if _, err := s.notifications.Create(ctx, uuid.UUID(id.Bytes), string(repository.NotificationTypeCommentMention), "Вас упомянули в комментарии", body, data); err != nil {
+			slog.Warn("failed to create mention notification", "user_id", uuid.UUID(id.Bytes), "error", err)
+		}
+	}
+}
+
+// uniqueMentions drops duplicates and the author from the mentioned users
+func uniqueMentions(authorID uuid.UUID, mentions []uuid.UUID) []pgtype.UUID {
+	seen := map[uuid.UUID]bool{authorID: true}
+	result := make([]pgtype.UUID, 0, len(mentions))
+	for _, id := range mentions {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, uuidToPgtype(id))
+	}
+	return result
+}
+
+// newMentions returns the users in current who were not in previous
+func newMentions(previous, current []pgtype.UUID) []pgtype.UUID {
+	old := make(map[[16]byte]bool, len(previous))
+	for _, id := range previous {
+		old[id.Bytes] = true
+	}
+	var added []pgtype.UUID
+	for _, id := range current {
+		if !old[id.Bytes] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// notificationExcerpt shortens a comment or post for a notification body
+func notificationExcerpt(content string) string {
+	if utf8.RuneCountInString(content) <= notificationExcerptLength {
+		return content
+	}
+	return string([]rune(content)[:notificationExcerptLength-1]) + "…"
+}
+
+func commentPage(page, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	return page, perPage
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation
+}
+
+func commentResponse(c repository.GetCommentViewRow) map[string]interface{} {
+	mentions := make([]string, 0, len(c.Mentions))
+	for _, id := range c.Mentions {
+		mentions = append(mentions, pgtypeUUIDToStringRequired(id))
+	}
+
+	return map[string]interface{}{
+		"id":        pgtypeUUIDToStringRequired(c.ID),
+		"post_id":   pgtypeUUIDToStringRequired(c.PostID),
+		"parent_id": pgtypeUUIDToString(c.ParentID),
+		"author": map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(c.AuthorID),
+			"first_name": c.AuthorFirstName.String,
+			"last_name":  c.AuthorLastName.String,
+			"avatar_url": c.AuthorAvatarUrl.String,
+		},
+		"content":    c.Content,
+		"mentions":   mentions,
+		"is_edited":  c.EditedAt.Valid,
+		"edited_at":  timestamptzToString(c.EditedAt),
+		"created_at": c.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestUniqueMentions(t *testing.T) {
+	author, a, b := uuid.New(), uuid.New(), uuid.New()
+
+	got := uniqueMentions(author, []uuid.UUID{a, author, b, a})
+	want := []pgtype.UUID{uuidToPgtype(a), uuidToPgtype(b)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueMentions = %v, want %v", got, want)
+	}
+	if got := uniqueMentions(author, nil); len(got) != 0 {
+		t.Errorf("uniqueMentions(nil) = %v, want empty", got)
+	}
+}
+
+func TestNewMentions(t *testing.T) {
+	a, b, c := uuidToPgtype(uuid.New()), uuidToPgtype(uuid.New()), uuidToPgtype(uuid.New())
+
+	if got := newMentions([]pgtype.UUID{a, b}, []pgtype.UUID{b, c}); !reflect.DeepEqual(got, []pgtype.UUID{c}) {
+		t.Errorf("newMentions = %v, want only the added user", got)
+	}
+	if got := newMentions([]pgtype.UUID{a, b}, []pgtype.UUID{a}); len(got) != 0 {
+		t.Errorf("newMentions = %v, want none after removing a mention", got)
+	}
+}
+
+func TestNotificationExcerpt(t *testing.T) {
+	if got := notificationExcerpt("Хорошая игра"); got != "Хорошая игра" {
+		t.Errorf("short comment changed: %q", got)
+	}
+
+	got := notificationExcerpt(strings.Repeat("я", notificationExcerptLength+10))
+	if n := utf8.RuneCountInString(got); n != notificationExcerptLength {
+		t.Errorf("excerpt length = %d runes, want %d", n, notificationExcerptLength)
+	}
+	if !strings.HasSuffix(got, "…") {
+		t.Errorf("excerpt %q should end with an ellipsis", got)
+	}
+}
+
+func TestCommentInputValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   CommentInput
+		wantErr bool
+	}{
+		{"ok", CommentInput{Content: " Отлично! "}, false},
+		{"blank", CommentInput{Content: "  "}, true},
+		{"too long", CommentInput{Content: strings.Repeat("a", commentMaxContent+1)}, true},
+		{"too many mentions", CommentInput{Content: "hi", Mentions: make([]uuid.UUID, commentMaxMentions+1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
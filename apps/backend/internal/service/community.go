@@ -1,24 +1,48 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const (
+	// maxCommunityNameLength matches communities.name VARCHAR(255)
+	maxCommunityNameLength = 255
+	// maxSlugAttempts is how many numbered suffixes are tried before a random one
+	maxSlugAttempts = 20
+
+	// pgUniqueViolation is raised when a concurrent write took the same slug
+	pgUniqueViolation = "23505"
+)
+
 // CommunityService handles community business logic
 type CommunityService struct {
-	repo *repository.Queries
+	repo    *repository.Queries
+	storage *StorageService
+	news    *CommunityNews
 }
 
 // NewCommunityService creates a new CommunityService
-func NewCommunityService(repo *repository.Queries) *CommunityService {
-	return &CommunityService{repo: repo}
+func NewCommunityService(repo *repository.Queries, storage *StorageService, news *CommunityNews) *CommunityService {
+	return &CommunityService{
+		repo:    repo,
+		storage: storage,
+		news:    news,
+	}
 }
 
 // CreateCommunityInput represents input for creating a community
@@ -33,7 +57,10 @@ type CreateCommunityInput struct {
 // Create creates a new community
 func (s *CommunityService) Create(ctx context.Context, userID uuid.UUID, input CreateCommunityInput) (map[string]interface{}, error) {
 	// Generate slug from name
-	slug := generateSlug(input.Name)
+	slug, err := s.uniqueSlug(ctx, generateSlug(input.Name), pgtype.UUID{})
+	if err != nil {
+		return nil, err
+	}
 
 	// Determine verification status
 	verificationStatus := repository.NullVerificationStatus{
@@ -48,10 +75,9 @@ func (s *CommunityService) Create(ctx context.Context, userID uuid.UUID, input C
 	accessLevel := repository.NullCommunityAccess{
 		CommunityAccess: repository.CommunityAccessOpen, Valid: true,
 	}
-	if input.AccessLevel == "closed" {
-		accessLevel = repository.NullCommunityAccess{
-			CommunityAccess: repository.CommunityAccessClosed, Valid: true,
-		}
+	switch access := repository.CommunityAccess(input.AccessLevel); access {
+	case repository.CommunityAccessClosed, repository.CommunityAccessPaid:
+		accessLevel = repository.NullCommunityAccess{CommunityAccess: access, Valid: true}
 	}
 
 	community, err := s.repo.CreateCommunity(ctx, repository.CreateCommunityParams{
@@ -79,6 +105,8 @@ func (s *CommunityService) Create(ctx context.Context, userID uuid.UUID, input C
 		return nil, fmt.Errorf("add owner: %w", err)
 	}
 
+	s.news.Subscribe(ctx, userID, uuid.UUID(community.ID.Bytes))
+
 	return buildCommunityResponse(community), nil
 }
 
@@ -163,6 +191,7 @@ func (s *CommunityService) List(ctx context.Context, input ListCommunitiesInput)
 			"community_type":      string(c.CommunityType),
 			"access_level":        string(c.AccessLevel.CommunityAccess),
 			"verification_status": string(c.VerificationStatus.VerificationStatus),
+			"is_verified":         c.VerificationStatus.VerificationStatus == repository.VerificationStatusVerified,
 			"logo_url":            c.LogoUrl.String,
 			"district":            c.District.String,
 			"member_count":        c.MemberCount.Int32,
@@ -208,6 +237,109 @@ func (s *CommunityService) GetByID(ctx context.Context, userID, communityID uuid
 	return result, nil
 }
 
+// UpdateCommunityInput represents a partial community update; nil fields are left unchanged
+type UpdateCommunityInput struct {
+	Name         *string         `json:"name"`
+	Description  *string         `json:"description"`
+	Rules        *string         `json:"rules"`
+	AccessLevel  *string         `json:"access_level"`
+	ContactPhone *string         `json:"contact_phone"`
+	ContactEmail *string         `json:"contact_email"`
+	SocialLinks  json.RawMessage `json:"social_links"`
+	Address      *string         `json:"address"`
+	District     *string         `json:"district"`
+}
+
+// Update changes community settings (owner/admin only, enforced by the router).
+// Renaming regenerates the slug, keeping it unique across communities.
+func (s *CommunityService) Update(ctx context.Context, communityID uuid.UUID, input UpdateCommunityInput) (map[string]interface{}, error) {
+	community, err := s.repo.GetCommunityByID(ctx, pgtype.UUID{Bytes: communityID, Valid: true})
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	params := repository.UpdateCommunityParams{
+		ID:           community.ID,
+		Description:  optionalText(input.Description),
+		Rules:        optionalText(input.Rules),
+		ContactPhone: optionalText(input.ContactPhone),
+		Address:      optionalText(input.Address),
+		District:     optionalText(input.District),
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, ErrValidation.WithMessage("Name is required")
+		}
+		if utf8.RuneCountInString(name) > maxCommunityNameLength {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("Name must be at most %d characters", maxCommunityNameLength))
+		}
+		params.Name = pgtype.Text{String: name, Valid: true}
+
+		if base := generateSlug(name); name != community.Name && base != community.Slug.String {
+			slug, err := s.uniqueSlug(ctx, base, community.ID)
+			if err != nil {
+				return nil, err
+			}
+			params.Slug = pgtype.Text{String: slug, Valid: true}
+		}
+	}
+
+	if input.AccessLevel != nil {
+		switch access := repository.CommunityAccess(*input.AccessLevel); access {
+		case repository.CommunityAccessOpen, repository.CommunityAccessClosed, repository.CommunityAccessPaid:
+			params.AccessLevel = repository.NullCommunityAccess{CommunityAccess: access, Valid: true}
+		default:
+			return nil, ErrValidation.WithMessage("access_level must be 'open', 'closed' or 'paid'")
+		}
+	}
+
+	if input.ContactEmail != nil {
+		email := strings.TrimSpace(*input.ContactEmail)
+		if email != "" {
+			if _, err := mail.ParseAddress(email); err != nil {
+				return nil, ErrValidation.WithMessage("Invalid contact_email")
+			}
+		}
+		params.ContactEmail = pgtype.Text{String: email, Valid: true}
+	}
+
+	if len(input.SocialLinks) > 0 {
+		links, err := normalizeSocialLinks(input.SocialLinks)
+		if err != nil {
+			return nil, err
+		}
+		params.SocialLinks = links
+	}
+
+	updated, err := s.repo.UpdateCommunity(ctx, params)
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists.WithMessage("A community with this name already exists, try another name")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update community: %w", err)
+	}
+
+	return buildCommunityResponse(updated), nil
+}
+
+// UploadLogo stores a new community logo and returns its URL
+func (s *CommunityService) UploadLogo(ctx context.Context, communityID uuid.UUID, fileData []byte) (string, error) {
+	return s.uploadImage(ctx, communityID, "logo", fileData)
+}
+
+// UploadBanner stores a new community banner and returns its URL
+func (s *CommunityService) UploadBanner(ctx context.Context, communityID uuid.UUID, fileData []byte) (string, error) {
+	return s.uploadImage(ctx, communityID, "banner", fileData)
+}
+
 // Join handles a user joining a community
 func (s *CommunityService) Join(ctx context.Context, userID, communityID uuid.UUID, message string) (map[string]interface{}, error) {
 	community, err := s.repo.GetCommunityByID(ctx, pgtype.UUID{Bytes: communityID, Valid: true})
@@ -231,16 +363,28 @@ func (s *CommunityService) Join(ctx context.Context, userID, communityID uuid.UU
 			return nil, ErrAlreadyMember.WithMessage("Join request already pending")
 		}
 		if existing.Status.MemberStatus == repository.MemberStatusBanned {
-			return nil, ErrForbidden.WithMessage("You are banned from this community")
+			lifted, err := banLifted(ctx, s.repo, existing, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if !lifted {
+				return nil, ErrForbidden.WithMessage("You are banned from this community")
+			}
 		}
+		return s.rejoin(ctx, community, existing)
 	}
 
 	// Determine status based on access level
 	status := repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true}
 	responseMsg := "Вы вступили в сообщество"
-	if community.AccessLevel.CommunityAccess == repository.CommunityAccessClosed {
+	switch community.AccessLevel.CommunityAccess {
+	case repository.CommunityAccessClosed:
 		status = repository.NullMemberStatus{MemberStatus: repository.MemberStatusPending, Valid: true}
 		responseMsg = "Заявка отправлена"
+	case repository.CommunityAccessPaid:
+		// Paid members are activated once their first dues payment is recorded
+		status = repository.NullMemberStatus{MemberStatus: repository.MemberStatusPending, Valid: true}
+		responseMsg = "Оплатите членский взнос, чтобы вступить"
 	}
 
 	var appMsg pgtype.Text
@@ -258,10 +402,68 @@ func (s *CommunityService) Join(ctx context.Context, userID, communityID uuid.UU
 	if err != nil {
 		return nil, fmt.Errorf("add member: %w", err)
 	}
+	if member.Status.MemberStatus == repository.MemberStatusActive {
+		s.news.Subscribe(ctx, userID, communityID)
+	}
 
 	return map[string]interface{}{
-		"status":  string(member.Status.MemberStatus),
-		"message": responseMsg,
+		"status":           string(member.Status.MemberStatus),
+		"message":          responseMsg,
+		"payment_required": community.AccessLevel.CommunityAccess == repository.CommunityAccessPaid,
+	}, nil
+}
+
+// rejoin handles Join for someone who already has a membership row: they
+// left, were rejected, had a temporary ban run out or let paid dues expire.
+// Expired members of a paid community have to pay again; everyone else is
+// treated like a new applicant and starts over as a regular member.
+func (s *CommunityService) rejoin(ctx context.Context, community repository.Community, member repository.CommunityMember) (map[string]interface{}, error) {
+	paid := community.AccessLevel.CommunityAccess == repository.CommunityAccessPaid
+	if paid && member.Status.MemberStatus == repository.MemberStatusExpired {
+		return map[string]interface{}{
+			"status":           string(member.Status.MemberStatus),
+			"message":          "Оплатите членский взнос, чтобы вернуться",
+			"payment_required": true,
+		}, nil
+	}
+
+	status := repository.MemberStatusActive
+	responseMsg := "Вы вступили в сообщество"
+	switch community.AccessLevel.CommunityAccess {
+	case repository.CommunityAccessClosed:
+		status = repository.MemberStatusPending
+		responseMsg = "Заявка отправлена"
+	case repository.CommunityAccessPaid:
+		status = repository.MemberStatusPending
+		responseMsg = "Оплатите членский взнос, чтобы вступить"
+	}
+
+	if member.Role.CommunityRole != repository.CommunityRoleMember {
+		if _, err := s.repo.UpdateCommunityMemberRole(ctx, repository.UpdateCommunityMemberRoleParams{
+			CommunityID: member.CommunityID,
+			UserID:      member.UserID,
+			Role:        repository.NullCommunityRole{CommunityRole: repository.CommunityRoleMember, Valid: true},
+		}); err != nil {
+			return nil, fmt.Errorf("update member role: %w", err)
+		}
+	}
+
+	updated, err := s.repo.UpdateCommunityMemberStatus(ctx, repository.UpdateCommunityMemberStatusParams{
+		CommunityID: member.CommunityID,
+		UserID:      member.UserID,
+		Status:      repository.NullMemberStatus{MemberStatus: status, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update member status: %w", err)
+	}
+	if updated.Status.MemberStatus == repository.MemberStatusActive {
+		s.news.Subscribe(ctx, uuid.UUID(updated.UserID.Bytes), uuid.UUID(updated.CommunityID.Bytes))
+	}
+
+	return map[string]interface{}{
+		"status":           string(updated.Status.MemberStatus),
+		"message":          responseMsg,
+		"payment_required": paid,
 	}, nil
 }
 
@@ -278,6 +480,11 @@ func (s *CommunityService) Leave(ctx context.Context, userID, communityID uuid.U
 		return fmt.Errorf("get member: %w", err)
 	}
 
+	// Leaving would drop the ban and let the user rejoin
+	if member.Status.MemberStatus == repository.MemberStatusBanned {
+		return ErrForbidden.WithMessage("You are banned from this community")
+	}
+
 	// Owner cannot leave
 	if member.Role.CommunityRole == repository.CommunityRoleOwner {
 		return ErrForbidden.WithMessage("Owner cannot leave community. Transfer ownership first.")
@@ -291,6 +498,7 @@ func (s *CommunityService) Leave(ctx context.Context, userID, communityID uuid.U
 		return fmt.Errorf("delete member: %w", err)
 	}
 
+	s.news.Unsubscribe(ctx, userID, communityID)
 	return nil
 }
 
@@ -400,6 +608,11 @@ func (s *CommunityService) UpdateMemberRole(ctx context.Context, actorID, commun
 		return ErrInsufficientRole
 	}
 
+	// Ownership changes hands only through a confirmed transfer
+	if newRole == string(repository.CommunityRoleOwner) {
+		return ErrForbidden.WithMessage("Use an ownership transfer to make someone the owner")
+	}
+
 	// Only owner can promote to admin
 	if newRole == "admin" && actor.Role.CommunityRole != repository.CommunityRoleOwner {
 		return ErrInsufficientRole.WithMessage("Only owner can promote to admin")
@@ -433,7 +646,9 @@ func (s *CommunityService) UpdateMemberRole(ctx context.Context, actorID, commun
 	return nil
 }
 
-// ReviewRequest approves or rejects a join request
+// ReviewRequest approves or rejects a join request. Requests to paid
+// communities can only be rejected: those members are activated by paying
+// their dues, which also gives them the period they expire after.
 func (s *CommunityService) ReviewRequest(ctx context.Context, actorID, communityID, targetUserID uuid.UUID, approve bool) error {
 	// Verify actor has permission
 	actor, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
@@ -464,6 +679,19 @@ func (s *CommunityService) ReviewRequest(ctx context.Context, actorID, community
 		return ErrValidation.WithMessage("User is not in pending status")
 	}
 
+	if approve {
+		community, err := s.repo.GetCommunityByID(ctx, pgtype.UUID{Bytes: communityID, Valid: true})
+		if err == pgx.ErrNoRows {
+			return ErrCommunityNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("get community: %w", err)
+		}
+		if community.AccessLevel.CommunityAccess == repository.CommunityAccessPaid {
+			return ErrValidation.WithMessage("Members of paid communities are activated once their dues are paid")
+		}
+	}
+
 	newStatus := repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true}
 	if !approve {
 		newStatus = repository.NullMemberStatus{MemberStatus: repository.MemberStatusLeft, Valid: true}
@@ -479,6 +707,9 @@ func (s *CommunityService) ReviewRequest(ctx context.Context, actorID, community
 		return fmt.Errorf("update status: %w", err)
 	}
 
+	if approve {
+		s.news.Subscribe(ctx, targetUserID, communityID)
+	}
 	return nil
 }
 
@@ -509,11 +740,101 @@ func (s *CommunityService) ListMyCommunities(ctx context.Context, userID uuid.UU
 	return result, nil
 }
 
+// uploadImage validates and uploads a logo or banner and saves its URL on the community
+func (s *CommunityService) uploadImage(ctx context.Context, communityID uuid.UUID, kind string, fileData []byte) (string, error) {
+	if s.storage == nil {
+		return "", fmt.Errorf("upload community %s: storage service not configured", kind)
+	}
+
+	contentType, err := ValidateImage(fileData)
+	if err != nil {
+		return "", err
+	}
+
+	community, err := s.repo.GetCommunityByID(ctx, pgtype.UUID{Bytes: communityID, Valid: true})
+	if err == pgx.ErrNoRows {
+		return "", ErrCommunityNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get community: %w", err)
+	}
+
+	key := GenerateCommunityImageKey(communityID, kind, contentType)
+	url, err := s.storage.Upload(ctx, "", key, bytes.NewReader(fileData), contentType)
+	if err != nil {
+		return "", fmt.Errorf("upload community %s: %w", kind, err)
+	}
+
+	params := repository.UpdateCommunityParams{ID: community.ID}
+	if kind == "logo" {
+		params.LogoUrl = pgtype.Text{String: url, Valid: true}
+	} else {
+		params.BannerUrl = pgtype.Text{String: url, Valid: true}
+	}
+	if _, err := s.repo.UpdateCommunity(ctx, params); err != nil {
+		return "", fmt.Errorf("update community %s url: %w", kind, err)
+	}
+
+	return url, nil
+}
+
+// uniqueSlug returns base, or base with a numeric suffix, that no other community uses.
+// excludeID is the community being renamed (invalid on create).
+func (s *CommunityService) uniqueSlug(ctx context.Context, base string, excludeID pgtype.UUID) (string, error) {
+	candidate := base
+	for i := 2; i <= maxSlugAttempts+1; i++ {
+		exists, err := s.repo.CommunitySlugExists(ctx, repository.CommunitySlugExistsParams{
+			Slug:      candidate,
+			ExcludeID: excludeID,
+		})
+		if err != nil {
+			return "", fmt.Errorf("check community slug: %w", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return base + "-" + uuid.New().String()[:8], nil
+}
+
+// normalizeSocialLinks accepts an object of network name to http(s) URL, e.g. {"instagram": "https://..."}.
+// An empty object clears the links.
+func normalizeSocialLinks(raw json.RawMessage) ([]byte, error) {
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return []byte("{}"), nil
+	}
+
+	var links map[string]string
+	if err := json.Unmarshal(raw, &links); err != nil {
+		return nil, ErrValidation.WithMessage("social_links must be an object of URLs")
+	}
+	for name, link := range links {
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("social_links.%s must be an http(s) URL", name))
+		}
+	}
+	return json.Marshal(links)
+}
+
+func optionalText(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: strings.TrimSpace(*v), Valid: true}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
 func buildCommunityResponse(c repository.Community) map[string]interface{} {
 	cID, _ := uuid.FromBytes(c.ID.Bytes[:])
 	creatorID, _ := uuid.FromBytes(c.CreatedBy.Bytes[:])
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"id":                  cID.String(),
 		"name":                c.Name,
 		"slug":                c.Slug.String,
@@ -522,10 +843,12 @@ func buildCommunityResponse(c repository.Community) map[string]interface{} {
 		"community_type":      string(c.CommunityType),
 		"access_level":        string(c.AccessLevel.CommunityAccess),
 		"verification_status": string(c.VerificationStatus.VerificationStatus),
+		"is_verified":         c.VerificationStatus.VerificationStatus == repository.VerificationStatusVerified,
 		"logo_url":            c.LogoUrl.String,
 		"banner_url":          c.BannerUrl.String,
 		"contact_phone":       c.ContactPhone.String,
-		"social_links":        c.SocialLinks,
+		"contact_email":       c.ContactEmail.String,
+		"social_links":        socialLinksJSON(c.SocialLinks),
 		"address":             c.Address.String,
 		"district":            c.District.String,
 		"member_count":        c.MemberCount.Int32,
@@ -533,6 +856,19 @@ func buildCommunityResponse(c repository.Community) map[string]interface{} {
 		"created_by":          creatorID.String(),
 		"created_at":          c.CreatedAt.Time,
 	}
+	if c.VerifiedAt.Valid {
+		result["verified_at"] = c.VerifiedAt.Time
+	}
+
+	return result
+}
+
+// socialLinksJSON keeps the JSONB column as an object in responses instead of base64 bytes
+func socialLinksJSON(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("{}")
+	}
+	return json.RawMessage(raw)
 }
 
 func generateSlug(name string) string {
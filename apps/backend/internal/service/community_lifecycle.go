@@ -0,0 +1,550 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ownershipTransferTTL is how long the nominated member has to accept
+const ownershipTransferTTL = 7 * 24 * time.Hour
+
+// CommunityLifecycleService handles ownership transfers and community
+// deactivation. A transfer only takes effect once the new owner accepts it;
+// the previous owner stays on as admin. A deactivated community is hidden
+// from listings, its leaderboard is frozen and its chat archived until a
+// superadmin reactivates it. Every step is written to audit_logs.
+type CommunityLifecycleService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+}
+
+// NewCommunityLifecycleService creates a new CommunityLifecycleService
+func NewCommunityLifecycleService(repo *repository.Queries, pool txStarter, notifications *NotificationService) *CommunityLifecycleService {
+	return &CommunityLifecycleService{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+	}
+}
+
+// StartTransfer nominates an active member as the next owner
+func (s *CommunityLifecycleService) StartTransfer(ctx context.Context, ownerID, communityID, toUserID uuid.UUID) (map[string]interface{}, error) {
+	if ownerID == toUserID {
+		return nil, ErrValidation.WithMessage("You already own this community")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	if _, err := s.requireOwner(ctx, qtx, ownerID, communityID); err != nil {
+		return nil, err
+	}
+
+	target, err := qtx.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(toUserID),
+	})
+	if err == pgx.ErrNoRows || (err == nil && target.Status.MemberStatus != repository.MemberStatusActive) {
+		return nil, ErrNotCommunityMember.WithMessage("Ownership can only be transferred to an active member")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get target member: %w", err)
+	}
+
+	now := time.Now()
+	pending, err := qtx.GetPendingOwnershipTransfer(ctx, uuidToPgtype(communityID))
+	switch {
+	case err == nil && !transferExpired(pending, now):
+		return nil, ErrAlreadyExists.WithMessage("An ownership transfer is already pending; cancel it first")
+	case err == nil:
+		if _, err := qtx.SetOwnershipTransferStatus(ctx, repository.SetOwnershipTransferStatusParams{
+			Status: repository.OwnershipTransferStatusExpired,
+			ID:     pending.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("expire transfer: %w", err)
+		}
+	case err != pgx.ErrNoRows:
+		return nil, fmt.Errorf("get pending transfer: %w", err)
+	}
+
+	transfer, err := qtx.CreateOwnershipTransfer(ctx, repository.CreateOwnershipTransferParams{
+		CommunityID: uuidToPgtype(communityID),
+		FromUserID:  uuidToPgtype(ownerID),
+		ToUserID:    uuidToPgtype(toUserID),
+		ExpiresAt:   pgtype.Timestamptz{Time: now.Add(ownershipTransferTTL), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create transfer: %w", err)
+	}
+
+	if err := s.audit(ctx, qtx, ownerID, "community.ownership_transfer_started", transfer.CommunityID, map[string]interface{}{
+		"transfer_id": pgtypeUUIDToStringRequired(transfer.ID),
+		"to_user_id":  toUserID.String(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, toUserID, transfer, "Вам предлагают стать владельцем сообщества",
+		"Подтвердите передачу прав владельца в течение 7 дней")
+	return transferResponse(transfer), nil
+}
+
+// GetTransfer returns the pending transfer to its sender or recipient
+func (s *CommunityLifecycleService) GetTransfer(ctx context.Context, userID, communityID uuid.UUID) (map[string]interface{}, error) {
+	transfer, err := s.pendingTransfer(ctx, s.repo, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	uid := uuidToPgtype(userID)
+	if transfer.FromUserID != uid && transfer.ToUserID != uid {
+		return nil, ErrTransferNotFound
+	}
+
+	return transferResponse(transfer), nil
+}
+
+// CancelTransfer withdraws the owner's pending transfer
+func (s *CommunityLifecycleService) CancelTransfer(ctx context.Context, ownerID, communityID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	if _, err := s.requireOwner(ctx, qtx, ownerID, communityID); err != nil {
+		return err
+	}
+
+	transfer, err := s.respond(ctx, qtx, ownerID, communityID, repository.OwnershipTransferStatusCancelled)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, uuid.UUID(transfer.ToUserID.Bytes), transfer, "Передача прав владельца отменена", "")
+	return nil
+}
+
+// DeclineTransfer lets the nominated member turn the ownership down
+func (s *CommunityLifecycleService) DeclineTransfer(ctx context.Context, userID, communityID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	transfer, err := s.respond(ctx, qtx, userID, communityID, repository.OwnershipTransferStatusDeclined)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, uuid.UUID(transfer.FromUserID.Bytes), transfer, "Передача прав владельца отклонена", "")
+	return nil
+}
+
+// AcceptTransfer makes the nominated member the owner and demotes the
+// previous owner to admin
+func (s *CommunityLifecycleService) AcceptTransfer(ctx context.Context, userID, communityID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	transfer, err := s.pendingTransfer(ctx, qtx, communityID)
+	if err != nil {
+		return err
+	}
+	if transfer.ToUserID != uuidToPgtype(userID) {
+		return ErrTransferNotFound
+	}
+
+	newOwner, err := qtx.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: transfer.CommunityID,
+		UserID:      transfer.ToUserID,
+	})
+	if err != nil || newOwner.Status.MemberStatus != repository.MemberStatusActive {
+		return ErrNotCommunityMember.WithMessage("Only an active member can become the owner")
+	}
+
+	oldOwner, err := qtx.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: transfer.CommunityID,
+		UserID:      transfer.FromUserID,
+	})
+	if err != nil || oldOwner.Role.CommunityRole != repository.CommunityRoleOwner {
+		return ErrForbidden.WithMessage("The sender no longer owns this community")
+	}
+
+	if _, err := qtx.UpdateCommunityMemberRole(ctx, repository.UpdateCommunityMemberRoleParams{
+		CommunityID: transfer.CommunityID,
+		UserID:      transfer.FromUserID,
+		Role:        repository.NullCommunityRole{CommunityRole: repository.CommunityRoleAdmin, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("demote previous owner: %w", err)
+	}
+	if _, err := qtx.UpdateCommunityMemberRole(ctx, repository.UpdateCommunityMemberRoleParams{
+		CommunityID: transfer.CommunityID,
+		UserID:      transfer.ToUserID,
+		Role:        repository.NullCommunityRole{CommunityRole: repository.CommunityRoleOwner, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("promote new owner: %w", err)
+	}
+
+	transfer, err = s.respond(ctx, qtx, userID, communityID, repository.OwnershipTransferStatusAccepted)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, uuid.UUID(transfer.FromUserID.Bytes), transfer, "Права владельца переданы",
+		"Новый владелец подтвердил передачу. Вы остаётесь администратором сообщества")
+	return nil
+}
+
+// Deactivate takes a community offline: it disappears from listings, its
+// leaderboard stops changing and its chat is archived
+func (s *CommunityLifecycleService) Deactivate(ctx context.Context, ownerID, communityID uuid.UUID, reason string) error {
+	reason, err := normalizeModerationReason(reason, false)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	if _, err := s.requireOwner(ctx, qtx, ownerID, communityID); err != nil {
+		return err
+	}
+
+	changed, err := qtx.SetCommunityActive(ctx, repository.SetCommunityActiveParams{
+		IsActive: pgtype.Bool{Bool: false, Valid: true},
+		ID:       uuidToPgtype(communityID),
+	})
+	if err != nil {
+		return fmt.Errorf("deactivate community: %w", err)
+	}
+	if changed == 0 {
+		return ErrCommunityNotFound
+	}
+
+	if _, err := qtx.CreateCommunityDeactivation(ctx, repository.CreateCommunityDeactivationParams{
+		CommunityID:   uuidToPgtype(communityID),
+		DeactivatedBy: uuidToPgtype(ownerID),
+		Reason:        pgtype.Text{String: reason, Valid: reason != ""},
+	}); err != nil {
+		return fmt.Errorf("record deactivation: %w", err)
+	}
+
+	if err := qtx.SetCommunityChatArchived(ctx, repository.SetCommunityChatArchivedParams{
+		IsArchived:  pgtype.Bool{Bool: true, Valid: true},
+		CommunityID: uuidToPgtype(communityID),
+	}); err != nil {
+		return fmt.Errorf("archive community chat: %w", err)
+	}
+
+	// A pending transfer makes no sense for an archived community
+	pending, err := qtx.GetPendingOwnershipTransfer(ctx, uuidToPgtype(communityID))
+	if err == nil {
+		if _, err := qtx.SetOwnershipTransferStatus(ctx, repository.SetOwnershipTransferStatusParams{
+			Status: repository.OwnershipTransferStatusCancelled,
+			ID:     pending.ID,
+		}); err != nil {
+			return fmt.Errorf("cancel transfer: %w", err)
+		}
+	} else if err != pgx.ErrNoRows {
+		return fmt.Errorf("get pending transfer: %w", err)
+	}
+
+	details := map[string]interface{}{}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	if err := s.audit(ctx, qtx, ownerID, "community.deactivated", uuidToPgtype(communityID), details); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Reactivate brings a deactivated community back (superadmin only)
+func (s *CommunityLifecycleService) Reactivate(ctx context.Context, adminID, communityID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	state, err := qtx.GetCommunityActiveState(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return ErrCommunityNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get community: %w", err)
+	}
+	if !state.IsActive.Valid || state.IsActive.Bool {
+		return ErrValidation.WithMessage("Community is already active")
+	}
+
+	if _, err := qtx.SetCommunityActive(ctx, repository.SetCommunityActiveParams{
+		IsActive: pgtype.Bool{Bool: true, Valid: true},
+		ID:       state.ID,
+	}); err != nil {
+		return fmt.Errorf("reactivate community: %w", err)
+	}
+
+	if err := qtx.CloseCommunityDeactivation(ctx, repository.CloseCommunityDeactivationParams{
+		ReactivatedBy: uuidToPgtype(adminID),
+		CommunityID:   state.ID,
+	}); err != nil {
+		return fmt.Errorf("close deactivation: %w", err)
+	}
+
+	if err := qtx.SetCommunityChatArchived(ctx, repository.SetCommunityChatArchivedParams{
+		IsArchived:  pgtype.Bool{Bool: false, Valid: true},
+		CommunityID: state.ID,
+	}); err != nil {
+		return fmt.Errorf("unarchive community chat: %w", err)
+	}
+
+	if err := s.audit(ctx, qtx, adminID, "community.reactivated", state.ID, map[string]interface{}{}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeactivated returns deactivated communities for superadmin review
+func (s *CommunityLifecycleService) ListDeactivated(ctx context.Context, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	offset := (page - 1) * perPage
+
+	rows, err := s.repo.ListDeactivatedCommunities(ctx, repository.ListDeactivatedCommunitiesParams{
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list deactivated communities: %w", err)
+	}
+
+	total, err := s.repo.CountDeactivatedCommunities(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count deactivated communities: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, c := range rows {
+		item := map[string]interface{}{
+			"id":             pgtypeUUIDToStringRequired(c.ID),
+			"name":           c.Name,
+			"slug":           c.Slug.String,
+			"logo_url":       c.LogoUrl.String,
+			"member_count":   c.MemberCount.Int32,
+			"reason":         c.Reason.String,
+			"deactivated_at": nil,
+			"deactivated_by": nil,
+		}
+		if c.DeactivatedAt.Valid {
+			item["deactivated_at"] = c.DeactivatedAt.Time
+		}
+		if c.DeactivatedBy.Valid {
+			item["deactivated_by"] = map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(c.DeactivatedBy),
+				"first_name": c.DeactivatedByFirstName.String,
+				"last_name":  c.DeactivatedByLastName.String,
+			}
+		}
+		result = append(result, item)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// requireOwner checks the user owns the (active) community
+func (s *CommunityLifecycleService) requireOwner(ctx context.Context, q *repository.Queries, userID, communityID uuid.UUID) (repository.CommunityMember, error) {
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(userID),
+	})
+	if err != nil || member.Role.CommunityRole != repository.CommunityRoleOwner {
+		return repository.CommunityMember{}, ErrInsufficientRole.WithMessage("Only the owner can do this")
+	}
+	active, err := communityIsActive(ctx, q, member.CommunityID)
+	if err != nil {
+		return repository.CommunityMember{}, err
+	}
+	if !active {
+		return repository.CommunityMember{}, ErrCommunityNotFound
+	}
+	return member, nil
+}
+
+// pendingTransfer loads the community's open transfer, ignoring expired ones
+func (s *CommunityLifecycleService) pendingTransfer(ctx context.Context, q *repository.Queries, communityID uuid.UUID) (repository.CommunityOwnershipTransfer, error) {
+	transfer, err := q.GetPendingOwnershipTransfer(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return repository.CommunityOwnershipTransfer{}, ErrTransferNotFound
+	}
+	if err != nil {
+		return repository.CommunityOwnershipTransfer{}, fmt.Errorf("get pending transfer: %w", err)
+	}
+	if transferExpired(transfer, time.Now()) {
+		return repository.CommunityOwnershipTransfer{}, ErrTransferNotFound.WithMessage("The ownership transfer has expired")
+	}
+	return transfer, nil
+}
+
+// respond closes the pending transfer on behalf of its sender or recipient
+func (s *CommunityLifecycleService) respond(
+	ctx context.Context,
+	q *repository.Queries,
+	userID, communityID uuid.UUID,
+	status repository.OwnershipTransferStatus,
+) (repository.CommunityOwnershipTransfer, error) {
+	transfer, err := s.pendingTransfer(ctx, q, communityID)
+	if err != nil {
+		return repository.CommunityOwnershipTransfer{}, err
+	}
+
+	party := transfer.ToUserID
+	if status == repository.OwnershipTransferStatusCancelled {
+		party = transfer.FromUserID
+	}
+	if party != uuidToPgtype(userID) {
+		return repository.CommunityOwnershipTransfer{}, ErrTransferNotFound
+	}
+
+	transfer, err = q.SetOwnershipTransferStatus(ctx, repository.SetOwnershipTransferStatusParams{
+		Status: status,
+		ID:     transfer.ID,
+	})
+	if err != nil {
+		return repository.CommunityOwnershipTransfer{}, fmt.Errorf("update transfer: %w", err)
+	}
+
+	if err := s.audit(ctx, q, userID, "community.ownership_transfer_"+string(status), transfer.CommunityID, map[string]interface{}{
+		"transfer_id":  pgtypeUUIDToStringRequired(transfer.ID),
+		"from_user_id": pgtypeUUIDToStringRequired(transfer.FromUserID),
+		"to_user_id":   pgtypeUUIDToStringRequired(transfer.ToUserID),
+	}); err != nil {
+		return repository.CommunityOwnershipTransfer{}, err
+	}
+	return transfer, nil
+}
+
+func (s *CommunityLifecycleService) audit(ctx context.Context, q *repository.Queries, actorID uuid.UUID, action string, communityID pgtype.UUID, details map[string]interface{}) error {
+	detailsJSON, _ := json.Marshal(details)
+
+	if err := q.CreateAuditLog(ctx, repository.CreateAuditLogParams{
+		ActorID:    uuidToPgtype(actorID),
+		Action:     action,
+		EntityType: pgtype.Text{String: "community", Valid: true},
+		EntityID:   communityID,
+		Details:    detailsJSON,
+	}); err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+	return nil
+}
+
+func (s *CommunityLifecycleService) notify(ctx context.Context, userID uuid.UUID, transfer repository.CommunityOwnershipTransfer, title, body string) {
+	if body == "" {
+		body = title
+	}
+	_, err := s.notifications.Create(ctx, userID,
+		string(repository.NotificationTypeCommunityOwnership),
+		title,
+		body,
+		map[string]any{
+			"community_id": pgtypeUUIDToStringRequired(transfer.CommunityID),
+			"transfer_id":  pgtypeUUIDToStringRequired(transfer.ID),
+		},
+	)
+	if err != nil {
+		slog.Warn("failed to send ownership notification", "error", err)
+	}
+}
+
+func transferResponse(t repository.CommunityOwnershipTransfer) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(t.ID),
+		"community_id": pgtypeUUIDToStringRequired(t.CommunityID),
+		"from_user_id": pgtypeUUIDToStringRequired(t.FromUserID),
+		"to_user_id":   pgtypeUUIDToStringRequired(t.ToUserID),
+		"status":       string(t.Status),
+		"expires_at":   t.ExpiresAt.Time,
+		"created_at":   t.CreatedAt.Time,
+	}
+}
+
+// transferExpired reports whether a pending transfer can no longer be accepted
+func transferExpired(t repository.CommunityOwnershipTransfer, now time.Time) bool {
+	return t.ExpiresAt.Valid && !t.ExpiresAt.Time.After(now)
+}
+
+// communityIsActive reports whether the community exists and is active
+func communityIsActive(ctx context.Context, q *repository.Queries, communityID pgtype.UUID) (bool, error) {
+	state, err := q.GetCommunityActiveState(ctx, communityID)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get community state: %w", err)
+	}
+	return state.IsActive.Valid && state.IsActive.Bool, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestTransferExpired(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{"future", now.Add(time.Hour), false},
+		{"exactly now", now, true},
+		{"past", now.Add(-time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer := repository.CommunityOwnershipTransfer{
+				ExpiresAt: pgtype.Timestamptz{Time: tt.expiresAt, Valid: true},
+			}
+			if got := transferExpired(transfer, now); got != tt.want {
+				t.Errorf("transferExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommunityIsActive(t *testing.T) {
+	ctx := context.Background()
+	communityID := uuidToPgtype(uuid.New())
+
+	db := newFakeDB()
+	db.rows("GetCommunityActiveState")
+	if active, err := communityIsActive(ctx, db.queries(), communityID); active || err != nil {
+		t.Errorf("missing community: active = %v, err = %v, want false and no error", active, err)
+	}
+
+	db.rows("GetCommunityActiveState", []any{repository.GetCommunityActiveStateRow{
+		ID:       communityID,
+		IsActive: pgtype.Bool{Bool: true, Valid: true},
+	}})
+	if active, err := communityIsActive(ctx, db.queries(), communityID); !active || err != nil {
+		t.Errorf("active community: active = %v, err = %v, want true and no error", active, err)
+	}
+
+	db.on("GetCommunityActiveState", func([]any) ([][]any, error) {
+		return nil, errors.New("connection reset")
+	})
+	if _, err := communityIsActive(ctx, db.queries(), communityID); err == nil {
+		t.Error("query failure was not reported")
+	}
+}
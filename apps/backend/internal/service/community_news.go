@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CommunityNewsTopic is the push topic of a community's news. Announcements
+// to all members and scheduled posts are pushed through it.
+func CommunityNewsTopic(communityID uuid.UUID) string {
+	return "community_" + communityID.String() + "_news"
+}
+
+// CommunityNews keeps the community news topic subscriptions in step with
+// memberships. A user's devices are subscribed to the topic of every
+// community they are an active member of while community_news is enabled,
+// and unsubscribed when they leave, are kicked or banned, let their dues
+// expire or turn the setting off. Subscription changes are best effort: a
+// failure is logged and does not undo the membership change.
+type CommunityNews struct {
+	repo     *repository.Queries
+	firebase *FirebaseService
+}
+
+// NewCommunityNews creates a new CommunityNews
+func NewCommunityNews(repo *repository.Queries, firebase *FirebaseService) *CommunityNews {
+	return &CommunityNews{
+		repo:     repo,
+		firebase: firebase,
+	}
+}
+
+// Subscribe subscribes a new active member unless they turned community_news off
+func (n *CommunityNews) Subscribe(ctx context.Context, userID, communityID uuid.UUID) {
+	if n == nil {
+		return
+	}
+
+	enabled, err := n.repo.GetCommunityNewsSetting(ctx, uuidToPgtype(userID))
+	if err != nil {
+		slog.Warn("failed to read community news setting", "user_id", userID, "error", err)
+		return
+	}
+	if enabled {
+		n.subscribe(ctx, userID, communityID)
+	}
+}
+
+// Unsubscribe unsubscribes a user who is no longer an active member
+func (n *CommunityNews) Unsubscribe(ctx context.Context, userID, communityID uuid.UUID) {
+	if n == nil {
+		return
+	}
+
+	topic := CommunityNewsTopic(communityID)
+	if err := n.firebase.UnsubscribeFromTopic(ctx, userID, topic); err != nil {
+		slog.Warn("failed to unsubscribe from community news", "user_id", userID, "topic", topic, "error", err)
+	}
+}
+
+// Sync subscribes the user to the news of all their active memberships, or
+// unsubscribes them from it, according to their community_news setting
+func (n *CommunityNews) Sync(ctx context.Context, userID uuid.UUID) {
+	if n == nil {
+		return
+	}
+
+	enabled, err := n.repo.GetCommunityNewsSetting(ctx, uuidToPgtype(userID))
+	if err != nil {
+		slog.Warn("failed to read community news setting", "user_id", userID, "error", err)
+		return
+	}
+	communityIDs, err := n.repo.ListMemberCommunityIDs(ctx, uuidToPgtype(userID))
+	if err != nil {
+		slog.Warn("failed to list communities for news subscriptions", "user_id", userID, "error", err)
+		return
+	}
+
+	for _, id := range communityIDs {
+		communityID := uuid.UUID(id.Bytes)
+		if enabled {
+			n.subscribe(ctx, userID, communityID)
+		} else {
+			n.Unsubscribe(ctx, userID, communityID)
+		}
+	}
+}
+
+func (n *CommunityNews) subscribe(ctx context.Context, userID, communityID uuid.UUID) {
+	topic := CommunityNewsTopic(communityID)
+	if err := n.firebase.SubscribeToTopic(ctx, userID, topic); err != nil {
+		slog.Warn("failed to subscribe to community news", "user_id", userID, "topic", topic, "error", err)
+	}
+}
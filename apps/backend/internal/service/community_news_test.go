@@ -0,0 +1,14 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCommunityNewsTopic(t *testing.T) {
+	id := uuid.MustParse("6f1c2b9e-3d4a-4b5c-8d7e-9f0a1b2c3d4e")
+	if got := CommunityNewsTopic(id); got != "community_6f1c2b9e-3d4a-4b5c-8d7e-9f0a1b2c3d4e_news" {
+		t.Errorf("unexpected topic %q", got)
+	}
+}
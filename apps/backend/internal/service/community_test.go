@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeCommunityStore keeps the communities created through the fake DB
+func fakeCommunityStore(db *fakeDB) map[[16]byte]repository.Community {
+	communities := map[[16]byte]repository.Community{}
+
+	db.rows("CommunitySlugExists", []any{false})
+	db.on("CreateCommunity", func(args []any) ([][]any, error) {
+		c := repository.Community{
+			ID:            uuidToPgtype(uuid.New()),
+			Name:          args[0].(string),
+			Slug:          args[1].(pgtype.Text),
+			CommunityType: args[3].(repository.CommunityType),
+			AccessLevel:   args[4].(repository.NullCommunityAccess),
+			IsActive:      pgtype.Bool{Bool: true, Valid: true},
+			CreatedBy:     args[7].(pgtype.UUID),
+		}
+		communities[c.ID.Bytes] = c
+		return [][]any{{c}}, nil
+	})
+	db.on("GetCommunityByID", func(args []any) ([][]any, error) {
+		c, ok := communities[args[0].(pgtype.UUID).Bytes]
+		if !ok {
+			return nil, nil
+		}
+		return [][]any{{c}}, nil
+	})
+	db.on("AddCommunityMember", func(args []any) ([][]any, error) {
+		return [][]any{{repository.CommunityMember{CommunityID: args[0].(pgtype.UUID), UserID: args[1].(pgtype.UUID)}}}, nil
+	})
+	return communities
+}
+
+// fakeCommunityUpdates applies UpdateCommunity to the communities of fakeCommunityStore
+func fakeCommunityUpdates(db *fakeDB, communities map[[16]byte]repository.Community) {
+	db.on("UpdateCommunity", func(args []any) ([][]any, error) {
+		p := repository.UpdateCommunityParams{
+			Name:         args[0].(pgtype.Text),
+			Slug:         args[1].(pgtype.Text),
+			AccessLevel:  args[4].(repository.NullCommunityAccess),
+			LogoUrl:      args[5].(pgtype.Text),
+			BannerUrl:    args[6].(pgtype.Text),
+			ContactEmail: args[8].(pgtype.Text),
+			SocialLinks:  args[9].([]byte),
+			ID:           args[12].(pgtype.UUID),
+		}
+		c, ok := communities[p.ID.Bytes]
+		if !ok {
+			return nil, nil
+		}
+		if p.Name.Valid {
+			c.Name = p.Name.String
+		}
+		if p.Slug.Valid {
+			c.Slug = p.Slug
+		}
+		if p.AccessLevel.Valid {
+			c.AccessLevel = p.AccessLevel
+		}
+		if p.LogoUrl.Valid {
+			c.LogoUrl = p.LogoUrl
+		}
+		if p.BannerUrl.Valid {
+			c.BannerUrl = p.BannerUrl
+		}
+		if p.ContactEmail.Valid {
+			c.ContactEmail = p.ContactEmail
+		}
+		if p.SocialLinks != nil {
+			c.SocialLinks = p.SocialLinks
+		}
+		communities[c.ID.Bytes] = c
+		return [][]any{{c}}, nil
+	})
+}
+
+func TestCreatePaidCommunityWithPlan(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityStore(db)
+	db.on("CreateMembershipPlan", func(args []any) ([][]any, error) {
+		return [][]any{{repository.MembershipPlan{
+			ID:             uuidToPgtype(uuid.New()),
+			CommunityID:    args[0].(pgtype.UUID),
+			Name:           args[1].(string),
+			Period:         args[2].(repository.MembershipPeriod),
+			DurationMonths: args[3].(int16),
+			PriceAmount:    args[4].(pgtype.Numeric),
+			PriceCurrency:  args[5].(string),
+			IsActive:       true,
+		}}}, nil
+	})
+
+	communities := NewCommunityService(db.queries(), nil, nil)
+	created, err := communities.Create(ctx, uuid.New(), CreateCommunityInput{
+		Name:          "Теннисный клуб Алматы",
+		CommunityType: "club",
+		AccessLevel:   "paid",
+	})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	if created["access_level"] != "paid" {
+		t.Fatalf("access_level = %v, want paid", created["access_level"])
+	}
+
+	communityID := uuid.MustParse(created["id"].(string))
+	dues := NewDuesService(db.queries(), nil, nil, nil, nil)
+	plan, err := dues.CreatePlan(ctx, communityID, CreatePlanInput{
+		Name:        "Месячный абонемент",
+		Period:      "monthly",
+		PriceAmount: 15000,
+	})
+	if err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+	if plan["name"] != "Месячный абонемент" {
+		t.Errorf("plan = %v", plan)
+	}
+}
+
+func TestUpdateCommunityAccessLevel(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityUpdates(db, fakeCommunityStore(db))
+
+	service := NewCommunityService(db.queries(), nil, nil)
+	created, err := service.Create(ctx, uuid.New(), CreateCommunityInput{Name: "Лига выходного дня", CommunityType: "league"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	communityID := uuid.MustParse(created["id"].(string))
+
+	paid := "paid"
+	updated, err := service.Update(ctx, communityID, UpdateCommunityInput{AccessLevel: &paid})
+	if err != nil {
+		t.Fatalf("update to paid: %v", err)
+	}
+	if updated["access_level"] != "paid" {
+		t.Errorf("access_level = %v, want paid", updated["access_level"])
+	}
+
+	invalid := "vip"
+	var appErr *AppError
+	if _, err := service.Update(ctx, communityID, UpdateCommunityInput{AccessLevel: &invalid}); !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+		t.Errorf("update to %q: error = %v, want validation error", invalid, err)
+	}
+}
+
+func TestReviewRequestPaidCommunity(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityStore(db)
+
+	ownerID, applicantID := uuid.New(), uuid.New()
+	db.on("GetCommunityMember", func(args []any) ([][]any, error) {
+		member := repository.CommunityMember{CommunityID: args[0].(pgtype.UUID), UserID: args[1].(pgtype.UUID)}
+		if member.UserID.Bytes == ownerID {
+			member.Role = repository.NullCommunityRole{CommunityRole: repository.CommunityRoleOwner, Valid: true}
+			member.Status = repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true}
+		} else {
+			member.Role = repository.NullCommunityRole{CommunityRole: repository.CommunityRoleMember, Valid: true}
+			member.Status = repository.NullMemberStatus{MemberStatus: repository.MemberStatusPending, Valid: true}
+		}
+		return [][]any{{member}}, nil
+	})
+	db.on("UpdateCommunityMemberStatus", func(args []any) ([][]any, error) {
+		return [][]any{{repository.CommunityMember{Status: args[2].(repository.NullMemberStatus)}}}, nil
+	})
+
+	service := NewCommunityService(db.queries(), nil, nil)
+	created, err := service.Create(ctx, ownerID, CreateCommunityInput{Name: "Клуб с абонементом", CommunityType: "club", AccessLevel: "paid"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	communityID := uuid.MustParse(created["id"].(string))
+
+	var appErr *AppError
+	if err := service.ReviewRequest(ctx, ownerID, communityID, applicantID, true); !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+		t.Fatalf("approve: error = %v, want validation error", err)
+	}
+	if calls := db.called("UpdateCommunityMemberStatus"); len(calls) != 0 {
+		t.Fatalf("approve changed the membership: %+v", calls)
+	}
+
+	if err := service.ReviewRequest(ctx, ownerID, communityID, applicantID, false); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	calls := db.called("UpdateCommunityMemberStatus")
+	if len(calls) != 1 || calls[0].Args[2].(repository.NullMemberStatus).MemberStatus != repository.MemberStatusLeft {
+		t.Errorf("reject calls = %+v, want one update to left", calls)
+	}
+}
+
+func TestUpdateCommunityRegeneratesSlug(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityUpdates(db, fakeCommunityStore(db))
+
+	service := NewCommunityService(db.queries(), nil, nil)
+	created, err := service.Create(ctx, uuid.New(), CreateCommunityInput{Name: "Morning Tennis", CommunityType: "group"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	communityID := uuid.MustParse(created["id"].(string))
+
+	// Another community already uses the plain slug
+	db.on("CommunitySlugExists", func(args []any) ([][]any, error) {
+		return [][]any{{args[0].(string) == "evening-tennis"}}, nil
+	})
+
+	tests := []struct {
+		name string
+		slug string
+	}{
+		{"Evening Tennis", "evening-tennis-2"},
+		{"EVENING TENNIS", "evening-tennis-2"},
+	}
+	for _, tt := range tests {
+		name := tt.name
+		updated, err := service.Update(ctx, communityID, UpdateCommunityInput{Name: &name})
+		if err != nil {
+			t.Fatalf("rename to %q: %v", tt.name, err)
+		}
+		if updated["name"] != tt.name || updated["slug"] != tt.slug {
+			t.Errorf("rename to %q: name = %v, slug = %v, want slug %s", tt.name, updated["name"], updated["slug"], tt.slug)
+		}
+	}
+
+	// The community's own slug never counts as taken
+	for _, c := range db.called("CommunitySlugExists")[1:] {
+		if c.Args[1].(pgtype.UUID).Bytes != communityID {
+			t.Errorf("slug check %v does not exclude the renamed community", c.Args)
+		}
+	}
+
+	blank := "  "
+	var appErr *AppError
+	if _, err := service.Update(ctx, communityID, UpdateCommunityInput{Name: &blank}); !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+		t.Errorf("blank name: error = %v, want validation error", err)
+	}
+}
+
+func TestUpdateCommunityNameTaken(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityStore(db)
+
+	service := NewCommunityService(db.queries(), nil, nil)
+	created, err := service.Create(ctx, uuid.New(), CreateCommunityInput{Name: "Morning Tennis", CommunityType: "group"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	db.on("UpdateCommunity", func([]any) ([][]any, error) {
+		return nil, &pgconn.PgError{Code: pgUniqueViolation}
+	})
+
+	name := "Evening Tennis"
+	var appErr *AppError
+	_, err = service.Update(ctx, uuid.MustParse(created["id"].(string)), UpdateCommunityInput{Name: &name})
+	if !errors.As(err, &appErr) || appErr.Code != ErrAlreadyExists.Code {
+		t.Errorf("error = %v, want already exists", err)
+	}
+}
+
+func TestUpdateCommunitySocialLinks(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityUpdates(db, fakeCommunityStore(db))
+
+	service := NewCommunityService(db.queries(), nil, nil)
+	created, err := service.Create(ctx, uuid.New(), CreateCommunityInput{Name: "Morning Tennis", CommunityType: "group"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	communityID := uuid.MustParse(created["id"].(string))
+
+	// Responses carry the links as a JSON object, not as base64 bytes
+	if body, _ := json.Marshal(created); !strings.Contains(string(body), `"social_links":{}`) {
+		t.Errorf("created = %s, want empty social_links object", body)
+	}
+
+	tests := []struct {
+		links   string
+		want    string
+		wantErr bool
+	}{
+		{links: `{"instagram": "https://instagram.com/morning.tennis"}`, want: `"social_links":{"instagram":"https://instagram.com/morning.tennis"}`},
+		{links: `null`, want: `"social_links":{}`},
+		{links: `{"telegram": "t.me/morning"}`, wantErr: true},
+		{links: `["https://instagram.com/morning.tennis"]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		updated, err := service.Update(ctx, communityID, UpdateCommunityInput{SocialLinks: json.RawMessage(tt.links)})
+		if tt.wantErr {
+			var appErr *AppError
+			if !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+				t.Errorf("links %s: error = %v, want validation error", tt.links, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("links %s: %v", tt.links, err)
+		}
+		if body, _ := json.Marshal(updated); !strings.Contains(string(body), tt.want) {
+			t.Errorf("links %s: response = %s, want %s", tt.links, body, tt.want)
+		}
+	}
+}
+
+var pngImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+func TestUploadCommunityImages(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	fakeCommunityUpdates(db, fakeCommunityStore(db))
+	storage, s3 := newFakeStorage(t)
+
+	service := NewCommunityService(db.queries(), storage, nil)
+	created, err := service.Create(ctx, uuid.New(), CreateCommunityInput{Name: "Morning Tennis", CommunityType: "group"})
+	if err != nil {
+		t.Fatalf("create community: %v", err)
+	}
+	communityID := uuid.MustParse(created["id"].(string))
+
+	logo, err := service.UploadLogo(ctx, communityID, pngImage)
+	if err != nil {
+		t.Fatalf("upload logo: %v", err)
+	}
+	banner, err := service.UploadBanner(ctx, communityID, pngImage)
+	if err != nil {
+		t.Fatalf("upload banner: %v", err)
+	}
+
+	uploads := s3.calls(http.MethodPut)
+	if len(uploads) != 2 || !strings.HasSuffix(uploads[0], communityID.String()+"/logo.png") || !strings.HasSuffix(uploads[1], communityID.String()+"/banner.png") {
+		t.Errorf("uploads = %v, want the logo and the banner", uploads)
+	}
+
+	updates := db.called("UpdateCommunity")
+	if len(updates) != 2 || updates[0].Args[5].(pgtype.Text).String != logo || updates[1].Args[6].(pgtype.Text).String != banner {
+		t.Errorf("updates = %+v, want logo_url %s and banner_url %s", updates, logo, banner)
+	}
+
+	var appErr *AppError
+	if _, err := service.UploadLogo(ctx, communityID, []byte("%PDF-1.4")); !errors.As(err, &appErr) || appErr.Code != ErrInvalidFileType.Code {
+		t.Errorf("pdf logo: error = %v, want invalid file type", err)
+	}
+	if uploads := s3.calls(http.MethodPut); len(uploads) != 2 {
+		t.Errorf("uploads = %v, want the pdf rejected before upload", uploads)
+	}
+}
@@ -0,0 +1,696 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/workinghours"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxMapSpan limits the bounding box size (in degrees) accepted by the map endpoint
+const maxMapSpan = 5.0
+
+var validCourtSurfaces = map[string]bool{
+	string(repository.CourtSurfaceHard):      true,
+	string(repository.CourtSurfaceClay):      true,
+	string(repository.CourtSurfaceCarpet):    true,
+	string(repository.CourtSurfaceGrass):     true,
+	string(repository.CourtSurfaceSynthetic): true,
+}
+
+// CourtService handles the courts directory
+type CourtService struct {
+	repo     *repository.Queries
+	location *time.Location
+}
+
+// NewCourtService creates a new CourtService
+func NewCourtService(repo *repository.Queries) *CourtService {
+	return &CourtService{
+		repo:     repo,
+		location: almatyLocation(),
+	}
+}
+
+// ListCourtsInput contains filter parameters for listing courts
+type ListCourtsInput struct {
+	District string
+	Surface  string
+	Indoor   *bool
+	MinPrice *float64
+	MaxPrice *float64
+	Query    string
+	OpenAt   *time.Time
+	SortBy   string
+	Page     int
+	PerPage  int
+}
+
+// List returns a paginated list of approved courts.
+// OpenAt keeps only courts whose working hours include that moment;
+// SortBy "rating" orders by average review score, unrated courts last.
+func (s *CourtService) List(ctx context.Context, input ListCourtsInput) ([]map[string]interface{}, *PaginationInfo, error) {
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PerPage < 1 || input.PerPage > 100 {
+		input.PerPage = 20
+	}
+	if input.Surface != "" && !validCourtSurfaces[input.Surface] {
+		return nil, nil, ErrValidation.WithMessage("Invalid surface")
+	}
+	if input.MinPrice != nil && input.MaxPrice != nil && *input.MinPrice > *input.MaxPrice {
+		return nil, nil, ErrValidation.WithMessage("min_price must not exceed max_price")
+	}
+	if input.SortBy != "" && input.SortBy != "name" && input.SortBy != "rating" {
+		return nil, nil, ErrValidation.WithMessage("sort must be 'name' or 'rating'")
+	}
+
+	offset := (input.Page - 1) * input.PerPage
+
+	countParams := repository.CountCourtsParams{}
+	if input.District != "" {
+		countParams.District = pgtype.Text{String: input.District, Valid: true}
+	}
+	if input.Surface != "" {
+		countParams.Surface = repository.NullCourtSurface{CourtSurface: repository.CourtSurface(input.Surface), Valid: true}
+	}
+	if input.Indoor != nil {
+		countParams.Indoor = pgtype.Bool{Bool: *input.Indoor, Valid: true}
+	}
+	if input.MinPrice != nil {
+		countParams.MinPrice = pgtype.Float8{Float64: *input.MinPrice, Valid: true}
+	}
+	if input.MaxPrice != nil {
+		countParams.MaxPrice = pgtype.Float8{Float64: *input.MaxPrice, Valid: true}
+	}
+	if input.Query != "" {
+		countParams.Query = pgtype.Text{String: input.Query, Valid: true}
+	}
+	if input.OpenAt != nil {
+		countParams.OpenAt = pgtype.Timestamptz{Time: *input.OpenAt, Valid: true}
+	}
+
+	courts, err := s.repo.ListCourts(ctx, repository.ListCourtsParams{
+		District:     countParams.District,
+		Surface:      countParams.Surface,
+		Indoor:       countParams.Indoor,
+		MinPrice:     countParams.MinPrice,
+		MaxPrice:     countParams.MaxPrice,
+		Query:        countParams.Query,
+		OpenAt:       countParams.OpenAt,
+		SortBy:       input.SortBy,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(input.PerPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list courts: %w", err)
+	}
+
+	total, err := s.repo.CountCourts(ctx, countParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count courts: %w", err)
+	}
+
+	ratings, err := s.ratingsByCourt(ctx, courts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(courts))
+	for _, c := range courts {
+		court := buildCourtResponse(c, s.now())
+		court["rating"] = buildCourtRatingResponse(ratings[c.ID.Bytes])
+		result = append(result, court)
+	}
+
+	return result, newPaginationInfo(input.Page, input.PerPage, total), nil
+}
+
+// GetByID returns court details.
+// Courts that are not approved yet are only visible to their creator and superadmins.
+func (s *CourtService) GetByID(ctx context.Context, userID uuid.UUID, isSuperadmin bool, courtID uuid.UUID) (map[string]interface{}, error) {
+	court, err := s.getCourt(ctx, courtID)
+	if err != nil {
+		return nil, err
+	}
+
+	if court.Status.CourtStatus != repository.CourtStatusApproved && !isSuperadmin &&
+		pgtypeUUIDToStringRequired(court.CreatedBy) != userID.String() {
+		return nil, ErrCourtNotFound
+	}
+
+	rating, err := s.repo.GetCourtRating(ctx, court.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get court rating: %w", err)
+	}
+
+	result := buildCourtResponse(court, s.now())
+	result["rating"] = buildCourtRatingResponse(rating)
+	return result, nil
+}
+
+// MapBounds is a lat/lng bounding box
+type MapBounds struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// Map returns approved courts inside the bounding box with a minimal set of fields for map pins
+func (s *CourtService) Map(ctx context.Context, bounds MapBounds) ([]map[string]interface{}, error) {
+	if bounds.MinLat < -90 || bounds.MaxLat > 90 || bounds.MinLng < -180 || bounds.MaxLng > 180 {
+		return nil, ErrValidation.WithMessage("Bounds are out of range")
+	}
+	if bounds.MinLat > bounds.MaxLat || bounds.MinLng > bounds.MaxLng {
+		return nil, ErrValidation.WithMessage("Min bounds must not exceed max bounds")
+	}
+	if bounds.MaxLat-bounds.MinLat > maxMapSpan || bounds.MaxLng-bounds.MinLng > maxMapSpan {
+		return nil, ErrValidation.WithMessage("Bounding box is too large")
+	}
+
+	rows, err := s.repo.ListCourtsInBounds(ctx, repository.ListCourtsInBoundsParams{
+		MinLat: bounds.MinLat,
+		MaxLat: bounds.MaxLat,
+		MinLng: bounds.MinLng,
+		MaxLng: bounds.MaxLng,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list courts in bounds: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, c := range rows {
+		item := map[string]interface{}{
+			"id":     pgtypeUUIDToStringRequired(c.ID),
+			"name":   c.Name,
+			"lat":    numericToFloat(c.Latitude),
+			"lng":    numericToFloat(c.Longitude),
+			"courts": c.TotalCourts.Int16,
+		}
+		if c.Surface.Valid {
+			item["surface"] = string(c.Surface.CourtSurface)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// Nearby returns approved courts within the radius, closest first
+func (s *CourtService) Nearby(ctx context.Context, input NearbyInput) ([]map[string]interface{}, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListCourtsNearby(ctx, repository.ListCourtsNearbyParams{
+		Lat:          input.Lat,
+		Lng:          input.Lng,
+		RadiusMeters: input.radiusMeters(),
+		ResultLimit:  int32(input.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list courts nearby: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, c := range rows {
+		item := map[string]interface{}{
+			"id":             pgtypeUUIDToStringRequired(c.ID),
+			"name":           c.Name,
+			"address":        c.Address,
+			"district":       c.District.String,
+			"latitude":       numericToFloat(c.Latitude),
+			"longitude":      numericToFloat(c.Longitude),
+			"total_courts":   c.TotalCourts.Int16,
+			"price_per_hour": numericToFloat(c.PricePerHour),
+			"currency":       c.Currency.String,
+			"distance_km":    distanceKm(c.DistanceMeters),
+		}
+		if c.Surface.Valid {
+			item["surface"] = string(c.Surface.CourtSurface)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// CreateCourtInput represents input for creating a court
+type CreateCourtInput struct {
+	Name          string          `json:"name"`
+	Address       string          `json:"address"`
+	District      string          `json:"district"`
+	Latitude      *float64        `json:"latitude"`
+	Longitude     *float64        `json:"longitude"`
+	TotalCourts   *int16          `json:"total_courts"`
+	IndoorCourts  *int16          `json:"indoor_courts"`
+	OutdoorCourts *int16          `json:"outdoor_courts"`
+	Surface       string          `json:"surface"`
+	PricePerHour  *float64        `json:"price_per_hour"`
+	Phone         string          `json:"phone"`
+	WorkingHours  json.RawMessage `json:"working_hours"`
+	Photos        json.RawMessage `json:"photos"`
+	CommunityID   string          `json:"community_id"`
+}
+
+// Create adds a court to the directory.
+// Community owners/admins submit courts for moderation; superadmin courts are approved immediately.
+func (s *CourtService) Create(ctx context.Context, userID uuid.UUID, isSuperadmin bool, input CreateCourtInput) (map[string]interface{}, error) {
+	if input.Name == "" || input.Address == "" {
+		return nil, ErrValidation.WithMessage("Name and address are required")
+	}
+	if err := validateCourtFields(input.Latitude, input.Longitude, input.Surface, input.PricePerHour); err != nil {
+		return nil, err
+	}
+	workingHours, err := normalizeWorkingHours(input.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	var communityID pgtype.UUID
+	if input.CommunityID != "" {
+		cID, err := uuid.Parse(input.CommunityID)
+		if err != nil {
+			return nil, ErrValidation.WithMessage("Invalid community_id")
+		}
+		communityID = uuidToPgtype(cID)
+
+		if _, err := s.repo.GetCommunityByID(ctx, communityID); err == pgx.ErrNoRows {
+			return nil, ErrCommunityNotFound
+		} else if err != nil {
+			return nil, fmt.Errorf("get community: %w", err)
+		}
+
+		if !isSuperadmin {
+			if err := s.requireCommunityAdmin(ctx, userID, communityID); err != nil {
+				return nil, err
+			}
+		}
+	} else if !isSuperadmin {
+		return nil, ErrValidation.WithMessage("community_id is required")
+	}
+
+	status := repository.CourtStatusPending
+	if isSuperadmin {
+		status = repository.CourtStatusApproved
+	}
+
+	params := repository.CreateCourtParams{
+		Name:         input.Name,
+		Address:      input.Address,
+		District:     pgtype.Text{String: input.District, Valid: input.District != ""},
+		Phone:        pgtype.Text{String: input.Phone, Valid: input.Phone != ""},
+		WorkingHours: workingHours,
+		Photos:       input.Photos,
+		CommunityID:  communityID,
+		Status:       repository.NullCourtStatus{CourtStatus: status, Valid: true},
+		CreatedBy:    uuidToPgtype(userID),
+	}
+	if input.Latitude != nil {
+		params.Latitude = coordinateToNumeric(*input.Latitude)
+	}
+	if input.Longitude != nil {
+		params.Longitude = coordinateToNumeric(*input.Longitude)
+	}
+	if input.TotalCourts != nil {
+		params.TotalCourts = pgtype.Int2{Int16: *input.TotalCourts, Valid: true}
+	}
+	if input.IndoorCourts != nil {
+		params.IndoorCourts = pgtype.Int2{Int16: *input.IndoorCourts, Valid: true}
+	}
+	if input.OutdoorCourts != nil {
+		params.OutdoorCourts = pgtype.Int2{Int16: *input.OutdoorCourts, Valid: true}
+	}
+	if input.Surface != "" {
+		params.Surface = repository.NullCourtSurface{CourtSurface: repository.CourtSurface(input.Surface), Valid: true}
+	}
+	if input.PricePerHour != nil {
+		params.PricePerHour = floatToNumeric(*input.PricePerHour)
+	}
+	if params.Photos == nil {
+		params.Photos = []byte("[]")
+	}
+
+	court, err := s.repo.CreateCourt(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create court: %w", err)
+	}
+
+	return buildCourtResponse(court, s.now()), nil
+}
+
+// UpdateCourtInput represents a partial court update (superadmin only)
+type UpdateCourtInput struct {
+	Name          *string         `json:"name"`
+	Address       *string         `json:"address"`
+	District      *string         `json:"district"`
+	Latitude      *float64        `json:"latitude"`
+	Longitude     *float64        `json:"longitude"`
+	TotalCourts   *int16          `json:"total_courts"`
+	IndoorCourts  *int16          `json:"indoor_courts"`
+	OutdoorCourts *int16          `json:"outdoor_courts"`
+	Surface       *string         `json:"surface"`
+	PricePerHour  *float64        `json:"price_per_hour"`
+	Phone         *string         `json:"phone"`
+	WorkingHours  json.RawMessage `json:"working_hours"`
+	Photos        json.RawMessage `json:"photos"`
+}
+
+// Update changes court details
+func (s *CourtService) Update(ctx context.Context, courtID uuid.UUID, input UpdateCourtInput) (map[string]interface{}, error) {
+	surface := ""
+	if input.Surface != nil {
+		surface = *input.Surface
+	}
+	if err := validateCourtFields(input.Latitude, input.Longitude, surface, input.PricePerHour); err != nil {
+		return nil, err
+	}
+	workingHours, err := normalizeWorkingHours(input.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.UpdateCourtParams{
+		ID:           uuidToPgtype(courtID),
+		WorkingHours: workingHours,
+		Photos:       input.Photos,
+	}
+	if input.Name != nil {
+		params.Name = pgtype.Text{String: *input.Name, Valid: true}
+	}
+	if input.Address != nil {
+		params.Address = pgtype.Text{String: *input.Address, Valid: true}
+	}
+	if input.District != nil {
+		params.District = pgtype.Text{String: *input.District, Valid: true}
+	}
+	if input.Latitude != nil {
+		params.Latitude = coordinateToNumeric(*input.Latitude)
+	}
+	if input.Longitude != nil {
+		params.Longitude = coordinateToNumeric(*input.Longitude)
+	}
+	if input.TotalCourts != nil {
+		params.TotalCourts = pgtype.Int2{Int16: *input.TotalCourts, Valid: true}
+	}
+	if input.IndoorCourts != nil {
+		params.IndoorCourts = pgtype.Int2{Int16: *input.IndoorCourts, Valid: true}
+	}
+	if input.OutdoorCourts != nil {
+		params.OutdoorCourts = pgtype.Int2{Int16: *input.OutdoorCourts, Valid: true}
+	}
+	if surface != "" {
+		params.Surface = repository.NullCourtSurface{CourtSurface: repository.CourtSurface(surface), Valid: true}
+	}
+	if input.PricePerHour != nil {
+		params.PricePerHour = floatToNumeric(*input.PricePerHour)
+	}
+	if input.Phone != nil {
+		params.Phone = pgtype.Text{String: *input.Phone, Valid: true}
+	}
+
+	court, err := s.repo.UpdateCourt(ctx, params)
+	if err == pgx.ErrNoRows {
+		return nil, ErrCourtNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update court: %w", err)
+	}
+
+	return buildCourtResponse(court, s.now()), nil
+}
+
+// Deactivate hides a court from the directory
+func (s *CourtService) Deactivate(ctx context.Context, courtID uuid.UUID) error {
+	if _, err := s.getCourt(ctx, courtID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeactivateCourt(ctx, uuidToPgtype(courtID)); err != nil {
+		return fmt.Errorf("deactivate court: %w", err)
+	}
+	return nil
+}
+
+// ListForModeration returns courts with the given moderation status (pending by default)
+func (s *CourtService) ListForModeration(ctx context.Context, status string, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if status == "" {
+		status = string(repository.CourtStatusPending)
+	}
+
+	courtStatus := repository.CourtStatus(status)
+	switch courtStatus {
+	case repository.CourtStatusPending, repository.CourtStatusApproved, repository.CourtStatusRejected:
+	default:
+		return nil, nil, ErrValidation.WithMessage("Invalid status")
+	}
+	nullStatus := repository.NullCourtStatus{CourtStatus: courtStatus, Valid: true}
+
+	offset := (page - 1) * perPage
+
+	courts, err := s.repo.ListCourtsByStatus(ctx, repository.ListCourtsByStatusParams{
+		Status:       nullStatus,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list courts by status: %w", err)
+	}
+
+	total, err := s.repo.CountCourtsByStatus(ctx, nullStatus)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count courts by status: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(courts))
+	for _, c := range courts {
+		result = append(result, buildCourtResponse(c, s.now()))
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Moderate approves or rejects a submitted court
+func (s *CourtService) Moderate(ctx context.Context, adminID, courtID uuid.UUID, action, note string) (map[string]interface{}, error) {
+	var status repository.CourtStatus
+	switch action {
+	case "approve":
+		status = repository.CourtStatusApproved
+	case "reject":
+		status = repository.CourtStatusRejected
+		if note == "" {
+			return nil, ErrValidation.WithMessage("A note is required when rejecting a court")
+		}
+	default:
+		return nil, ErrValidation.WithMessage("Action must be 'approve' or 'reject'")
+	}
+
+	court, err := s.repo.ModerateCourt(ctx, repository.ModerateCourtParams{
+		Status:         repository.NullCourtStatus{CourtStatus: status, Valid: true},
+		ModerationNote: pgtype.Text{String: note, Valid: note != ""},
+		ModeratedBy:    uuidToPgtype(adminID),
+		ID:             uuidToPgtype(courtID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrCourtNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("moderate court: %w", err)
+	}
+
+	return buildCourtResponse(court, s.now()), nil
+}
+
+// ratingsByCourt loads review aggregates for a page of courts, keyed by court ID
+func (s *CourtService) ratingsByCourt(ctx context.Context, courts []repository.Court) (map[[16]byte]repository.CourtRating, error) {
+	ids := make([]pgtype.UUID, 0, len(courts))
+	for _, c := range courts {
+		ids = append(ids, c.ID)
+	}
+
+	ratings, err := s.repo.ListCourtRatings(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list court ratings: %w", err)
+	}
+
+	byCourt := make(map[[16]byte]repository.CourtRating, len(ratings))
+	for _, r := range ratings {
+		byCourt[r.CourtID.Bytes] = r
+	}
+	return byCourt, nil
+}
+
+func (s *CourtService) getCourt(ctx context.Context, courtID uuid.UUID) (repository.Court, error) {
+	court, err := s.repo.GetCourtByID(ctx, uuidToPgtype(courtID))
+	if err == pgx.ErrNoRows {
+		return repository.Court{}, ErrCourtNotFound
+	}
+	if err != nil {
+		return repository.Court{}, fmt.Errorf("get court: %w", err)
+	}
+	return court, nil
+}
+
+// requireCommunityAdmin checks that the user is an active owner/admin of the community
+func (s *CourtService) requireCommunityAdmin(ctx context.Context, userID uuid.UUID, communityID pgtype.UUID) error {
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: communityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotCommunityMember
+	}
+	if err != nil {
+		return fmt.Errorf("get community member: %w", err)
+	}
+	if member.Status.MemberStatus != repository.MemberStatusActive {
+		return ErrNotCommunityMember
+	}
+	if member.Role.CommunityRole != repository.CommunityRoleOwner && member.Role.CommunityRole != repository.CommunityRoleAdmin {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+func (s *CourtService) now() time.Time {
+	return time.Now().In(s.location)
+}
+
+// normalizeWorkingHours validates working_hours on write and re-encodes it in canonical form
+func normalizeWorkingHours(raw json.RawMessage) ([]byte, error) {
+	schedule, err := workinghours.Parse(raw)
+	if err != nil {
+		return nil, ErrValidation.WithMessage(err.Error())
+	}
+	if schedule == nil {
+		return nil, nil
+	}
+	return json.Marshal(schedule)
+}
+
+// courtHoursWarning explains why [start, end) falls outside the court's opening hours.
+// It returns "" when the hours are unknown or cover the whole range.
+func courtHoursWarning(c repository.Court, start, end time.Time, loc *time.Location) string {
+	schedule, err := workinghours.Parse(c.WorkingHours)
+	if err != nil || schedule == nil {
+		return ""
+	}
+	if schedule.Covers(start.In(loc), end.In(loc)) {
+		return ""
+	}
+	return fmt.Sprintf("The event time is outside of the opening hours of %s", c.Name)
+}
+
+func validateCourtFields(lat, lng *float64, surface string, price *float64) error {
+	if lat != nil && (*lat < -90 || *lat > 90) {
+		return ErrValidation.WithMessage("Latitude must be between -90 and 90")
+	}
+	if lng != nil && (*lng < -180 || *lng > 180) {
+		return ErrValidation.WithMessage("Longitude must be between -180 and 180")
+	}
+	if surface != "" && !validCourtSurfaces[surface] {
+		return ErrValidation.WithMessage("Invalid surface")
+	}
+	if price != nil && *price < 0 {
+		return ErrValidation.WithMessage("price_per_hour must not be negative")
+	}
+	return nil
+}
+
+// buildCourtRatingResponse renders review aggregates; averages are nil while a court has no reviews
+func buildCourtRatingResponse(r repository.CourtRating) map[string]interface{} {
+	result := map[string]interface{}{
+		"review_count":    r.ReviewCount,
+		"average":         nil,
+		"surface_quality": nil,
+		"lighting":        nil,
+		"changing_rooms":  nil,
+		"price_value":     nil,
+	}
+	if r.AvgRating.Valid {
+		result["average"] = numericToFloat(r.AvgRating)
+	}
+	if r.AvgSurface.Valid {
+		result["surface_quality"] = numericToFloat(r.AvgSurface)
+	}
+	if r.AvgLighting.Valid {
+		result["lighting"] = numericToFloat(r.AvgLighting)
+	}
+	if r.AvgChangingRooms.Valid {
+		result["changing_rooms"] = numericToFloat(r.AvgChangingRooms)
+	}
+	if r.AvgValue.Valid {
+		result["price_value"] = numericToFloat(r.AvgValue)
+	}
+	return result
+}
+
+// coordinateToNumeric keeps the 7 decimal places stored in courts.latitude/longitude
+func coordinateToNumeric(f float64) pgtype.Numeric {
+	var n pgtype.Numeric
+	n.Scan(fmt.Sprintf("%.7f", f))
+	return n
+}
+
+// buildCourtResponse renders a court; now (in the court's time zone) is used for is_open_now
+func buildCourtResponse(c repository.Court, now time.Time) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":             pgtypeUUIDToStringRequired(c.ID),
+		"name":           c.Name,
+		"address":        c.Address,
+		"district":       c.District.String,
+		"latitude":       numericToFloat(c.Latitude),
+		"longitude":      numericToFloat(c.Longitude),
+		"total_courts":   c.TotalCourts.Int16,
+		"indoor_courts":  c.IndoorCourts.Int16,
+		"outdoor_courts": c.OutdoorCourts.Int16,
+		"price_per_hour": numericToFloat(c.PricePerHour),
+		"currency":       c.Currency.String,
+		"phone":          c.Phone.String,
+		"status":         string(c.Status.CourtStatus),
+		"created_at":     c.CreatedAt.Time,
+	}
+
+	if c.Surface.Valid {
+		result["surface"] = string(c.Surface.CourtSurface)
+	}
+	if len(c.WorkingHours) > 0 {
+		result["working_hours"] = json.RawMessage(c.WorkingHours)
+		if schedule, err := workinghours.Parse(c.WorkingHours); err == nil && schedule != nil {
+			result["is_open_now"] = schedule.IsOpenAt(now)
+		}
+	}
+	if len(c.Photos) > 0 {
+		result["photos"] = json.RawMessage(c.Photos)
+	} else {
+		result["photos"] = json.RawMessage("[]")
+	}
+	if c.CommunityID.Valid {
+		result["community_id"] = pgtypeUUIDToStringRequired(c.CommunityID)
+	}
+	if c.CreatedBy.Valid {
+		result["created_by"] = pgtypeUUIDToStringRequired(c.CreatedBy)
+	}
+	if c.ModerationNote.Valid {
+		result["moderation_note"] = c.ModerationNote.String
+	}
+	if c.ModeratedAt.Valid {
+		result["moderated_at"] = c.ModeratedAt.Time
+	}
+
+	return result
+}
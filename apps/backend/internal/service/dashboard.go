@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// dashboardGrowthMonths is how many months of member growth the dashboard shows
+	dashboardGrowthMonths = 12
+	// dashboardActiveWindow is how recently a member must have played or attended to count as active
+	dashboardActiveWindow = 30 * 24 * time.Hour
+	// dashboardRatingBucket is the width of a rating histogram bucket
+	dashboardRatingBucket = 100
+	dashboardTopPlayers   = 5
+)
+
+// CommunityDashboard is the aggregate view shown in the web admin
+type CommunityDashboard struct {
+	CommunityID  string              `json:"community_id"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	MemberGrowth []MemberGrowthPoint `json:"member_growth"`
+	Members      DashboardMembers    `json:"members"`
+	Events       DashboardEvents     `json:"events"`
+	Matches      DashboardMatches    `json:"matches"`
+	Rating       DashboardRating     `json:"rating"`
+	TopPlayers   []LeaderboardEntry  `json:"top_players"`
+	Attendance   DashboardAttendance `json:"attendance"`
+}
+
+// MemberGrowthPoint is one month of member growth. Members counts current
+// members who had joined by the end of the month.
+type MemberGrowthPoint struct {
+	Month   string `json:"month"`
+	Joined  int    `json:"joined"`
+	Members int    `json:"members"`
+}
+
+// DashboardMembers splits active members by recent activity
+type DashboardMembers struct {
+	Total           int `json:"total"`
+	Active          int `json:"active"`
+	Inactive        int `json:"inactive"`
+	PendingRequests int `json:"pending_requests"`
+}
+
+// DashboardEvents counts community events
+type DashboardEvents struct {
+	Held      int `json:"held"`
+	Upcoming  int `json:"upcoming"`
+	Cancelled int `json:"cancelled"`
+}
+
+// DashboardMatches counts community matches by result status
+type DashboardMatches struct {
+	Played   int `json:"played"`
+	Disputed int `json:"disputed"`
+	Pending  int `json:"pending"`
+}
+
+// DashboardRating summarises community ratings of active members
+type DashboardRating struct {
+	Average      float64                 `json:"average"`
+	BucketSize   int                     `json:"bucket_size"`
+	Distribution []RatingHistogramBucket `json:"distribution"`
+}
+
+// RatingHistogramBucket counts members with a rating in [From, From+BucketSize)
+type RatingHistogramBucket struct {
+	From    int `json:"from"`
+	Members int `json:"members"`
+}
+
+// DashboardAttendance covers participants of past, non-cancelled events.
+// Rates are percentages of participants whose attendance was marked.
+type DashboardAttendance struct {
+	Attended       int     `json:"attended"`
+	NoShows        int     `json:"no_shows"`
+	Unmarked       int     `json:"unmarked"`
+	AttendanceRate float64 `json:"attendance_rate"`
+	NoShowRate     float64 `json:"no_show_rate"`
+}
+
+// DashboardService builds community admin dashboards. Results are cached in
+// Redis for cacheTTL, so figures may lag behind by up to that long.
+type DashboardService struct {
+	repo     *repository.Queries
+	ratings  *RatingService
+	redis    *redis.Client
+	cacheTTL time.Duration
+	location *time.Location
+}
+
+// NewDashboardService creates a new DashboardService
+func NewDashboardService(repo *repository.Queries, ratings *RatingService, redis *redis.Client, cacheTTL time.Duration) *DashboardService {
+	return &DashboardService{
+		repo:     repo,
+		ratings:  ratings,
+		redis:    redis,
+		cacheTTL: cacheTTL,
+		location: almatyLocation(),
+	}
+}
+
+// GetCommunityDashboard returns the dashboard for a community, from cache when possible
+func (s *DashboardService) GetCommunityDashboard(ctx context.Context, communityID uuid.UUID) (*CommunityDashboard, error) {
+	key := fmt.Sprintf("dashboard:community:%s", communityID)
+
+	if s.cacheEnabled() {
+		data, err := s.redis.Get(ctx, key).Bytes()
+		if err == nil {
+			var cached CommunityDashboard
+			if json.Unmarshal(data, &cached) == nil {
+				return &cached, nil
+			}
+		} else if err != redis.Nil {
+			slog.Warn("failed to read dashboard cache", "community_id", communityID, "error", err)
+		}
+	}
+
+	dashboard, err := s.build(ctx, communityID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cacheEnabled() {
+		data, _ := json.Marshal(dashboard)
+		if err := s.redis.Set(ctx, key, data, s.cacheTTL).Err(); err != nil {
+			slog.Warn("failed to cache dashboard", "community_id", communityID, "error", err)
+		}
+	}
+
+	return dashboard, nil
+}
+
+func (s *DashboardService) cacheEnabled() bool {
+	return s.redis != nil && s.cacheTTL > 0
+}
+
+func (s *DashboardService) build(ctx context.Context, communityID uuid.UUID, now time.Time) (*CommunityDashboard, error) {
+	_, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	cid := uuidToPgtype(communityID)
+	local := now.In(s.location)
+	firstMonth := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.location).AddDate(0, -(dashboardGrowthMonths - 1), 0)
+	since := pgtype.Timestamptz{Time: firstMonth, Valid: true}
+	pgNow := pgtype.Timestamptz{Time: now, Valid: true}
+
+	summary, err := s.repo.GetCommunityMemberSummary(ctx, repository.GetCommunityMemberSummaryParams{
+		Since:       since,
+		ActiveSince: pgtype.Timestamptz{Time: now.Add(-dashboardActiveWindow), Valid: true},
+		CommunityID: cid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get member summary: %w", err)
+	}
+
+	growth, err := s.repo.GetCommunityMemberGrowth(ctx, repository.GetCommunityMemberGrowthParams{
+		CommunityID: cid,
+		Since:       since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get member growth: %w", err)
+	}
+
+	events, err := s.repo.GetCommunityEventSummary(ctx, repository.GetCommunityEventSummaryParams{
+		Now:         pgNow,
+		CommunityID: cid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get event summary: %w", err)
+	}
+
+	matches, err := s.repo.GetCommunityMatchSummary(ctx, cid)
+	if err != nil {
+		return nil, fmt.Errorf("get match summary: %w", err)
+	}
+
+	distribution, err := s.repo.GetCommunityRatingDistribution(ctx, repository.GetCommunityRatingDistributionParams{
+		BucketSize:  dashboardRatingBucket,
+		CommunityID: cid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get rating distribution: %w", err)
+	}
+
+	attendance, err := s.repo.GetCommunityAttendance(ctx, repository.GetCommunityAttendanceParams{
+		CommunityID: cid,
+		Now:         pgNow,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get attendance: %w", err)
+	}
+
+	topPlayers, err := s.ratings.GetCommunityLeaderboard(ctx, communityID, ListLeaderboardInput{
+		MinGames: 1,
+		Page:     1,
+		PerPage:  dashboardTopPlayers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommunityDashboard{
+		CommunityID:  communityID.String(),
+		GeneratedAt:  now,
+		MemberGrowth: memberGrowth(growth, firstMonth, dashboardGrowthMonths, int(summary.ActiveBefore)),
+		Members: DashboardMembers{
+			Total:           int(summary.ActiveMembers),
+			Active:          int(summary.RecentlyActive),
+			Inactive:        int(summary.ActiveMembers - summary.RecentlyActive),
+			PendingRequests: int(summary.PendingRequests),
+		},
+		Events: DashboardEvents{
+			Held:      int(events.Held),
+			Upcoming:  int(events.Upcoming),
+			Cancelled: int(events.Cancelled),
+		},
+		Matches: DashboardMatches{
+			Played:   int(matches.Played),
+			Disputed: int(matches.Disputed),
+			Pending:  int(matches.Pending),
+		},
+		Rating: DashboardRating{
+			Average:      math.Round(summary.AverageRating*10) / 10,
+			BucketSize:   dashboardRatingBucket,
+			Distribution: ratingHistogram(distribution, dashboardRatingBucket),
+		},
+		TopPlayers: topPlayers,
+		Attendance: DashboardAttendance{
+			Attended:       int(attendance.Attended),
+			NoShows:        int(attendance.NoShows),
+			Unmarked:       int(attendance.Unmarked),
+			AttendanceRate: percentage(attendance.Attended, attendance.Attended+attendance.NoShows),
+			NoShowRate:     percentage(attendance.NoShows, attendance.Attended+attendance.NoShows),
+		},
+	}, nil
+}
+
+// memberGrowth lays the monthly join counts over a continuous range of months,
+// starting from the members who joined before the first month
+func memberGrowth(rows []repository.GetCommunityMemberGrowthRow, firstMonth time.Time, months, base int) []MemberGrowthPoint {
+	byMonth := make(map[string]repository.GetCommunityMemberGrowthRow, len(rows))
+	for _, r := range rows {
+		byMonth[r.Month.Time.Format("2006-01")] = r
+	}
+
+	points := make([]MemberGrowthPoint, 0, months)
+	members := base
+	for i := 0; i < months; i++ {
+		month := firstMonth.AddDate(0, i, 0).Format("2006-01")
+		r := byMonth[month]
+		members += int(r.StillActive)
+		points = append(points, MemberGrowthPoint{
+			Month:   month,
+			Joined:  int(r.Joined),
+			Members: members,
+		})
+	}
+	return points
+}
+
+// ratingHistogram fills the gaps between the lowest and highest bucket with zeros
+func ratingHistogram(rows []repository.GetCommunityRatingDistributionRow, bucketSize int) []RatingHistogramBucket {
+	if len(rows) == 0 {
+		return []RatingHistogramBucket{}
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, r := range rows {
+		counts[int(r.BucketStart)] = int(r.Members)
+	}
+
+	lowest, highest := int(rows[0].BucketStart), int(rows[len(rows)-1].BucketStart)
+	buckets := make([]RatingHistogramBucket, 0, (highest-lowest)/bucketSize+1)
+	for from := lowest; from <= highest; from += bucketSize {
+		buckets = append(buckets, RatingHistogramBucket{From: from, Members: counts[from]})
+	}
+	return buckets
+}
+
+// percentage returns part/total as a percentage with one decimal, or 0 when total is 0
+func percentage(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestMemberGrowth(t *testing.T) {
+	first := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	rows := []repository.GetCommunityMemberGrowthRow{
+		{Month: pgtype.Date{Time: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), Valid: true}, Joined: 3, StillActive: 2},
+		{Month: pgtype.Date{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}, Joined: 1, StillActive: 1},
+	}
+
+	points := memberGrowth(rows, first, 3, 10)
+	want := []MemberGrowthPoint{
+		{Month: "2025-11", Joined: 3, Members: 12},
+		{Month: "2025-12", Joined: 0, Members: 12},
+		{Month: "2026-01", Joined: 1, Members: 13},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i := range want {
+		if points[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, points[i], want[i])
+		}
+	}
+}
+
+func TestRatingHistogram(t *testing.T) {
+	if got := ratingHistogram(nil, 100); len(got) != 0 {
+		t.Errorf("expected empty histogram, got %v", got)
+	}
+
+	rows := []repository.GetCommunityRatingDistributionRow{
+		{BucketStart: 900, Members: 2},
+		{BucketStart: 1200, Members: 1},
+	}
+	got := ratingHistogram(rows, 100)
+	want := []RatingHistogramBucket{{900, 2}, {1000, 0}, {1100, 0}, {1200, 1}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("bucket %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPercentage(t *testing.T) {
+	if got := percentage(1, 0); got != 0 {
+		t.Errorf("percentage(1, 0) = %v, want 0", got)
+	}
+	if got := percentage(1, 3); got != 33.3 {
+		t.Errorf("percentage(1, 3) = %v, want 33.3", got)
+	}
+}
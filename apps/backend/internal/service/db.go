@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// txStarter begins transactions. Services that run several statements
+// atomically take one; *pgxpool.Pool is the production implementation.
+type txStarter interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
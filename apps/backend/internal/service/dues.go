@@ -0,0 +1,685 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/payments"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	maxPlanNameLength   = 100
+	maxSeasonMonths     = 12
+	defaultDuesCurrency = "KZT"
+	dateLayout          = "2006-01-02"
+)
+
+// DuesService handles membership plans of paid communities and the dues ledger.
+// A member of a paid community stays active while their latest paid period lasts.
+type DuesService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	provider      payments.Provider
+	notifications *NotificationService
+	news          *CommunityNews
+	location      *time.Location
+}
+
+// NewDuesService creates a new DuesService
+func NewDuesService(repo *repository.Queries, pool txStarter, provider payments.Provider, notifications *NotificationService, news *CommunityNews) *DuesService {
+	return &DuesService{
+		repo:          repo,
+		pool:          pool,
+		provider:      provider,
+		notifications: notifications,
+		news:          news,
+		location:      almatyLocation(),
+	}
+}
+
+// CreatePlanInput represents a new membership plan.
+// Monthly plans always last one month; seasonal plans last DurationMonths.
+type CreatePlanInput struct {
+	Name           string  `json:"name"`
+	Period         string  `json:"period"`
+	DurationMonths int16   `json:"duration_months"`
+	PriceAmount    float64 `json:"price_amount"`
+	PriceCurrency  string  `json:"price_currency"`
+}
+
+// UpdatePlanInput represents a partial plan update.
+// Duration and period are fixed once members have paid for them.
+type UpdatePlanInput struct {
+	Name        *string  `json:"name"`
+	PriceAmount *float64 `json:"price_amount"`
+	IsActive    *bool    `json:"is_active"`
+}
+
+// RecordPaymentInput represents a payment an admin received outside the app
+type RecordPaymentInput struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PlanID    uuid.UUID `json:"plan_id"`
+	Method    string    `json:"method"`
+	Reference string    `json:"reference"`
+	Amount    *float64  `json:"amount"`
+}
+
+// ListPlans returns the active plans of a community, shortest and cheapest first
+func (s *DuesService) ListPlans(ctx context.Context, communityID uuid.UUID) ([]map[string]interface{}, error) {
+	plans, err := s.repo.ListMembershipPlans(ctx, repository.ListMembershipPlansParams{
+		CommunityID:     uuidToPgtype(communityID),
+		IncludeInactive: false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list membership plans: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(plans))
+	for _, p := range plans {
+		result = append(result, buildPlanResponse(p))
+	}
+	return result, nil
+}
+
+// CreatePlan adds a membership plan to a paid community
+func (s *DuesService) CreatePlan(ctx context.Context, communityID uuid.UUID, input CreatePlanInput) (map[string]interface{}, error) {
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+	if community.AccessLevel.CommunityAccess != repository.CommunityAccessPaid {
+		return nil, ErrValidation.WithMessage("Membership plans are only available for paid communities")
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || utf8.RuneCountInString(input.Name) > maxPlanNameLength {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("Name is required and must be at most %d characters", maxPlanNameLength))
+	}
+
+	period := repository.MembershipPeriod(input.Period)
+	switch period {
+	case repository.MembershipPeriodMonthly:
+		input.DurationMonths = 1
+	case repository.MembershipPeriodSeasonal:
+		if input.DurationMonths < 2 || input.DurationMonths > maxSeasonMonths {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("Seasonal plans must last 2-%d months", maxSeasonMonths))
+		}
+	default:
+		return nil, ErrValidation.WithMessage("Period must be 'monthly' or 'seasonal'")
+	}
+
+	if input.PriceAmount <= 0 {
+		return nil, ErrValidation.WithMessage("Price must be positive")
+	}
+	if input.PriceCurrency == "" {
+		input.PriceCurrency = defaultDuesCurrency
+	}
+	if len(input.PriceCurrency) != 3 {
+		return nil, ErrValidation.WithMessage("Currency must be a 3-letter code")
+	}
+
+	plan, err := s.repo.CreateMembershipPlan(ctx, repository.CreateMembershipPlanParams{
+		CommunityID:    community.ID,
+		Name:           input.Name,
+		Period:         period,
+		DurationMonths: input.DurationMonths,
+		PriceAmount:    floatToNumeric(input.PriceAmount),
+		PriceCurrency:  strings.ToUpper(input.PriceCurrency),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create membership plan: %w", err)
+	}
+
+	return buildPlanResponse(plan), nil
+}
+
+// UpdatePlan renames, reprices or (de)activates a plan.
+// Price changes only apply to payments recorded afterwards.
+func (s *DuesService) UpdatePlan(ctx context.Context, communityID, planID uuid.UUID, input UpdatePlanInput) (map[string]interface{}, error) {
+	params := repository.UpdateMembershipPlanParams{
+		ID:          uuidToPgtype(planID),
+		CommunityID: uuidToPgtype(communityID),
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxPlanNameLength {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("Name is required and must be at most %d characters", maxPlanNameLength))
+		}
+		params.Name = pgtype.Text{String: name, Valid: true}
+	}
+	if input.PriceAmount != nil {
+		if *input.PriceAmount <= 0 {
+			return nil, ErrValidation.WithMessage("Price must be positive")
+		}
+		params.PriceAmount = floatToNumeric(*input.PriceAmount)
+	}
+	if input.IsActive != nil {
+		params.IsActive = pgtype.Bool{Bool: *input.IsActive, Valid: true}
+	}
+
+	plan, err := s.repo.UpdateMembershipPlan(ctx, params)
+	if err == pgx.ErrNoRows {
+		return nil, ErrPlanNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update membership plan: %w", err)
+	}
+
+	return buildPlanResponse(plan), nil
+}
+
+// RecordPayment records a cash or Kaspi transfer payment received by an admin.
+// The payment extends the member's paid period and (re)activates the membership.
+func (s *DuesService) RecordPayment(ctx context.Context, adminID, communityID uuid.UUID, input RecordPaymentInput) (map[string]interface{}, error) {
+	method := repository.PaymentMethod(input.Method)
+	switch method {
+	case repository.PaymentMethodCash:
+	case repository.PaymentMethodKaspiTransfer:
+		if strings.TrimSpace(input.Reference) == "" {
+			return nil, ErrValidation.WithMessage("A transfer reference is required for Kaspi payments")
+		}
+	default:
+		return nil, ErrValidation.WithMessage("Method must be 'cash' or 'kaspi_transfer'")
+	}
+	if input.Amount != nil && *input.Amount <= 0 {
+		return nil, ErrValidation.WithMessage("Amount must be positive")
+	}
+
+	plan, err := s.getPlan(ctx, communityID, input.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPayer(ctx, communityID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	amount := plan.PriceAmount
+	if input.Amount != nil {
+		amount = floatToNumeric(*input.Amount)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	if err := lockMemberDues(ctx, qtx, plan.CommunityID, uuidToPgtype(input.UserID)); err != nil {
+		return nil, err
+	}
+	start, end, err := s.nextPeriod(ctx, qtx, plan.CommunityID, uuidToPgtype(input.UserID), plan.DurationMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	dues, err := qtx.CreateMembershipDues(ctx, repository.CreateMembershipDuesParams{
+		CommunityID:      plan.CommunityID,
+		UserID:           uuidToPgtype(input.UserID),
+		PlanID:           plan.ID,
+		Amount:           amount,
+		Currency:         plan.PriceCurrency,
+		PeriodStart:      pgtype.Date{Time: start, Valid: true},
+		PeriodEnd:        pgtype.Date{Time: end, Valid: true},
+		Status:           repository.DuesStatusPaid,
+		PaymentMethod:    method,
+		PaymentReference: pgtype.Text{String: strings.TrimSpace(input.Reference), Valid: strings.TrimSpace(input.Reference) != ""},
+		RecordedBy:       uuidToPgtype(adminID),
+		PaidAt:           pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create membership dues: %w", err)
+	}
+
+	activated, err := activatePaidMember(ctx, qtx, plan.CommunityID, dues.UserID, uuidToPgtype(adminID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if activated {
+		s.news.Subscribe(ctx, input.UserID, communityID)
+	}
+
+	s.notifyPaid(ctx, input.UserID, dues)
+
+	return buildDuesResponse(dues), nil
+}
+
+// StartPayment creates a pending dues entry and an online payment for it.
+// The member completes the payment on the provider's checkout page; the
+// membership is extended when the provider's callback confirms it.
+func (s *DuesService) StartPayment(ctx context.Context, userID, communityID, planID uuid.UUID) (map[string]interface{}, error) {
+	if s.provider == nil {
+		return nil, ErrValidation.WithMessage("Online payments are not available")
+	}
+
+	plan, err := s.getPlan(ctx, communityID, planID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPayer(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	start, end, err := s.nextPeriod(ctx, s.repo, plan.CommunityID, uuidToPgtype(userID), plan.DurationMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.provider.CreatePayment(ctx, payments.Request{
+		Reference:   fmt.Sprintf("%s/%s", communityID, userID),
+		Amount:      numericToFloat(plan.PriceAmount),
+		Currency:    plan.PriceCurrency,
+		Description: plan.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create provider payment: %w", err)
+	}
+
+	dues, err := s.repo.CreateMembershipDues(ctx, repository.CreateMembershipDuesParams{
+		CommunityID:       plan.CommunityID,
+		UserID:            uuidToPgtype(userID),
+		PlanID:            plan.ID,
+		Amount:            plan.PriceAmount,
+		Currency:          plan.PriceCurrency,
+		PeriodStart:       pgtype.Date{Time: start, Valid: true},
+		PeriodEnd:         pgtype.Date{Time: end, Valid: true},
+		Status:            repository.DuesStatusPending,
+		PaymentMethod:     repository.PaymentMethodOnline,
+		Provider:          pgtype.Text{String: s.provider.Name(), Valid: true},
+		ProviderPaymentID: pgtype.Text{String: payment.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create membership dues: %w", err)
+	}
+
+	result := buildDuesResponse(dues)
+	result["checkout_url"] = payment.CheckoutURL
+	return result, nil
+}
+
+// HandleCallback settles (or cancels) the dues entry of an online payment.
+// Repeated callbacks for an already settled payment are ignored.
+func (s *DuesService) HandleCallback(ctx context.Context, providerName string, r *http.Request) error {
+	if s.provider == nil || providerName != s.provider.Name() {
+		return ErrNotFound.WithMessage("Unknown payment provider")
+	}
+
+	callback, err := s.provider.ParseCallback(r)
+	if errors.Is(err, payments.ErrInvalidSignature) {
+		return ErrUnauthorized.WithMessage("Invalid callback signature")
+	}
+	if err != nil {
+		return ErrValidation.WithMessage("Invalid callback payload")
+	}
+
+	dues, err := s.repo.GetMembershipDuesByProviderPayment(ctx, repository.GetMembershipDuesByProviderPaymentParams{
+		Provider:          pgtype.Text{String: providerName, Valid: true},
+		ProviderPaymentID: pgtype.Text{String: callback.PaymentID, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound.WithMessage("Payment not found")
+	}
+	if err != nil {
+		return fmt.Errorf("get membership dues: %w", err)
+	}
+	if dues.Status != repository.DuesStatusPending {
+		return nil
+	}
+
+	if callback.Status == payments.StatusFailed {
+		if _, err := s.repo.CancelMembershipDues(ctx, dues.ID); err != nil {
+			return fmt.Errorf("cancel membership dues: %w", err)
+		}
+		return nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	if err := lockMemberDues(ctx, qtx, dues.CommunityID, dues.UserID); err != nil {
+		return err
+	}
+
+	// The period is recomputed: the member may have paid by other means
+	// (or the membership may have lapsed) since the payment was started.
+	start, end := dues.PeriodStart.Time, dues.PeriodEnd.Time
+	plan, err := qtx.GetMembershipPlan(ctx, repository.GetMembershipPlanParams{
+		ID:          dues.PlanID,
+		CommunityID: dues.CommunityID,
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		return fmt.Errorf("get membership plan: %w", err)
+	}
+	if err == nil {
+		start, end, err = s.nextPeriod(ctx, qtx, dues.CommunityID, dues.UserID, plan.DurationMonths)
+		if err != nil {
+			return err
+		}
+	}
+
+	settled, err := qtx.SettleMembershipDues(ctx, repository.SettleMembershipDuesParams{
+		PeriodStart: pgtype.Date{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Date{Time: end, Valid: true},
+		ID:          dues.ID,
+	})
+	if err == pgx.ErrNoRows {
+		// settled concurrently by another callback
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("settle membership dues: %w", err)
+	}
+
+	activated, err := activatePaidMember(ctx, qtx, settled.CommunityID, settled.UserID, pgtype.UUID{})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if activated {
+		s.news.Subscribe(ctx, uuid.UUID(settled.UserID.Bytes), uuid.UUID(settled.CommunityID.Bytes))
+	}
+
+	s.notifyPaid(ctx, uuid.UUID(settled.UserID.Bytes), settled)
+	return nil
+}
+
+// GetMyMembership returns the user's paid period and payment history in a community
+func (s *DuesService) GetMyMembership(ctx context.Context, userID, communityID uuid.UUID) (map[string]interface{}, error) {
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotCommunityMember
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get member: %w", err)
+	}
+
+	paidUntil, err := s.repo.GetMemberPaidUntil(ctx, repository.GetMemberPaidUntilParams{
+		CommunityID: member.CommunityID,
+		UserID:      member.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get paid until: %w", err)
+	}
+
+	dues, err := s.repo.ListMemberDues(ctx, repository.ListMemberDuesParams{
+		CommunityID: member.CommunityID,
+		UserID:      member.UserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list member dues: %w", err)
+	}
+
+	history := make([]map[string]interface{}, 0, len(dues))
+	for _, d := range dues {
+		history = append(history, buildDuesResponse(d))
+	}
+
+	result := map[string]interface{}{
+		"status":     string(member.Status.MemberStatus),
+		"paid_until": nil,
+		"dues":       history,
+	}
+	if paidUntil.Valid {
+		result["paid_until"] = paidUntil.Time.Format(dateLayout)
+	}
+	return result, nil
+}
+
+// ListDues returns the community's dues ledger, optionally for a single member
+func (s *DuesService) ListDues(ctx context.Context, communityID uuid.UUID, userID *uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	offset := (page - 1) * perPage
+
+	var userFilter pgtype.UUID
+	if userID != nil {
+		userFilter = uuidToPgtype(*userID)
+	}
+
+	dues, err := s.repo.ListCommunityDues(ctx, repository.ListCommunityDuesParams{
+		CommunityID:  uuidToPgtype(communityID),
+		UserID:       userFilter,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list community dues: %w", err)
+	}
+
+	total, err := s.repo.CountCommunityDues(ctx, repository.CountCommunityDuesParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      userFilter,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("count community dues: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(dues))
+	for _, d := range dues {
+		entry := map[string]interface{}{
+			"id":             pgtypeUUIDToStringRequired(d.ID),
+			"plan_id":        pgtypeUUIDToString(d.PlanID),
+			"plan_name":      d.PlanName.String,
+			"amount":         numericToFloat(d.Amount),
+			"currency":       d.Currency,
+			"period_start":   d.PeriodStart.Time.Format(dateLayout),
+			"period_end":     d.PeriodEnd.Time.Format(dateLayout),
+			"status":         string(d.Status),
+			"payment_method": string(d.PaymentMethod),
+			"member": map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(d.UserID),
+				"first_name": d.FirstName.String,
+				"last_name":  d.LastName.String,
+			},
+			"created_at": d.CreatedAt.Time,
+		}
+		if d.PaymentReference.Valid {
+			entry["payment_reference"] = d.PaymentReference.String
+		}
+		if d.RecordedBy.Valid {
+			entry["recorded_by"] = pgtypeUUIDToStringRequired(d.RecordedBy)
+		}
+		if d.PaidAt.Valid {
+			entry["paid_at"] = d.PaidAt.Time
+		}
+		result = append(result, entry)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+func (s *DuesService) getPlan(ctx context.Context, communityID, planID uuid.UUID) (repository.MembershipPlan, error) {
+	plan, err := s.repo.GetMembershipPlan(ctx, repository.GetMembershipPlanParams{
+		ID:          uuidToPgtype(planID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows || (err == nil && !plan.IsActive) {
+		return repository.MembershipPlan{}, ErrPlanNotFound
+	}
+	if err != nil {
+		return repository.MembershipPlan{}, fmt.Errorf("get membership plan: %w", err)
+	}
+	return plan, nil
+}
+
+// checkPayer ensures dues are only taken from people who joined (or are
+// renewing) and were not banned
+func (s *DuesService) checkPayer(ctx context.Context, communityID, userID uuid.UUID) error {
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotCommunityMember.WithMessage("Join the community before paying dues")
+	}
+	if err != nil {
+		return fmt.Errorf("get member: %w", err)
+	}
+	if member.Status.MemberStatus == repository.MemberStatusBanned {
+		return ErrForbidden.WithMessage("Member is banned from this community")
+	}
+	return nil
+}
+
+// lockMemberDues serialises the payments of one member until the transaction
+// ends, so concurrent payments do not compute the same period
+func lockMemberDues(ctx context.Context, q *repository.Queries, communityID, userID pgtype.UUID) error {
+	if err := q.LockMemberDues(ctx, repository.LockMemberDuesParams{
+		CommunityID: communityID,
+		UserID:      userID,
+	}); err != nil {
+		return fmt.Errorf("lock member dues: %w", err)
+	}
+	return nil
+}
+
+// nextPeriod returns the period covered by a new payment of the given length
+func (s *DuesService) nextPeriod(ctx context.Context, q *repository.Queries, communityID, userID pgtype.UUID, months int16) (time.Time, time.Time, error) {
+	paidUntil, err := q.GetMemberPaidUntil(ctx, repository.GetMemberPaidUntilParams{
+		CommunityID: communityID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("get paid until: %w", err)
+	}
+
+	start, end := membershipPeriod(paidUntil, calendarDate(time.Now(), s.location), int(months))
+	return start, end, nil
+}
+
+func (s *DuesService) notifyPaid(ctx context.Context, userID uuid.UUID, dues repository.MembershipDue) {
+	body := fmt.Sprintf("Членский взнос получен. Членство оплачено до %s", dues.PeriodEnd.Time.Format("02.01.2006"))
+	_, err := s.notifications.Create(ctx, userID,
+		string(repository.NotificationTypeMembershipDues),
+		"Оплата получена",
+		body,
+		map[string]any{
+			"community_id": pgtypeUUIDToStringRequired(dues.CommunityID),
+			"dues_id":      pgtypeUUIDToStringRequired(dues.ID),
+		},
+	)
+	if err != nil {
+		slog.Warn("failed to send dues notification", "error", err)
+	}
+}
+
+// activatePaidMember turns a pending or expired membership into an active one
+// and reports whether it did
+func activatePaidMember(ctx context.Context, q *repository.Queries, communityID, userID, reviewedBy pgtype.UUID) (bool, error) {
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: communityID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("get member: %w", err)
+	}
+
+	switch member.Status.MemberStatus {
+	case repository.MemberStatusPending, repository.MemberStatusExpired:
+	default:
+		return false, nil
+	}
+
+	_, err = q.UpdateCommunityMemberStatus(ctx, repository.UpdateCommunityMemberStatusParams{
+		CommunityID: communityID,
+		UserID:      userID,
+		Status:      repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true},
+		ReviewedBy:  reviewedBy,
+	})
+	if err != nil {
+		return false, fmt.Errorf("activate member: %w", err)
+	}
+	return true, nil
+}
+
+// membershipPeriod returns the first and last day covered by a payment for
+// the given number of months. Payments made before the current period ends
+// extend it; otherwise the new period starts today.
+func membershipPeriod(paidUntil pgtype.Date, today time.Time, months int) (time.Time, time.Time) {
+	start := today
+	if paidUntil.Valid && !paidUntil.Time.Before(today) {
+		start = paidUntil.Time.AddDate(0, 0, 1)
+	}
+	return start, start.AddDate(0, months, -1)
+}
+
+// calendarDate returns the date of t in loc as midnight UTC, the form
+// pgtype.Date uses for DATE columns
+func calendarDate(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func buildPlanResponse(p repository.MembershipPlan) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              pgtypeUUIDToStringRequired(p.ID),
+		"community_id":    pgtypeUUIDToStringRequired(p.CommunityID),
+		"name":            p.Name,
+		"period":          string(p.Period),
+		"duration_months": p.DurationMonths,
+		"price_amount":    numericToFloat(p.PriceAmount),
+		"price_currency":  p.PriceCurrency,
+		"is_active":       p.IsActive,
+		"created_at":      p.CreatedAt.Time,
+	}
+}
+
+func buildDuesResponse(d repository.MembershipDue) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":             pgtypeUUIDToStringRequired(d.ID),
+		"community_id":   pgtypeUUIDToStringRequired(d.CommunityID),
+		"user_id":        pgtypeUUIDToStringRequired(d.UserID),
+		"plan_id":        pgtypeUUIDToString(d.PlanID),
+		"amount":         numericToFloat(d.Amount),
+		"currency":       d.Currency,
+		"period_start":   d.PeriodStart.Time.Format(dateLayout),
+		"period_end":     d.PeriodEnd.Time.Format(dateLayout),
+		"status":         string(d.Status),
+		"payment_method": string(d.PaymentMethod),
+		"created_at":     d.CreatedAt.Time,
+	}
+	if d.PaymentReference.Valid {
+		result["payment_reference"] = d.PaymentReference.String
+	}
+	if d.PaidAt.Valid {
+		result["paid_at"] = d.PaidAt.Time
+	}
+	return result
+}
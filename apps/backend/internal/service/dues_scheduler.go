@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// duesReminderDays is how many days before the paid period ends members are reminded
+	duesReminderDays = 3
+	duesBatchSize    = 500
+
+	duesReminderType = "membership_dues_reminder"
+	duesExpiredType  = "membership_expired"
+)
+
+// DuesScheduler reminds members of paid communities that their period is
+// ending and expires memberships whose dues are overdue. Owners and admins
+// are never expired. Each ledger entry produces at most one reminder and one
+// expiry notice, deduplicated through sent_reminders. A reminder claim whose
+// notification could not be created is released so the next tick retries it.
+type DuesScheduler struct {
+	repo          *repository.Queries
+	notifications *NotificationService
+	news          *CommunityNews
+	interval      time.Duration
+	location      *time.Location
+}
+
+// NewDuesScheduler creates a new DuesScheduler
+func NewDuesScheduler(repo *repository.Queries, notifications *NotificationService, news *CommunityNews, interval time.Duration) *DuesScheduler {
+	return &DuesScheduler{
+		repo:          repo,
+		notifications: notifications,
+		news:          news,
+		interval:      interval,
+		location:      almatyLocation(),
+	}
+}
+
+// Run processes dues every interval until ctx is cancelled.
+func (s *DuesScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Warn("dues scheduler tick failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick sends reminders for periods ending soon and expires overdue memberships.
+func (s *DuesScheduler) Tick(ctx context.Context, now time.Time) error {
+	today := calendarDate(now, s.location)
+
+	if err := s.sendReminders(ctx, today); err != nil {
+		return err
+	}
+	return s.expireOverdue(ctx, today)
+}
+
+func (s *DuesScheduler) sendReminders(ctx context.Context, today time.Time) error {
+	rows, err := s.repo.ListEndingMemberships(ctx, repository.ListEndingMembershipsParams{
+		WindowStart:  pgtype.Date{Time: today, Valid: true},
+		WindowEnd:    pgtype.Date{Time: today.AddDate(0, 0, duesReminderDays), Valid: true},
+		ReminderType: duesReminderType,
+		BatchLimit:   duesBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list ending memberships: %w", err)
+	}
+
+	for _, r := range rows {
+		claimed, err := s.claim(ctx, r, duesReminderType)
+		if err != nil || !claimed {
+			continue
+		}
+
+		body := fmt.Sprintf("Членство в «%s» оплачено до %s. Продлите его, чтобы остаться участником",
+			r.CommunityName, r.PeriodEnd.Time.Format("02.01.2006"))
+		if !s.notify(ctx, r, "Пора оплатить членский взнос", body) {
+			s.release(ctx, r, duesReminderType)
+		}
+	}
+
+	return nil
+}
+
+func (s *DuesScheduler) expireOverdue(ctx context.Context, today time.Time) error {
+	rows, err := s.repo.ListEndingMemberships(ctx, repository.ListEndingMembershipsParams{
+		WindowStart:  pgtype.Date{Time: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		WindowEnd:    pgtype.Date{Time: today.AddDate(0, 0, -1), Valid: true},
+		ReminderType: duesExpiredType,
+		BatchLimit:   duesBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list overdue memberships: %w", err)
+	}
+
+	for _, r := range rows {
+		expired, err := s.repo.ExpireCommunityMember(ctx, repository.ExpireCommunityMemberParams{
+			CommunityID: r.CommunityID,
+			UserID:      r.UserID,
+		})
+		if err != nil {
+			slog.Warn("failed to expire membership", "community_id", pgtypeUUIDToStringRequired(r.CommunityID), "error", err)
+			continue
+		}
+		if expired == 0 {
+			continue
+		}
+		s.news.Unsubscribe(ctx, uuid.UUID(r.UserID.Bytes), uuid.UUID(r.CommunityID.Bytes))
+
+		claimed, err := s.claim(ctx, r, duesExpiredType)
+		if err != nil || !claimed {
+			continue
+		}
+
+		body := fmt.Sprintf("Членство в «%s» приостановлено: взнос не оплачен. Оплатите его, чтобы вернуться", r.CommunityName)
+		s.notify(ctx, r, "Членство истекло", body)
+	}
+
+	return nil
+}
+
+func (s *DuesScheduler) claim(ctx context.Context, r repository.ListEndingMembershipsRow, reminderType string) (bool, error) {
+	claimed, err := s.repo.ClaimReminder(ctx, repository.ClaimReminderParams{
+		UserID:       r.UserID,
+		TargetType:   "membership_dues",
+		TargetID:     r.ID,
+		ReminderType: reminderType,
+	})
+	if err != nil {
+		slog.Warn("failed to claim dues reminder", "dues_id", pgtypeUUIDToStringRequired(r.ID), "error", err)
+		return false, err
+	}
+	return claimed > 0, nil
+}
+
+func (s *DuesScheduler) release(ctx context.Context, r repository.ListEndingMembershipsRow, reminderType string) {
+	if err := s.repo.ReleaseReminder(ctx, repository.ReleaseReminderParams{
+		UserID:       r.UserID,
+		TargetID:     r.ID,
+		ReminderType: reminderType,
+	}); err != nil {
+		slog.Warn("failed to release dues reminder", "dues_id", pgtypeUUIDToStringRequired(r.ID), "error", err)
+	}
+}
+
+// notify creates the member's notification and reports whether it was created
+func (s *DuesScheduler) notify(ctx context.Context, r repository.ListEndingMembershipsRow, title, body string) bool {
+	uid, err := uuid.FromBytes(r.UserID.Bytes[:])
+	if err != nil {
+		return false
+	}
+
+	data := map[string]any{
+		"community_id": pgtypeUUIDToStringRequired(r.CommunityID),
+		"paid_until":   r.PeriodEnd.Time.Format(dateLayout),
+	}
+	if _, err := s.notifications.Create(ctx, uid, string(repository.NotificationTypeMembershipDues), title, body, data); err != nil {
+		slog.Warn("failed to create dues notification", "user_id", uid, "error", err)
+		return false
+	}
+	return true
+}
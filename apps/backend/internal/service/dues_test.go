@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestMembershipPeriod(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	today := day(2025, 3, 10)
+
+	tests := []struct {
+		name      string
+		paidUntil pgtype.Date
+		months    int
+		start     time.Time
+		end       time.Time
+	}{
+		{
+			name:   "First payment starts today",
+			months: 1,
+			start:  day(2025, 3, 10),
+			end:    day(2025, 4, 9),
+		},
+		{
+			name:      "Early renewal extends the current period",
+			paidUntil: pgtype.Date{Time: day(2025, 3, 15), Valid: true},
+			months:    1,
+			start:     day(2025, 3, 16),
+			end:       day(2025, 4, 15),
+		},
+		{
+			name:      "Renewal on the last paid day",
+			paidUntil: pgtype.Date{Time: today, Valid: true},
+			months:    1,
+			start:     day(2025, 3, 11),
+			end:       day(2025, 4, 10),
+		},
+		{
+			name:      "Lapsed membership restarts today",
+			paidUntil: pgtype.Date{Time: day(2025, 2, 1), Valid: true},
+			months:    6,
+			start:     day(2025, 3, 10),
+			end:       day(2025, 9, 9),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := membershipPeriod(tt.paidUntil, today, tt.months)
+			if !start.Equal(tt.start) || !end.Equal(tt.end) {
+				t.Errorf("got %s..%s, want %s..%s",
+					start.Format(dateLayout), end.Format(dateLayout),
+					tt.start.Format(dateLayout), tt.end.Format(dateLayout))
+			}
+		})
+	}
+}
+
+func TestCalendarDate(t *testing.T) {
+	loc := time.FixedZone("+05", 5*60*60)
+
+	// 21:30 UTC is already the next day in Almaty
+	got := calendarDate(time.Date(2025, 3, 10, 21, 30, 0, 0, time.UTC), loc)
+	want := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestDuesReminderReleasedWhenNotificationFails(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	dues := repository.ListEndingMembershipsRow{
+		ID:            uuidToPgtype(uuid.New()),
+		CommunityID:   uuidToPgtype(uuid.New()),
+		UserID:        uuidToPgtype(uuid.New()),
+		PeriodEnd:     pgtype.Date{Time: now.AddDate(0, 0, 2), Valid: true},
+		CommunityName: "Теннисный клуб Алматы",
+	}
+
+	db := newFakeDB()
+	db.on("ListEndingMemberships", func(args []any) ([][]any, error) {
+		if args[2].(string) != duesReminderType {
+			return nil, nil
+		}
+		return [][]any{{dues}}, nil
+	})
+	db.rows("ClaimReminder", []any{})
+	db.rows("ReleaseReminder", []any{})
+	db.on("CreateNotification", func([]any) ([][]any, error) {
+		return nil, errors.New("connection reset")
+	})
+
+	notifications := NewNotificationService(db.queries(), slog.Default(), nil)
+	scheduler := NewDuesScheduler(db.queries(), notifications, nil, time.Hour)
+	if err := scheduler.Tick(context.Background(), now); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	released := db.called("ReleaseReminder")
+	if len(released) != 1 || released[0].Args[1] != dues.ID || released[0].Args[2] != duesReminderType {
+		t.Errorf("released = %+v, want the reminder claim of %s released", released, pgtypeUUIDToStringRequired(dues.ID))
+	}
+}
+
+func TestRecordPaymentLocksMemberBeforeComputingPeriod(t *testing.T) {
+	communityID, userID := uuid.New(), uuid.New()
+	db := newFakeDB()
+	db.rows("GetMembershipPlan", []any{repository.MembershipPlan{
+		ID:             uuidToPgtype(uuid.New()),
+		CommunityID:    uuidToPgtype(communityID),
+		DurationMonths: 1,
+		PriceAmount:    floatToNumeric(5000),
+		PriceCurrency:  "KZT",
+		IsActive:       true,
+	}})
+	db.rows("GetCommunityMember", []any{repository.CommunityMember{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(userID),
+		Status:      repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true},
+	}})
+	db.rows("LockMemberDues", []any{})
+	db.rows("GetMemberPaidUntil", []any{pgtype.Date{}})
+	db.on("CreateMembershipDues", func(args []any) ([][]any, error) {
+		return [][]any{{repository.MembershipDue{
+			ID:          uuidToPgtype(uuid.New()),
+			CommunityID: args[0].(pgtype.UUID),
+			UserID:      args[1].(pgtype.UUID),
+			PeriodStart: args[5].(pgtype.Date),
+			PeriodEnd:   args[6].(pgtype.Date),
+		}}}, nil
+	})
+	db.rows("CreateNotification", []any{repository.Notification{ID: uuidToPgtype(uuid.New())}})
+
+	notifications := NewNotificationService(db.queries(), slog.Default(), nil)
+	service := NewDuesService(db.queries(), db, nil, notifications, nil)
+	if _, err := service.RecordPayment(context.Background(), uuid.New(), communityID, RecordPaymentInput{
+		PlanID: uuid.New(),
+		UserID: userID,
+		Method: string(repository.PaymentMethodCash),
+	}); err != nil {
+		t.Fatalf("record payment: %v", err)
+	}
+
+	locked, read := -1, -1
+	for i, name := range db.names() {
+		switch name {
+		case "LockMemberDues":
+			locked = i
+		case "GetMemberPaidUntil":
+			read = i
+		}
+	}
+	if locked < 0 || read < locked {
+		t.Errorf("queries = %v, want the member locked before the paid period is read", db.names())
+	}
+	if lock := db.called("LockMemberDues"); len(lock) != 1 || lock[0].Args[0] != uuidToPgtype(communityID) || lock[0].Args[1] != uuidToPgtype(userID) {
+		t.Errorf("locks = %+v, want the paying member", lock)
+	}
+}
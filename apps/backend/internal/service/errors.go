@@ -52,16 +52,31 @@ var (
 	ErrForbidden          = &AppError{Code: "FORBIDDEN", Status: 403}
 	ErrNotCommunityMember = &AppError{Code: "NOT_COMMUNITY_MEMBER", Status: 403}
 	ErrInsufficientRole   = &AppError{Code: "INSUFFICIENT_ROLE", Status: 403}
+	ErrNotPlayedAtCourt   = &AppError{Code: "NOT_PLAYED_AT_COURT", Status: 403}
 )
 
 // Not Found (404)
 var (
-	ErrNotFound          = &AppError{Code: "NOT_FOUND", Status: 404}
-	ErrUserNotFound      = &AppError{Code: "USER_NOT_FOUND", Status: 404}
-	ErrEventNotFound     = &AppError{Code: "EVENT_NOT_FOUND", Status: 404}
-	ErrCommunityNotFound = &AppError{Code: "COMMUNITY_NOT_FOUND", Status: 404}
-	ErrMatchNotFound     = &AppError{Code: "MATCH_NOT_FOUND", Status: 404}
-	ErrChatNotFound      = &AppError{Code: "CHAT_NOT_FOUND", Status: 404}
+	ErrNotFound             = &AppError{Code: "NOT_FOUND", Status: 404}
+	ErrUserNotFound         = &AppError{Code: "USER_NOT_FOUND", Status: 404}
+	ErrEventNotFound        = &AppError{Code: "EVENT_NOT_FOUND", Status: 404}
+	ErrCommunityNotFound    = &AppError{Code: "COMMUNITY_NOT_FOUND", Status: 404}
+	ErrMatchNotFound        = &AppError{Code: "MATCH_NOT_FOUND", Status: 404}
+	ErrChatNotFound         = &AppError{Code: "CHAT_NOT_FOUND", Status: 404}
+	ErrCourtNotFound        = &AppError{Code: "COURT_NOT_FOUND", Status: 404}
+	ErrBookingNotFound      = &AppError{Code: "BOOKING_NOT_FOUND", Status: 404}
+	ErrReviewNotFound       = &AppError{Code: "REVIEW_NOT_FOUND", Status: 404}
+	ErrPlanNotFound         = &AppError{Code: "PLAN_NOT_FOUND", Status: 404}
+	ErrInviteNotFound       = &AppError{Code: "INVITE_NOT_FOUND", Status: 404}
+	ErrTransferNotFound     = &AppError{Code: "TRANSFER_NOT_FOUND", Status: 404}
+	ErrImportNotFound       = &AppError{Code: "IMPORT_NOT_FOUND", Status: 404}
+	ErrAnnouncementNotFound = &AppError{Code: "ANNOUNCEMENT_NOT_FOUND", Status: 404}
+	ErrTeamNotFound         = &AppError{Code: "TEAM_NOT_FOUND", Status: 404}
+	ErrTieNotFound          = &AppError{Code: "TIE_NOT_FOUND", Status: 404}
+	ErrLadderNotFound       = &AppError{Code: "LADDER_NOT_FOUND", Status: 404}
+	ErrChallengeNotFound    = &AppError{Code: "CHALLENGE_NOT_FOUND", Status: 404}
+	ErrPostNotFound         = &AppError{Code: "POST_NOT_FOUND", Status: 404}
+	ErrCommentNotFound      = &AppError{Code: "COMMENT_NOT_FOUND", Status: 404}
 )
 
 // Conflict (409)
@@ -72,6 +87,8 @@ var (
 	ErrAlreadyFriends      = &AppError{Code: "ALREADY_FRIENDS", Status: 409}
 	ErrProfileAlreadySet   = &AppError{Code: "PROFILE_ALREADY_SET", Status: 409}
 	ErrResultAlreadySubmit = &AppError{Code: "RESULT_ALREADY_SUBMITTED", Status: 409}
+	ErrBookingConflict     = &AppError{Code: "BOOKING_CONFLICT", Status: 409}
+	ErrImportCommitted     = &AppError{Code: "IMPORT_ALREADY_COMMITTED", Status: 409}
 )
 
 // Rate Limit (429)
@@ -87,6 +104,11 @@ var (
 	ErrEventWrongLevel = &AppError{Code: "LEVEL_MISMATCH", Status: 400}
 )
 
+// Invite errors (400)
+var (
+	ErrInviteExpired = &AppError{Code: "INVITE_EXPIRED", Status: 400}
+)
+
 // File errors (400)
 var (
 	ErrFileTooLarge   = &AppError{Code: "FILE_TOO_LARGE", Status: 400}
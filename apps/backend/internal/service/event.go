@@ -13,12 +13,18 @@ import (
 
 // EventService handles event business logic
 type EventService struct {
-	repo *repository.Queries
+	repo     *repository.Queries
+	pool     txStarter
+	bookings *BookingService
 }
 
 // NewEventService creates a new EventService
-func NewEventService(repo *repository.Queries) *EventService {
-	return &EventService{repo: repo}
+func NewEventService(repo *repository.Queries, pool txStarter, bookings *BookingService) *EventService {
+	return &EventService{
+		repo:     repo,
+		pool:     pool,
+		bookings: bookings,
+	}
 }
 
 // CreateEventInput represents input for creating an event
@@ -126,9 +132,21 @@ func (s *EventService) Create(ctx context.Context, userID uuid.UUID, input Creat
 		params.PriceAmount.Scan(fmt.Sprintf("%.2f", *input.PriceAmount))
 	}
 
-	event, err := s.repo.CreateEvent(ctx, params)
-	if err != nil {
-		return nil, fmt.Errorf("create event: %w", err)
+	var (
+		event   repository.Event
+		booking map[string]interface{}
+		err     error
+	)
+	if params.CourtID.Valid {
+		event, booking, err = s.createWithBooking(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		event, err = s.repo.CreateEvent(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("create event: %w", err)
+		}
 	}
 
 	// Auto-join creator as participant
@@ -138,7 +156,55 @@ func (s *EventService) Create(ctx context.Context, userID uuid.UUID, input Creat
 		Status:  repository.NullParticipantStatus{ParticipantStatus: repository.ParticipantStatusRegistered, Valid: true},
 	})
 
-	return buildEventResponse(event), nil
+	result := buildEventResponse(event)
+	if booking != nil {
+		result["court_booking"] = booking
+	}
+	if warning := s.courtHoursWarning(ctx, event); warning != "" {
+		result["warnings"] = []string{warning}
+	}
+	return result, nil
+}
+
+// courtHoursWarning checks the event time against the court's opening hours.
+// Events outside the hours are still created, the organiser only gets a warning.
+func (s *EventService) courtHoursWarning(ctx context.Context, event repository.Event) string {
+	if !event.CourtID.Valid {
+		return ""
+	}
+	court, err := s.repo.GetCourtByID(ctx, event.CourtID)
+	if err != nil {
+		return ""
+	}
+	return courtHoursWarning(court, event.StartTime.Time, eventEnd(event), almatyLocation())
+}
+
+// createWithBooking creates an event at a directory court and reserves its slot
+// in the same transaction, so a conflicting booking rolls back the event.
+func (s *EventService) createWithBooking(ctx context.Context, params repository.CreateEventParams) (repository.Event, map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return repository.Event{}, nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	event, err := qtx.CreateEvent(ctx, params)
+	if err != nil {
+		return repository.Event{}, nil, fmt.Errorf("create event: %w", err)
+	}
+
+	booking, err := s.bookings.ReserveForEvent(ctx, qtx, event)
+	if err != nil {
+		return repository.Event{}, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return repository.Event{}, nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return event, booking, nil
 }
 
 // ListEventsInput contains filter parameters
@@ -411,10 +477,35 @@ func (s *EventService) Leave(ctx context.Context, userID, eventID uuid.UUID) err
 // Valid status transitions
 var validTransitions = map[repository.EventStatus][]repository.EventStatus{
 	repository.EventStatusDraft:              {repository.EventStatusPublished},
-	repository.EventStatusPublished:          {repository.EventStatusRegistrationOpen, repository.EventStatusCancelled},
+	repository.EventStatusPublished:          {repository.EventStatusRegistrationOpen, repository.EventStatusRegistrationClosed, repository.EventStatusCancelled},
 	repository.EventStatusRegistrationOpen:   {repository.EventStatusRegistrationClosed, repository.EventStatusCancelled},
 	repository.EventStatusRegistrationClosed: {repository.EventStatusInProgress, repository.EventStatusCancelled},
 	repository.EventStatusInProgress:         {repository.EventStatusCompleted, repository.EventStatusCancelled},
+	repository.EventStatusCompleted:          {repository.EventStatusArchived},
+}
+
+// validateTransition checks that an event may move from one status to another.
+// Cancelled is allowed from any status except completed.
+func validateTransition(currentStatus, targetStatus repository.EventStatus) error {
+	if targetStatus == repository.EventStatusCancelled {
+		if currentStatus == repository.EventStatusCompleted {
+			return ErrValidation.WithMessage("Cannot cancel completed event")
+		}
+		return nil
+	}
+
+	allowed, ok := validTransitions[currentStatus]
+	if !ok {
+		return ErrValidation.WithMessage("Invalid current status: " + string(currentStatus))
+	}
+	for _, s := range allowed {
+		if s == targetStatus {
+			return nil
+		}
+	}
+	return ErrValidation.WithMessage(
+		fmt.Sprintf("Cannot transition from %s to %s", currentStatus, targetStatus),
+	)
 }
 
 // UpdateStatus changes the event status with lifecycle validation
@@ -436,32 +527,20 @@ func (s *EventService) UpdateStatus(ctx context.Context, userID, eventID uuid.UU
 	targetStatus := repository.EventStatus(newStatus)
 	currentStatus := event.Status.EventStatus
 
-	// Cancelled allowed from any non-completed status
-	if targetStatus == repository.EventStatusCancelled {
-		if currentStatus == repository.EventStatusCompleted {
-			return nil, ErrValidation.WithMessage("Cannot cancel completed event")
-		}
-	} else {
-		// Validate transition
-		allowed, ok := validTransitions[currentStatus]
-		if !ok {
-			return nil, ErrValidation.WithMessage("Invalid current status: " + string(currentStatus))
-		}
-		valid := false
-		for _, s := range allowed {
-			if s == targetStatus {
-				valid = true
-				break
-			}
-		}
-		if !valid {
-			return nil, ErrValidation.WithMessage(
-				fmt.Sprintf("Cannot transition from %s to %s", currentStatus, targetStatus),
-			)
-		}
+	if err := validateTransition(currentStatus, targetStatus); err != nil {
+		return nil, err
 	}
 
-	updated, err := s.repo.UpdateEventStatus(ctx, repository.UpdateEventStatusParams{
+	// A cancelled event frees its court slot in the same transaction
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	updated, err := qtx.UpdateEventStatus(ctx, repository.UpdateEventStatusParams{
 		ID:     pgtype.UUID{Bytes: eventID, Valid: true},
 		Status: repository.NullEventStatus{EventStatus: targetStatus, Valid: true},
 	})
@@ -469,9 +548,66 @@ func (s *EventService) UpdateStatus(ctx context.Context, userID, eventID uuid.UU
 		return nil, fmt.Errorf("update status: %w", err)
 	}
 
+	if targetStatus == repository.EventStatusCancelled && event.CourtID.Valid {
+		if err := s.bookings.ReleaseForEvent(ctx, qtx, event.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
 	return buildUpdateEventStatusResponse(updated), nil
 }
 
+// Nearby returns upcoming open events at courts within the radius, closest first
+func (s *EventService) Nearby(ctx context.Context, input NearbyInput) ([]map[string]interface{}, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListEventsNearby(ctx, repository.ListEventsNearbyParams{
+		Lat:          input.Lat,
+		Lng:          input.Lng,
+		StartsAfter:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		RadiusMeters: input.radiusMeters(),
+		ResultLimit:  int32(input.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list events nearby: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, e := range rows {
+		item := map[string]interface{}{
+			"id":                   pgtypeUUIDToStringRequired(e.ID),
+			"title":                e.Title,
+			"event_type":           string(e.EventType),
+			"status":               string(e.Status.EventStatus),
+			"player_composition":   string(e.PlayerComposition),
+			"start_time":           e.StartTime.Time,
+			"max_participants":     e.MaxParticipants.Int32,
+			"current_participants": e.CurrentParticipants.Int32,
+			"min_level":            numericToFloat(e.MinLevel),
+			"max_level":            numericToFloat(e.MaxLevel),
+			"court_id":             pgtypeUUIDToStringRequired(e.CourtID),
+			"court_name":           e.CourtName,
+			"court_address":        e.CourtAddress,
+			"distance_km":          distanceKm(e.DistanceMeters),
+		}
+		if e.EndTime.Valid {
+			item["end_time"] = e.EndTime.Time
+		}
+		if e.CommunityID.Valid {
+			item["community_id"] = pgtypeUUIDToStringRequired(e.CommunityID)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
 // GetCalendar returns events grouped by day for a given month
 func (s *EventService) GetCalendar(ctx context.Context, year, month int, communityID string) (map[string][]map[string]interface{}, error) {
 	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// lifecycleBatchSize limits how many events are processed per tick.
+const lifecycleBatchSize = 200
+
+// EventSchedulerConfig controls timing of automatic event transitions.
+type EventSchedulerConfig struct {
+	// Interval between scheduler ticks.
+	Interval time.Duration
+	// CancelCutoff is how long before start_time an event without enough
+	// participants is cancelled.
+	CancelCutoff time.Duration
+	// DefaultDuration is used to complete events that have no end_time.
+	DefaultDuration time.Duration
+	// ArchiveAfter is how long a completed event stays visible before archiving.
+	ArchiveAfter time.Duration
+}
+
+// EventScheduler moves events through their lifecycle based on time.
+// Each transition is applied with a conditional update on the current status,
+// so several instances may run the scheduler concurrently. A cancellation
+// releases the event's court booking in the same transaction.
+type EventScheduler struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+	cfg           EventSchedulerConfig
+}
+
+// NewEventScheduler creates a new EventScheduler
+func NewEventScheduler(repo *repository.Queries, pool txStarter, notifications *NotificationService, cfg EventSchedulerConfig) *EventScheduler {
+	return &EventScheduler{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+		cfg:           cfg,
+	}
+}
+
+// Run processes due transitions every interval until ctx is cancelled.
+func (s *EventScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Warn("event lifecycle tick failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick applies all transitions that are due at the given time. The query
+// lists only events with a transition due, so events waiting for a later one
+// never fill the batch and hold back due ones.
+func (s *EventScheduler) Tick(ctx context.Context, now time.Time) error {
+	events, err := s.repo.ListEventsForLifecycle(ctx, repository.ListEventsForLifecycleParams{
+		CancelHorizon: pgtype.Timestamptz{Time: now.Add(s.cfg.CancelCutoff), Valid: true},
+		Now:           pgtype.Timestamptz{Time: now, Valid: true},
+		StartedBefore: pgtype.Timestamptz{Time: now.Add(-s.cfg.DefaultDuration), Valid: true},
+		ArchiveBefore: pgtype.Timestamptz{Time: now.Add(-s.cfg.ArchiveAfter), Valid: true},
+		BatchLimit:    lifecycleBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list lifecycle events: %w", err)
+	}
+
+	for _, e := range events {
+		s.advance(ctx, e, now)
+	}
+
+	return nil
+}
+
+// advance walks a single event through every transition that is due,
+// e.g. published -> registration_closed -> in_progress in one tick.
+func (s *EventScheduler) advance(ctx context.Context, e repository.ListEventsForLifecycleRow, now time.Time) {
+	current := e.Status.EventStatus
+	for {
+		next, ok := nextLifecycleStatus(e, current, now, s.cfg)
+		if !ok {
+			return
+		}
+		if err := validateTransition(current, next); err != nil {
+			slog.Warn("skipping invalid lifecycle transition",
+				"event_id", pgtypeUUIDToStringRequired(e.ID), "from", current, "to", next)
+			return
+		}
+
+		affected, err := s.transition(ctx, e.ID, current, next)
+		if err != nil {
+			slog.Warn("failed to transition event", "event_id", pgtypeUUIDToStringRequired(e.ID), "error", err)
+			return
+		}
+		// Another instance or the creator changed the status first.
+		if affected == 0 {
+			return
+		}
+
+		if next == repository.EventStatusCancelled {
+			s.notifyAutoCancelled(ctx, e)
+		}
+		current = next
+	}
+}
+
+// transition moves the event from one status to another and reports whether
+// it was still in the expected status. A cancellation also releases the
+// event's court booking, committed together with the status change.
+func (s *EventScheduler) transition(ctx context.Context, eventID pgtype.UUID, from, to repository.EventStatus) (int64, error) {
+	params := repository.TransitionEventStatusParams{
+		ToStatus:   repository.NullEventStatus{EventStatus: to, Valid: true},
+		ID:         eventID,
+		FromStatus: repository.NullEventStatus{EventStatus: from, Valid: true},
+	}
+	if to != repository.EventStatusCancelled {
+		return s.repo.TransitionEventStatus(ctx, params)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	affected, err := qtx.TransitionEventStatus(ctx, params)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	if _, err := qtx.CancelEventCourtBookings(ctx, eventID); err != nil {
+		return 0, fmt.Errorf("cancel event court bookings: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return affected, nil
+}
+
+// nextLifecycleStatus returns the status an event should move to at the given
+// time, or false if nothing is due.
+func nextLifecycleStatus(e repository.ListEventsForLifecycleRow, current repository.EventStatus, now time.Time, cfg EventSchedulerConfig) (repository.EventStatus, bool) {
+	start := e.StartTime.Time
+
+	switch current {
+	case repository.EventStatusPublished, repository.EventStatusRegistrationOpen, repository.EventStatusRegistrationClosed:
+		if !now.Before(start.Add(-cfg.CancelCutoff)) &&
+			e.MinParticipants.Valid && e.CurrentParticipants.Int32 < e.MinParticipants.Int32 {
+			return repository.EventStatusCancelled, true
+		}
+		if current == repository.EventStatusRegistrationClosed {
+			if !now.Before(start) {
+				return repository.EventStatusInProgress, true
+			}
+			return "", false
+		}
+		deadlinePassed := e.RegistrationDeadline.Valid && !now.Before(e.RegistrationDeadline.Time)
+		if deadlinePassed || !now.Before(start) {
+			return repository.EventStatusRegistrationClosed, true
+		}
+
+	case repository.EventStatusInProgress:
+		end := start.Add(cfg.DefaultDuration)
+		if e.EndTime.Valid {
+			end = e.EndTime.Time
+		}
+		if !now.Before(end) {
+			return repository.EventStatusCompleted, true
+		}
+
+	case repository.EventStatusCompleted:
+		end := start
+		if e.EndTime.Valid {
+			end = e.EndTime.Time
+		}
+		if !now.Before(end.Add(cfg.ArchiveAfter)) {
+			return repository.EventStatusArchived, true
+		}
+	}
+
+	return "", false
+}
+
+// notifyAutoCancelled tells registered participants that the event was cancelled.
+func (s *EventScheduler) notifyAutoCancelled(ctx context.Context, e repository.ListEventsForLifecycleRow) {
+	if s.notifications == nil {
+		return
+	}
+
+	participants, err := s.repo.ListEventParticipants(ctx, e.ID)
+	if err != nil {
+		slog.Warn("failed to list participants for cancelled event", "event_id", pgtypeUUIDToStringRequired(e.ID), "error", err)
+		return
+	}
+
+	eventID := pgtypeUUIDToStringRequired(e.ID)
+	for _, p := range participants {
+		userID, err := uuid.FromBytes(p.UserID.Bytes[:])
+		if err != nil {
+			continue
+		}
+
+		_, err = s.notifications.Create(ctx, userID,
+			string(repository.NotificationTypeEventCancelled),
+			"Ивент отменён",
+			fmt.Sprintf("«%s» отменён: не набралось минимальное количество участников.", e.Title),
+			map[string]any{
+				"event_id": eventID,
+			},
+		)
+		if err != nil {
+			slog.Warn("failed to create event_cancelled notification", "user_id", userID, "error", err)
+		}
+	}
+}
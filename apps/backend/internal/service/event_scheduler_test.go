@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestNextLifecycleStatus(t *testing.T) {
+	cfg := EventSchedulerConfig{
+		CancelCutoff:    2 * time.Hour,
+		DefaultDuration: 2 * time.Hour,
+		ArchiveAfter:    30 * 24 * time.Hour,
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ts := func(t time.Time) pgtype.Timestamptz { return pgtype.Timestamptz{Time: t, Valid: true} }
+
+	tests := []struct {
+		name     string
+		event    repository.ListEventsForLifecycleRow
+		current  repository.EventStatus
+		expected repository.EventStatus
+		due      bool
+	}{
+		{
+			name: "Registration deadline passed",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime:            ts(now.Add(24 * time.Hour)),
+				RegistrationDeadline: ts(now.Add(-time.Minute)),
+				MinParticipants:      pgtype.Int4{Int32: 2, Valid: true},
+				CurrentParticipants:  pgtype.Int4{Int32: 4, Valid: true},
+			},
+			current:  repository.EventStatusPublished,
+			expected: repository.EventStatusRegistrationClosed,
+			due:      true,
+		},
+		{
+			name: "Not enough participants by cutoff",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime:           ts(now.Add(time.Hour)),
+				MinParticipants:     pgtype.Int4{Int32: 4, Valid: true},
+				CurrentParticipants: pgtype.Int4{Int32: 2, Valid: true},
+			},
+			current:  repository.EventStatusRegistrationOpen,
+			expected: repository.EventStatusCancelled,
+			due:      true,
+		},
+		{
+			name: "Start time reached",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime:           ts(now),
+				MinParticipants:     pgtype.Int4{Int32: 2, Valid: true},
+				CurrentParticipants: pgtype.Int4{Int32: 2, Valid: true},
+			},
+			current:  repository.EventStatusRegistrationClosed,
+			expected: repository.EventStatusInProgress,
+			due:      true,
+		},
+		{
+			name: "In progress without end time uses default duration",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime: ts(now.Add(-time.Hour)),
+			},
+			current: repository.EventStatusInProgress,
+			due:     false,
+		},
+		{
+			name: "End time passed",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime: ts(now.Add(-3 * time.Hour)),
+				EndTime:   ts(now.Add(-time.Hour)),
+			},
+			current:  repository.EventStatusInProgress,
+			expected: repository.EventStatusCompleted,
+			due:      true,
+		},
+		{
+			name: "Old completed event is archived",
+			event: repository.ListEventsForLifecycleRow{
+				StartTime: ts(now.Add(-40 * 24 * time.Hour)),
+			},
+			current:  repository.EventStatusCompleted,
+			expected: repository.EventStatusArchived,
+			due:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, due := nextLifecycleStatus(tt.event, tt.current, now, cfg)
+			if due != tt.due || next != tt.expected {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.expected, tt.due, next, due)
+			}
+			if due {
+				if err := validateTransition(tt.current, next); err != nil {
+					t.Errorf("Transition %s -> %s rejected: %v", tt.current, next, err)
+				}
+			}
+		})
+	}
+}
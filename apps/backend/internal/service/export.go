@@ -0,0 +1,555 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/spreadsheet"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ExportMembers     = "members"
+	ExportMatches     = "matches"
+	ExportLeaderboard = "leaderboard"
+	ExportAttendance  = "attendance"
+
+	exportBatchSize = 500
+	// exportJobTimeout bounds a single background export
+	exportJobTimeout = 15 * time.Minute
+	// exportLinkTTL is how long a download link of a background export works
+	exportLinkTTL = 24 * time.Hour
+)
+
+// exportSheetNames are the worksheet names used in XLSX exports
+var exportSheetNames = map[string]string{
+	ExportMembers:     "Участники",
+	ExportMatches:     "Матчи",
+	ExportLeaderboard: "Рейтинг",
+	ExportAttendance:  "Посещаемость",
+}
+
+// ExportService exports community data as CSV or XLSX. Exports up to
+// asyncThreshold rows are streamed in the response; larger ones run in the
+// background, are uploaded to private storage and the requester gets a
+// notification with a presigned download link. The job keeps the object key;
+// every read of the job signs a fresh link.
+type ExportService struct {
+	repo           *repository.Queries
+	storage        *StorageService
+	notifications  *NotificationService
+	asyncThreshold int
+	location       *time.Location
+}
+
+// NewExportService creates a new ExportService
+func NewExportService(repo *repository.Queries, storage *StorageService, notifications *NotificationService, asyncThreshold int) *ExportService {
+	return &ExportService{
+		repo:           repo,
+		storage:        storage,
+		notifications:  notifications,
+		asyncThreshold: asyncThreshold,
+		location:       almatyLocation(),
+	}
+}
+
+// ExportInput selects what to export
+type ExportInput struct {
+	Dataset string
+	Format  string
+	// Async forces a background export regardless of size
+	Async bool
+}
+
+// ExportPlan tells the caller how an export will be delivered. Job is set
+// when the export runs in the background; otherwise the caller streams it.
+type ExportPlan struct {
+	Job         map[string]interface{}
+	Format      string
+	Filename    string
+	ContentType string
+}
+
+// Prepare validates the request and starts a background job for large exports
+func (s *ExportService) Prepare(ctx context.Context, actorID, communityID uuid.UUID, input ExportInput) (*ExportPlan, error) {
+	if _, ok := exportSheetNames[input.Dataset]; !ok {
+		return nil, ErrValidation.WithMessage("dataset must be one of: members, matches, leaderboard, attendance")
+	}
+	if input.Format == "" {
+		input.Format = spreadsheet.FormatCSV
+	}
+	if !spreadsheet.ValidFormat(input.Format) {
+		return nil, ErrValidation.WithMessage("format must be csv or xlsx")
+	}
+
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	plan := &ExportPlan{
+		Format:      input.Format,
+		Filename:    exportFilename(community.Slug.String, input.Dataset, input.Format, time.Now().In(s.location)),
+		ContentType: spreadsheet.ContentType(input.Format),
+	}
+
+	async := input.Async
+	if !async {
+		rows, err := s.countRows(ctx, community.ID, input.Dataset)
+		if err != nil {
+			return nil, err
+		}
+		async = rows > s.asyncThreshold
+	}
+	if !async {
+		return plan, nil
+	}
+
+	job, err := s.repo.CreateCommunityExport(ctx, repository.CreateCommunityExportParams{
+		CommunityID: community.ID,
+		RequestedBy: uuidToPgtype(actorID),
+		Dataset:     input.Dataset,
+		Format:      input.Format,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+
+	go s.run(job, community.Name, plan.Filename)
+
+	plan.Job = s.exportResponse(ctx, job)
+	return plan, nil
+}
+
+// Stream writes the export to w and returns the number of data rows
+func (s *ExportService) Stream(ctx context.Context, w io.Writer, communityID uuid.UUID, dataset, format string) (int, error) {
+	sw, err := spreadsheet.New(format, w, exportSheetNames[dataset])
+	if err != nil {
+		return 0, err
+	}
+
+	var rows int
+	cid := uuidToPgtype(communityID)
+	switch dataset {
+	case ExportMembers:
+		rows, err = s.writeMembers(ctx, sw, cid)
+	case ExportMatches:
+		rows, err = s.writeMatches(ctx, sw, cid)
+	case ExportLeaderboard:
+		rows, err = s.writeLeaderboard(ctx, sw, cid)
+	case ExportAttendance:
+		rows, err = s.writeAttendance(ctx, sw, cid)
+	default:
+		err = ErrValidation.WithMessage("unknown dataset")
+	}
+	if err != nil {
+		return rows, err
+	}
+
+	return rows, sw.Close()
+}
+
+// GetJob returns a background export
+func (s *ExportService) GetJob(ctx context.Context, communityID, jobID uuid.UUID) (map[string]interface{}, error) {
+	job, err := s.repo.GetCommunityExport(ctx, repository.GetCommunityExportParams{
+		ID:          uuidToPgtype(jobID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound.WithMessage("Export not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get export: %w", err)
+	}
+	return s.exportResponse(ctx, job), nil
+}
+
+// ListJobs returns the community's recent background exports
+func (s *ExportService) ListJobs(ctx context.Context, communityID uuid.UUID) ([]map[string]interface{}, error) {
+	jobs, err := s.repo.ListCommunityExports(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, fmt.Errorf("list exports: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(jobs))
+	for _, j := range jobs {
+		result = append(result, s.exportResponse(ctx, j))
+	}
+	return result, nil
+}
+
+// run produces a background export into a temporary file, uploads it to
+// private storage and notifies the requester
+func (s *ExportService) run(job repository.CommunityExport, communityName, filename string) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+	defer cancel()
+
+	if err := s.repo.StartCommunityExport(ctx, job.ID); err != nil {
+		slog.Warn("failed to mark export running", "export_id", pgtypeUUIDToStringRequired(job.ID), "error", err)
+	}
+
+	key, rows, err := s.produce(ctx, job, filename)
+	if err != nil {
+		slog.Error("community export failed", "export_id", pgtypeUUIDToStringRequired(job.ID), "error", err)
+		if ferr := s.repo.FailCommunityExport(ctx, repository.FailCommunityExportParams{
+			Error: pgtype.Text{String: err.Error(), Valid: true},
+			ID:    job.ID,
+		}); ferr != nil {
+			slog.Warn("failed to mark export failed", "error", ferr)
+		}
+		s.notify(ctx, job, "Выгрузка не удалась",
+			fmt.Sprintf("Не удалось подготовить выгрузку «%s». Попробуйте ещё раз", communityName), "")
+		return
+	}
+
+	if err := s.repo.CompleteCommunityExport(ctx, repository.CompleteCommunityExportParams{
+		RowCount: pgtype.Int4{Int32: int32(rows), Valid: true},
+		FileUrl:  pgtype.Text{String: key, Valid: true},
+		ID:       job.ID,
+	}); err != nil {
+		slog.Warn("failed to mark export completed", "error", err)
+	}
+
+	url, err := s.storage.PresignGet(ctx, key, exportLinkTTL)
+	if err != nil {
+		slog.Warn("failed to sign export link", "export_id", pgtypeUUIDToStringRequired(job.ID), "error", err)
+	}
+
+	s.notify(ctx, job, "Выгрузка готова",
+		fmt.Sprintf("Файл с данными сообщества «%s» готов к скачиванию", communityName), url)
+}
+
+func (s *ExportService) produce(ctx context.Context, job repository.CommunityExport, filename string) (string, int, error) {
+	if s.storage == nil {
+		return "", 0, fmt.Errorf("storage service not configured")
+	}
+
+	tmp, err := os.CreateTemp("", "export-*."+job.Format)
+	if err != nil {
+		return "", 0, fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rows, err := s.Stream(ctx, tmp, uuid.UUID(job.CommunityID.Bytes), job.Dataset, job.Format)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", 0, fmt.Errorf("rewind temp file: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%s/%s/%s", pgtypeUUIDToStringRequired(job.CommunityID), pgtypeUUIDToStringRequired(job.ID), filename)
+	if err := s.storage.UploadPrivate(ctx, key, tmp, spreadsheet.ContentType(job.Format)); err != nil {
+		return "", 0, err
+	}
+	return key, rows, nil
+}
+
+func (s *ExportService) countRows(ctx context.Context, communityID pgtype.UUID, dataset string) (int, error) {
+	var (
+		count int64
+		err   error
+	)
+	switch dataset {
+	case ExportMembers, ExportLeaderboard:
+		count, err = s.repo.CountCommunityMembers(ctx, repository.CountCommunityMembersParams{CommunityID: communityID})
+	case ExportMatches:
+		count, err = s.repo.CountCommunityMatches(ctx, communityID)
+	case ExportAttendance:
+		count, err = s.repo.CountCommunityAttendance(ctx, repository.CountCommunityAttendanceParams{
+			CommunityID: communityID,
+			Now:         pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		})
+	}
+	if err != nil {
+		return 0, fmt.Errorf("count export rows: %w", err)
+	}
+	return int(count), nil
+}
+
+func (s *ExportService) writeMembers(ctx context.Context, w spreadsheet.Writer, communityID pgtype.UUID) (int, error) {
+	if err := w.WriteRow("ID", "Имя", "Фамилия", "NTRP", "Роль", "Статус", "Дата вступления",
+		"Рейтинг", "Игры", "Победы", "Поражения"); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		rows, err := s.repo.ExportCommunityMembers(ctx, repository.ExportCommunityMembersParams{
+			CommunityID:  communityID,
+			ResultOffset: int32(total),
+			ResultLimit:  exportBatchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("export members: %w", err)
+		}
+
+		for _, m := range rows {
+			if err := w.WriteRow(
+				pgtypeUUIDToStringRequired(m.UserID),
+				m.FirstName.String,
+				m.LastName.String,
+				optionalNumeric(m.NtrpLevel),
+				string(m.Role.CommunityRole),
+				string(m.Status.MemberStatus),
+				s.localTime(m.JoinedAt),
+				optionalNumeric(m.CommunityRating),
+				m.CommunityGamesCount.Int32,
+				m.CommunityWins.Int32,
+				m.CommunityLosses.Int32,
+			); err != nil {
+				return total, err
+			}
+		}
+
+		total += len(rows)
+		if len(rows) < exportBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *ExportService) writeMatches(ctx context.Context, w spreadsheet.Writer, communityID pgtype.UUID) (int, error) {
+	if err := w.WriteRow("ID", "Дата", "Турнир/событие", "Формат", "Игрок 1", "Игрок 2", "Счёт", "Победитель",
+		"Статус результата", "Рейтинг 1 до", "Рейтинг 1 после", "Рейтинг 2 до", "Рейтинг 2 после"); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		rows, err := s.repo.ExportCommunityMatches(ctx, repository.ExportCommunityMatchesParams{
+			CommunityID:  communityID,
+			ResultOffset: int32(total),
+			ResultLimit:  exportBatchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("export matches: %w", err)
+		}
+
+		for _, m := range rows {
+			player1 := fullName(m.Player1FirstName, m.Player1LastName)
+			player2 := fullName(m.Player2FirstName, m.Player2LastName)
+
+			winner := ""
+			switch {
+			case m.WinnerID.Valid && m.WinnerID == m.Player1ID:
+				winner = player1
+			case m.WinnerID.Valid && m.WinnerID == m.Player2ID:
+				winner = player2
+			}
+
+			if err := w.WriteRow(
+				pgtypeUUIDToStringRequired(m.ID),
+				s.localTime(m.PlayedAt),
+				m.EventTitle.String,
+				string(m.Composition),
+				player1,
+				player2,
+				formatScore(m.Score),
+				winner,
+				string(m.ResultStatus.ResultStatus),
+				optionalNumeric(m.Player1RatingBefore),
+				optionalNumeric(m.Player1RatingAfter),
+				optionalNumeric(m.Player2RatingBefore),
+				optionalNumeric(m.Player2RatingAfter),
+			); err != nil {
+				return total, err
+			}
+		}
+
+		total += len(rows)
+		if len(rows) < exportBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *ExportService) writeLeaderboard(ctx context.Context, w spreadsheet.Writer, communityID pgtype.UUID) (int, error) {
+	if err := w.WriteRow("Место", "ID", "Имя", "Фамилия", "Рейтинг", "Игры", "Победы", "Поражения", "Процент побед"); err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		rows, err := s.repo.GetCommunityLeaderboard(ctx, repository.GetCommunityLeaderboardParams{
+			CommunityID:         communityID,
+			CommunityGamesCount: pgtype.Int4{Int32: 0, Valid: true},
+			Limit:               exportBatchSize,
+			Offset:              int32(total),
+		})
+		if err != nil {
+			return total, fmt.Errorf("export leaderboard: %w", err)
+		}
+
+		for i, r := range rows {
+			if err := w.WriteRow(
+				total+i+1,
+				pgtypeUUIDToStringRequired(r.UserID),
+				r.FirstName.String,
+				r.LastName.String,
+				optionalNumeric(r.CommunityRating),
+				r.CommunityGamesCount.Int32,
+				r.CommunityWins.Int32,
+				r.CommunityLosses.Int32,
+				float64(r.WinRate),
+			); err != nil {
+				return total, err
+			}
+		}
+
+		total += len(rows)
+		if len(rows) < exportBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *ExportService) writeAttendance(ctx context.Context, w spreadsheet.Writer, communityID pgtype.UUID) (int, error) {
+	if err := w.WriteRow("ID события", "Событие", "Начало", "ID участника", "Имя", "Фамилия", "Статус"); err != nil {
+		return 0, err
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	total := 0
+	for {
+		rows, err := s.repo.ExportCommunityAttendance(ctx, repository.ExportCommunityAttendanceParams{
+			CommunityID:  communityID,
+			Now:          now,
+			ResultOffset: int32(total),
+			ResultLimit:  exportBatchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("export attendance: %w", err)
+		}
+
+		for _, a := range rows {
+			if err := w.WriteRow(
+				pgtypeUUIDToStringRequired(a.EventID),
+				a.Title,
+				s.localTime(a.StartTime),
+				pgtypeUUIDToStringRequired(a.UserID),
+				a.FirstName.String,
+				a.LastName.String,
+				string(a.Status.ParticipantStatus),
+			); err != nil {
+				return total, err
+			}
+		}
+
+		total += len(rows)
+		if len(rows) < exportBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *ExportService) localTime(t pgtype.Timestamptz) any {
+	if !t.Valid {
+		return nil
+	}
+	return t.Time.In(s.location)
+}
+
+func (s *ExportService) notify(ctx context.Context, job repository.CommunityExport, title, body, url string) {
+	if !job.RequestedBy.Valid {
+		return
+	}
+
+	data := map[string]any{
+		"community_id": pgtypeUUIDToStringRequired(job.CommunityID),
+		"export_id":    pgtypeUUIDToStringRequired(job.ID),
+	}
+	if url != "" {
+		data["url"] = url
+	}
+
+	_, err := s.notifications.Create(ctx, uuid.UUID(job.RequestedBy.Bytes),
+		string(repository.NotificationTypeExportReady), title, body, data)
+	if err != nil {
+		slog.Warn("failed to send export notification", "error", err)
+	}
+}
+
+// exportResponse renders a job; file_url is a freshly signed link to the file
+func (s *ExportService) exportResponse(ctx context.Context, j repository.CommunityExport) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(j.ID),
+		"dataset":      j.Dataset,
+		"format":       j.Format,
+		"status":       string(j.Status),
+		"row_count":    nil,
+		"file_url":     nil,
+		"error":        nil,
+		"created_at":   j.CreatedAt.Time,
+		"completed_at": nil,
+	}
+	if j.RowCount.Valid {
+		result["row_count"] = j.RowCount.Int32
+	}
+	if j.FileUrl.Valid && s.storage != nil {
+		url, err := s.storage.PresignGet(ctx, j.FileUrl.String, exportLinkTTL)
+		if err != nil {
+			slog.Warn("failed to sign export link", "export_id", pgtypeUUIDToStringRequired(j.ID), "error", err)
+		} else {
+			result["file_url"] = url
+		}
+	}
+	if j.Error.Valid {
+		result["error"] = j.Error.String
+	}
+	if j.CompletedAt.Valid {
+		result["completed_at"] = j.CompletedAt.Time
+	}
+	return result
+}
+
+// exportFilename builds e.g. "astana-tennis-members-2025-06-01.xlsx"
+func exportFilename(slug, dataset, format string, now time.Time) string {
+	if slug == "" {
+		slug = "community"
+	}
+	return fmt.Sprintf("%s-%s-%s.%s", slug, dataset, now.Format(dateLayout), format)
+}
+
+// formatScore renders a stored score as "6:4 3:6 7:6(5)"
+func formatScore(raw []byte) string {
+	var sets []SetScore
+	if len(raw) == 0 || json.Unmarshal(raw, &sets) != nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(sets))
+	for _, set := range sets {
+		part := fmt.Sprintf("%d:%d", set.Player1, set.Player2)
+		if set.Tiebreak != nil {
+			part += fmt.Sprintf("(%d)", *set.Tiebreak)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+func fullName(first, last pgtype.Text) string {
+	return strings.TrimSpace(first.String + " " + last.String)
+}
+
+// optionalNumeric returns nil for NULL so the cell stays empty
+func optionalNumeric(n pgtype.Numeric) any {
+	if !n.Valid {
+		return nil
+	}
+	return numericToFloat(n)
+}
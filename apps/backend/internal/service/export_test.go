@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestFormatScore(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`[{"p1":6,"p2":4},{"p1":3,"p2":6},{"p1":7,"p2":6,"tiebreak":5}]`, "6:4 3:6 7:6(5)"},
+		{`[]`, ""},
+		{``, ""},
+		{`not json`, ""},
+	}
+	for _, tt := range tests {
+		if got := formatScore([]byte(tt.raw)); got != tt.want {
+			t.Errorf("formatScore(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestExportFilename(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	if got := exportFilename("astana-tennis", ExportMembers, "xlsx", now); got != "astana-tennis-members-2025-06-01.xlsx" {
+		t.Errorf("unexpected filename %q", got)
+	}
+	if got := exportFilename("", ExportMatches, "csv", now); got != "community-matches-2025-06-01.csv" {
+		t.Errorf("unexpected filename %q", got)
+	}
+}
+
+func TestFullName(t *testing.T) {
+	first := pgtype.Text{String: "Алма", Valid: true}
+	if got := fullName(first, pgtype.Text{}); got != "Алма" {
+		t.Errorf("fullName() = %q, want %q", got, "Алма")
+	}
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeHandler answers one query: the rows it returns are scanned in column
+// order, and for :exec/:execrows queries their count is the affected rows
+type fakeHandler func(args []any) ([][]any, error)
+
+// fakeCall records a query the service ran
+type fakeCall struct {
+	Name string
+	SQL  string
+	Args []any
+}
+
+// fakeDB is a repository.DBTX that answers queries by their sqlc name, so
+// services can be tested without a database. Queries without a handler fail.
+type fakeDB struct {
+	mu       sync.Mutex
+	handlers map[string]fakeHandler
+	calls    []fakeCall
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{handlers: map[string]fakeHandler{}}
+}
+
+// queries returns a repository backed by the fake
+func (db *fakeDB) queries() *repository.Queries {
+	return repository.New(db)
+}
+
+// on registers the handler of a query
+func (db *fakeDB) on(name string, h fakeHandler) {
+	db.handlers[name] = h
+}
+
+// rows registers a query that always returns the given rows
+func (db *fakeDB) rows(name string, rows ...[]any) {
+	db.on(name, func([]any) ([][]any, error) { return rows, nil })
+}
+
+// called returns the calls of one query in order
+func (db *fakeDB) called(name string) []fakeCall {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var calls []fakeCall
+	for _, c := range db.calls {
+		if c.Name == name {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// names returns the names of all queries run, in order
+func (db *fakeDB) names() []string {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	names := make([]string, 0, len(db.calls))
+	for _, c := range db.calls {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func (db *fakeDB) run(sql string, args []any) ([][]any, error) {
+	name := sql
+	if i := strings.Index(sql, "-- name: "); i >= 0 {
+		name = strings.Fields(sql[i+len("-- name: "):])[0]
+	}
+
+	db.mu.Lock()
+	db.calls = append(db.calls, fakeCall{Name: name, SQL: sql, Args: args})
+	h, ok := db.handlers[name]
+	db.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("fakeDB: unexpected query %s", name)
+	}
+	return h(args)
+}
+
+func (db *fakeDB) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	rows, err := db.run(sql, args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", len(rows))), nil
+}
+
+func (db *fakeDB) Query(_ context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := db.run(sql, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{rows: rows, pos: -1}, nil
+}
+
+func (db *fakeDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	rows, err := db.run(sql, args)
+	if err != nil {
+		return fakeRow{err: err}
+	}
+	if len(rows) == 0 {
+		return fakeRow{err: pgx.ErrNoRows}
+	}
+	return fakeRow{values: rows[0]}
+}
+
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanFakeValues(r.values, dest)
+}
+
+type fakeRows struct {
+	rows [][]any
+	pos  int
+	err  error
+}
+
+func (r *fakeRows) Close()                                       {}
+func (r *fakeRows) Err() error                                   { return r.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if err := scanFakeValues(r.rows[r.pos], dest); err != nil {
+		r.err = err
+		return err
+	}
+	return nil
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.rows[r.pos], nil
+}
+
+// scanFakeValues assigns each value to its destination; nil leaves the zero
+// value, so handlers only need to fill in the columns a test cares about. A
+// row may also be a single model or Row struct, whose fields are the columns.
+func scanFakeValues(values, dest []any) error {
+	if len(values) == 1 && len(dest) > 1 {
+		if s := reflect.ValueOf(values[0]); s.Kind() == reflect.Struct && s.NumField() == len(dest) {
+			values = make([]any, s.NumField())
+			for i := range values {
+				values[i] = s.Field(i).Interface()
+			}
+		}
+	}
+	if len(values) != len(dest) {
+		return fmt.Errorf("fakeDB: %d values for %d columns", len(values), len(dest))
+	}
+	for i, v := range values {
+		target := reflect.ValueOf(dest[i]).Elem()
+		if v == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+		value := reflect.ValueOf(v)
+		if !value.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("fakeDB: column %d: cannot scan %T into %s", i, v, target.Type())
+		}
+		target.Set(value)
+	}
+	return nil
+}
+
+// Begin starts a fake transaction on the same handlers; commits and
+// rollbacks are recorded as COMMIT and ROLLBACK calls
+func (db *fakeDB) Begin(context.Context) (pgx.Tx, error) {
+	return &fakeTx{db: db}, nil
+}
+
+// committed reports whether a transaction was committed
+func (db *fakeDB) committed() bool {
+	return len(db.called("COMMIT")) > 0
+}
+
+type fakeTx struct {
+	db   *fakeDB
+	done bool
+}
+
+func (tx *fakeTx) record(name string) {
+	tx.db.mu.Lock()
+	tx.db.calls = append(tx.db.calls, fakeCall{Name: name})
+	tx.db.mu.Unlock()
+}
+
+func (tx *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) { return tx.db.Begin(ctx) }
+
+func (tx *fakeTx) Commit(context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	tx.record("COMMIT")
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	tx.record("ROLLBACK")
+	return nil
+}
+
+func (tx *fakeTx) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, fmt.Errorf("fakeTx: CopyFrom is not supported")
+}
+
+func (tx *fakeTx) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults { return nil }
+func (tx *fakeTx) LargeObjects() pgx.LargeObjects                         { return pgx.LargeObjects{} }
+func (tx *fakeTx) Conn() *pgx.Conn                                        { return nil }
+
+func (tx *fakeTx) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
+	return nil, fmt.Errorf("fakeTx: Prepare is not supported")
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx *fakeTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx *fakeTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
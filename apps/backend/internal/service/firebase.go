@@ -80,3 +80,30 @@ func (f *FirebaseService) SendToUser(_ context.Context, userID uuid.UUID, title,
 	)
 	return nil
 }
+
+// SubscribeToTopic subscribes the user's devices to a topic. For now it only
+// logs; a real implementation subscribes each of the user's device tokens.
+func (f *FirebaseService) SubscribeToTopic(_ context.Context, userID uuid.UUID, topic string) error {
+	if f == nil {
+		return nil
+	}
+
+	f.logger.Info("mock topic subscription",
+		"user_id", userID,
+		"topic", topic,
+	)
+	return nil
+}
+
+// UnsubscribeFromTopic unsubscribes the user's devices from a topic. For now it only logs.
+func (f *FirebaseService) UnsubscribeFromTopic(_ context.Context, userID uuid.UUID, topic string) error {
+	if f == nil {
+		return nil
+	}
+
+	f.logger.Info("mock topic unsubscription",
+		"user_id", userID,
+		"topic", topic,
+	)
+	return nil
+}
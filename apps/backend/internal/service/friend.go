@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Friendship states as seen by the current user
+const (
+	FriendshipNone            = "none"
+	FriendshipFriends         = "friends"
+	FriendshipRequestSent     = "request_sent"
+	FriendshipRequestReceived = "request_received"
+)
+
+const (
+	// friendSuggestionCandidates is how many candidates are scored per request
+	friendSuggestionCandidates = 200
+	friendSuggestionsDefault   = 20
+	friendSuggestionsMax       = 50
+)
+
+// Suggestion weights: playing someone says more than sharing a community
+const (
+	suggestWeightMatch     = 5
+	suggestWeightEvent     = 3
+	suggestWeightMutual    = 2
+	suggestWeightCommunity = 1
+)
+
+// FriendService manages friend requests and friendships. A request is a
+// pending friends row from the requester; accepting it stores the friendship
+// in both directions.
+type FriendService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+}
+
+// NewFriendService creates a new FriendService
+func NewFriendService(repo *repository.Queries, pool txStarter, notifications *NotificationService) *FriendService {
+	return &FriendService{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+	}
+}
+
+// FriendSuggestion is a suggested friend and why they were suggested
+type FriendSuggestion struct {
+	User              map[string]interface{} `json:"user"`
+	MutualFriends     int64                  `json:"mutual_friends"`
+	SharedCommunities int64                  `json:"shared_communities"`
+	MatchesPlayed     int64                  `json:"matches_played"`
+	SharedEvents      int64                  `json:"shared_events"`
+	Reasons           []string               `json:"reasons"`
+	score             int64
+}
+
+// List returns the user's friends
+func (s *FriendService) List(ctx context.Context, userID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	rows, err := s.repo.ListFriends(ctx, repository.ListFriendsParams{
+		UserID:       uuidToPgtype(userID),
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list friends: %w", err)
+	}
+
+	total, err := s.repo.CountFriends(ctx, uuidToPgtype(userID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count friends: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, map[string]interface{}{
+			"id":             pgtypeUUIDToStringRequired(r.ID),
+			"user":           friendUser(r.FriendID, r.FirstName, r.LastName, r.AvatarUrl, r.NtrpLevel),
+			"mutual_friends": r.MutualFriends,
+			"added_at":       r.AcceptedAt.Time,
+		})
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// ListRequests returns pending requests sent to the user ("incoming") or by the user ("outgoing")
+func (s *FriendService) ListRequests(ctx context.Context, userID uuid.UUID, direction string, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	var (
+		rows  []repository.ListIncomingFriendRequestsRow
+		total int64
+		err   error
+	)
+	switch direction {
+	case "", "incoming":
+		rows, err = s.repo.ListIncomingFriendRequests(ctx, repository.ListIncomingFriendRequestsParams{
+			UserID:       uuidToPgtype(userID),
+			ResultOffset: int32(offset),
+			ResultLimit:  int32(perPage),
+		})
+		if err == nil {
+			total, err = s.repo.CountIncomingFriendRequests(ctx, uuidToPgtype(userID))
+		}
+	case "outgoing":
+		var outgoing []repository.ListOutgoingFriendRequestsRow
+		outgoing, err = s.repo.ListOutgoingFriendRequests(ctx, repository.ListOutgoingFriendRequestsParams{
+			UserID:       uuidToPgtype(userID),
+			ResultOffset: int32(offset),
+			ResultLimit:  int32(perPage),
+		})
+		for _, r := range outgoing {
+			rows = append(rows, repository.ListIncomingFriendRequestsRow(r))
+		}
+		if err == nil {
+			total, err = s.repo.CountOutgoingFriendRequests(ctx, uuidToPgtype(userID))
+		}
+	default:
+		return nil, nil, ErrValidation.WithMessage("direction must be incoming or outgoing")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("list friend requests: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, map[string]interface{}{
+			"id":             pgtypeUUIDToStringRequired(r.ID),
+			"user":           friendUser(r.OtherID, r.FirstName, r.LastName, r.AvatarUrl, r.NtrpLevel),
+			"mutual_friends": r.MutualFriends,
+			"requested_at":   r.CreatedAt.Time,
+		})
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Add sends a friend request. If the other user has already asked, the
+// request is accepted instead. Returns the resulting friendship status.
+func (s *FriendService) Add(ctx context.Context, userID, targetID uuid.UUID) (string, error) {
+	if userID == targetID {
+		return "", ErrValidation.WithMessage("You cannot add yourself as a friend")
+	}
+
+	target, err := s.repo.GetUserByID(ctx, uuidToPgtype(targetID))
+	if err == pgx.ErrNoRows || (err == nil && target.Status.UserStatus != repository.UserStatusActive) {
+		return "", ErrUserNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("get user: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	// Crossed requests sent at the same time must see each other, so the
+	// second one is accepted rather than stored as another pending request
+	if err := qtx.LockFriendPair(ctx, repository.LockFriendPairParams{
+		UserID:   uuidToPgtype(userID),
+		FriendID: uuidToPgtype(targetID),
+	}); err != nil {
+		return "", fmt.Errorf("lock friend pair: %w", err)
+	}
+
+	status, err := friendshipStatus(ctx, qtx, userID, targetID)
+	if err != nil {
+		return "", err
+	}
+
+	switch status {
+	case FriendshipFriends:
+		return "", ErrAlreadyFriends
+	case FriendshipRequestSent:
+		return "", ErrAlreadyExists.WithMessage("Friend request already sent")
+	case FriendshipRequestReceived:
+		if err := acceptFriendRequest(ctx, qtx, targetID, userID); err != nil {
+			return "", err
+		}
+	default:
+		_, err := qtx.CreateFriendRequest(ctx, repository.CreateFriendRequestParams{
+			UserID:   uuidToPgtype(userID),
+			FriendID: uuidToPgtype(targetID),
+		})
+		if isUniqueViolation(err) {
+			return "", ErrAlreadyExists.WithMessage("Friend request already sent")
+		}
+		if err != nil {
+			return "", fmt.Errorf("create friend request: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if status == FriendshipRequestReceived {
+		s.notify(ctx, targetID, userID, repository.NotificationTypeFriendAccepted, "Заявка в друзья принята", "теперь у вас в друзьях")
+		return FriendshipFriends, nil
+	}
+	s.notify(ctx, targetID, userID, repository.NotificationTypeFriendRequest, "Новая заявка в друзья", "хочет добавить вас в друзья")
+	return FriendshipRequestSent, nil
+}
+
+// Accept accepts a pending request from requesterID
+func (s *FriendService) Accept(ctx context.Context, userID, requesterID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := acceptFriendRequest(ctx, s.repo.WithTx(tx), requesterID, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, requesterID, userID, repository.NotificationTypeFriendAccepted, "Заявка в друзья принята", "теперь у вас в друзьях")
+	return nil
+}
+
+// Decline rejects a pending request from requesterID
+func (s *FriendService) Decline(ctx context.Context, userID, requesterID uuid.UUID) error {
+	deleted, err := s.repo.DeleteFriendRequest(ctx, repository.DeleteFriendRequestParams{
+		UserID:   uuidToPgtype(requesterID),
+		FriendID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("decline friend request: %w", err)
+	}
+	if deleted == 0 {
+		return ErrNotFound.WithMessage("Friend request not found")
+	}
+	return nil
+}
+
+// Remove ends a friendship, or withdraws a request the user sent
+func (s *FriendService) Remove(ctx context.Context, userID, targetID uuid.UUID) error {
+	removed, err := s.repo.DeleteFriendship(ctx, repository.DeleteFriendshipParams{
+		UserID:   uuidToPgtype(userID),
+		FriendID: uuidToPgtype(targetID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete friendship: %w", err)
+	}
+	if removed > 0 {
+		return nil
+	}
+
+	withdrawn, err := s.repo.DeleteFriendRequest(ctx, repository.DeleteFriendRequestParams{
+		UserID:   uuidToPgtype(userID),
+		FriendID: uuidToPgtype(targetID),
+	})
+	if err != nil {
+		return fmt.Errorf("withdraw friend request: %w", err)
+	}
+	if withdrawn == 0 {
+		return ErrNotFound.WithMessage("User is not in your friends")
+	}
+	return nil
+}
+
+// Check returns the friendship status with another user and the number of mutual friends
+func (s *FriendService) Check(ctx context.Context, userID, targetID uuid.UUID) (map[string]interface{}, error) {
+	status, err := friendshipStatus(ctx, s.repo, userID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutual, err := s.repo.CountMutualFriends(ctx, repository.CountMutualFriendsParams{
+		UserID:  uuidToPgtype(userID),
+		OtherID: uuidToPgtype(targetID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count mutual friends: %w", err)
+	}
+
+	return map[string]interface{}{
+		"is_friend":      status == FriendshipFriends,
+		"status":         status,
+		"mutual_friends": mutual,
+	}, nil
+}
+
+// Suggestions returns people the user may know: members of their communities,
+// past opponents and players from the same completed events, ranked by how
+// much they have in common. Friends and pending requests are excluded.
+func (s *FriendService) Suggestions(ctx context.Context, userID uuid.UUID, limit int) ([]FriendSuggestion, error) {
+	if limit < 1 || limit > friendSuggestionsMax {
+		limit = friendSuggestionsDefault
+	}
+
+	rows, err := s.repo.ListFriendSuggestions(ctx, repository.ListFriendSuggestionsParams{
+		UserID:         uuidToPgtype(userID),
+		CandidateLimit: friendSuggestionCandidates,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list friend suggestions: %w", err)
+	}
+
+	suggestions := rankFriendSuggestions(rows)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// notify tells userID about a friend request or acceptance by actorID (best-effort)
+func (s *FriendService) notify(ctx context.Context, userID, actorID uuid.UUID, notificationType repository.NotificationType, title, action string) {
+	if s.notifications == nil {
+		return
+	}
+
+	actor, err := s.repo.GetUserByID(ctx, uuidToPgtype(actorID))
+	if err != nil {
+		slog.Warn("failed to load user for friend notification", "user_id", actorID, "error", err)
+		return
+	}
+
+	body := fmt.Sprintf("%s %s", fullName(actor.FirstName, actor.LastName), action)
+	data := map[string]any{"user_id": actorID.String()}
+	if _, err := s.notifications.Create(ctx, userID, string(notificationType), title, body, data); err != nil {
+		slog.Warn("failed to create friend notification", "user_id", userID, "error", err)
+	}
+}
+
+// friendshipStatus looks at both directions of the friends table
+func friendshipStatus(ctx context.Context, q *repository.Queries, userID, otherID uuid.UUID) (string, error) {
+	outgoing, err := q.GetFriendLink(ctx, repository.GetFriendLinkParams{
+		UserID:   uuidToPgtype(userID),
+		FriendID: uuidToPgtype(otherID),
+	})
+	if err == nil {
+		if outgoing.Status == repository.FriendStatusAccepted {
+			return FriendshipFriends, nil
+		}
+		return FriendshipRequestSent, nil
+	}
+	if err != pgx.ErrNoRows {
+		return "", fmt.Errorf("get friend link: %w", err)
+	}
+
+	incoming, err := q.GetFriendLink(ctx, repository.GetFriendLinkParams{
+		UserID:   uuidToPgtype(otherID),
+		FriendID: uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return FriendshipNone, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get friend link: %w", err)
+	}
+	if incoming.Status == repository.FriendStatusPending {
+		return FriendshipRequestReceived, nil
+	}
+	// A one-way accepted row is a friendship missing its reverse row
+	return FriendshipFriends, nil
+}
+
+// acceptFriendRequest accepts requesterID's pending request to userID
+func acceptFriendRequest(ctx context.Context, q *repository.Queries, requesterID, userID uuid.UUID) error {
+	accepted, err := q.AcceptFriendRequest(ctx, repository.AcceptFriendRequestParams{
+		UserID:   uuidToPgtype(requesterID),
+		FriendID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("accept friend request: %w", err)
+	}
+	if accepted == 0 {
+		return ErrNotFound.WithMessage("Friend request not found")
+	}
+
+	if err := q.AddAcceptedFriend(ctx, repository.AddAcceptedFriendParams{
+		UserID:   uuidToPgtype(userID),
+		FriendID: uuidToPgtype(requesterID),
+	}); err != nil {
+		return fmt.Errorf("add friend: %w", err)
+	}
+	return nil
+}
+
+// rankFriendSuggestions scores candidates by what they share with the user
+// and orders them best first
+func rankFriendSuggestions(rows []repository.ListFriendSuggestionsRow) []FriendSuggestion {
+	suggestions := make([]FriendSuggestion, 0, len(rows))
+	for _, r := range rows {
+		sg := FriendSuggestion{
+			User:              friendUser(r.UserID, r.FirstName, r.LastName, r.AvatarUrl, r.NtrpLevel),
+			MutualFriends:     r.MutualFriends,
+			SharedCommunities: r.SharedCommunities,
+			MatchesPlayed:     r.MatchesPlayed,
+			SharedEvents:      r.SharedEvents,
+			Reasons:           []string{},
+			score: r.MatchesPlayed*suggestWeightMatch +
+				r.SharedEvents*suggestWeightEvent +
+				r.MutualFriends*suggestWeightMutual +
+				r.SharedCommunities*suggestWeightCommunity,
+		}
+		if r.MatchesPlayed > 0 {
+			sg.Reasons = append(sg.Reasons, "past_opponent")
+		}
+		if r.SharedEvents > 0 {
+			sg.Reasons = append(sg.Reasons, "shared_event")
+		}
+		if r.MutualFriends > 0 {
+			sg.Reasons = append(sg.Reasons, "mutual_friends")
+		}
+		if r.SharedCommunities > 0 {
+			sg.Reasons = append(sg.Reasons, "shared_community")
+		}
+		suggestions = append(suggestions, sg)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].score > suggestions[j].score
+	})
+	return suggestions
+}
+
+func friendUser(id pgtype.UUID, firstName, lastName, avatarURL pgtype.Text, ntrp pgtype.Numeric) map[string]interface{} {
+	user := map[string]interface{}{
+		"id":         pgtypeUUIDToStringRequired(id),
+		"first_name": firstName.String,
+		"last_name":  lastName.String,
+		"avatar_url": avatarURL.String,
+	}
+	if ntrp.Valid {
+		user["ntrp_level"] = numericToFloat(ntrp)
+	}
+	return user
+}
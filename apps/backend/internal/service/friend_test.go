@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRankFriendSuggestions(t *testing.T) {
+	row := func(name string, communities, matches, events, mutual int64) repository.ListFriendSuggestionsRow {
+		return repository.ListFriendSuggestionsRow{
+			UserID:            uuidToPgtype(uuid.New()),
+			FirstName:         pgtype.Text{String: name, Valid: true},
+			SharedCommunities: communities,
+			MatchesPlayed:     matches,
+			SharedEvents:      events,
+			MutualFriends:     mutual,
+		}
+	}
+
+	ranked := rankFriendSuggestions([]repository.ListFriendSuggestionsRow{
+		row("community", 3, 0, 0, 0),
+		row("opponent", 1, 1, 0, 0),
+		row("mutual", 1, 0, 0, 3),
+		row("event", 0, 0, 1, 0),
+	})
+
+	var order []string
+	for _, s := range ranked {
+		order = append(order, s.User["first_name"].(string))
+	}
+	want := []string{"mutual", "opponent", "community", "event"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+
+	if got := ranked[1].Reasons; !reflect.DeepEqual(got, []string{"past_opponent", "shared_community"}) {
+		t.Errorf("opponent reasons = %v", got)
+	}
+	if got := ranked[3].Reasons; !reflect.DeepEqual(got, []string{"shared_event"}) {
+		t.Errorf("event reasons = %v", got)
+	}
+}
+
+func TestAddFriendLocksPairBeforeCheckingStatus(t *testing.T) {
+	userID, targetID := uuid.New(), uuid.New()
+	db := newFakeDB()
+	db.rows("GetUserByID", []any{repository.User{
+		ID:     uuidToPgtype(targetID),
+		Status: repository.NullUserStatus{UserStatus: repository.UserStatusActive, Valid: true},
+	}})
+	db.rows("LockFriendPair", []any{})
+	db.rows("GetFriendLink")
+	db.on("CreateFriendRequest", func(args []any) ([][]any, error) {
+		return [][]any{{repository.Friend{
+			ID:       uuidToPgtype(uuid.New()),
+			UserID:   args[0].(pgtype.UUID),
+			FriendID: args[1].(pgtype.UUID),
+			Status:   repository.FriendStatusPending,
+		}}}, nil
+	})
+
+	service := NewFriendService(db.queries(), db, nil)
+	status, err := service.Add(context.Background(), userID, targetID)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if status != FriendshipRequestSent || !db.committed() {
+		t.Fatalf("status = %q, committed = %v, want a committed request", status, db.committed())
+	}
+
+	names := db.names()
+	lock := -1
+	for i, name := range names {
+		if name == "LockFriendPair" {
+			lock = i
+			break
+		}
+	}
+	for i, name := range names {
+		if name == "GetFriendLink" && (lock < 0 || i < lock) {
+			t.Fatalf("queries = %v, want the pair locked before the links are read", names)
+		}
+	}
+	if args := db.called("LockFriendPair")[0].Args; args[0] != uuidToPgtype(userID) || args[1] != uuidToPgtype(targetID) {
+		t.Errorf("locked %v, want the requesting pair", args)
+	}
+}
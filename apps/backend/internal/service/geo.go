@@ -0,0 +1,61 @@
+package service
+
+import "math"
+
+const (
+	defaultNearbyRadiusKm = 5.0
+	maxNearbyRadiusKm     = 50.0
+	defaultNearbyLimit    = 50
+	maxNearbyLimit        = 100
+
+	// locationPrecision rounds stored player locations to 0.01° (about 1 km)
+	locationPrecision = 100
+	// playerDistanceStepKm is the granularity of distances shown for players
+	playerDistanceStepKm = 0.5
+)
+
+// NearbyInput is a "near me" search around a point
+type NearbyInput struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+	Limit    int
+}
+
+// normalize validates the point and applies radius/limit defaults
+func (in *NearbyInput) normalize() error {
+	if in.Lat < -90 || in.Lat > 90 || in.Lng < -180 || in.Lng > 180 {
+		return ErrValidation.WithMessage("lat must be between -90 and 90, lng between -180 and 180")
+	}
+	if in.RadiusKm <= 0 {
+		in.RadiusKm = defaultNearbyRadiusKm
+	}
+	if in.RadiusKm > maxNearbyRadiusKm {
+		return ErrValidation.WithMessage("radius_km must not exceed 50")
+	}
+	if in.Limit < 1 || in.Limit > maxNearbyLimit {
+		in.Limit = defaultNearbyLimit
+	}
+	return nil
+}
+
+func (in NearbyInput) radiusMeters() float64 {
+	return in.RadiusKm * 1000
+}
+
+// roundCoordinate reduces a coordinate to the stored privacy precision
+func roundCoordinate(v float64) float64 {
+	return math.Round(v*locationPrecision) / locationPrecision
+}
+
+// distanceKm converts meters to kilometers with one decimal
+func distanceKm(meters float64) float64 {
+	return math.Round(meters/100) / 10
+}
+
+// approximateDistanceKm rounds a player distance up to the next 0.5 km step,
+// so exact positions can't be triangulated from several searches.
+func approximateDistanceKm(meters float64) float64 {
+	km := meters / 1000
+	return math.Max(playerDistanceStepKm, math.Ceil(km/playerDistanceStepKm)*playerDistanceStepKm)
+}
@@ -0,0 +1,42 @@
+package service
+
+import "testing"
+
+func TestApproximateDistanceKm(t *testing.T) {
+	tests := []struct {
+		meters   float64
+		expected float64
+	}{
+		{0, 0.5},
+		{120, 0.5},
+		{500, 0.5},
+		{501, 1.0},
+		{2340, 2.5},
+	}
+
+	for _, tt := range tests {
+		if got := approximateDistanceKm(tt.meters); got != tt.expected {
+			t.Errorf("approximateDistanceKm(%v) = %v, expected %v", tt.meters, got, tt.expected)
+		}
+	}
+}
+
+func TestNearbyInput_Normalize(t *testing.T) {
+	in := NearbyInput{Lat: 51.128, Lng: 71.43}
+	if err := in.normalize(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if in.RadiusKm != defaultNearbyRadiusKm || in.Limit != defaultNearbyLimit {
+		t.Errorf("Expected defaults, got radius=%v limit=%v", in.RadiusKm, in.Limit)
+	}
+
+	in = NearbyInput{Lat: 51.128, Lng: 71.43, RadiusKm: 500}
+	if err := in.normalize(); err == nil {
+		t.Error("Expected error for radius above the maximum")
+	}
+
+	in = NearbyInput{Lat: 95, Lng: 71.43}
+	if err := in.normalize(); err == nil {
+		t.Error("Expected error for latitude out of range")
+	}
+}
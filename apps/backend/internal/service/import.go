@@ -0,0 +1,927 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/pkg/elo"
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ImportMembers = "members"
+	ImportMatches = "matches"
+
+	// importMaxRows bounds the data rows of a single upload
+	importMaxRows = 5000
+	importMaxSets = 5
+
+	// importInviteTTL is how long invites sent to registered accounts stay valid
+	importInviteTTL = 14 * 24 * time.Hour
+)
+
+// importColumns are the required CSV header columns per import kind
+var importColumns = map[string][]string{
+	ImportMembers: {"phone", "first_name"},
+	ImportMatches: {"date", "player1_phone", "player2_phone", "score"},
+}
+
+// importDateLayouts are the accepted match date formats
+var importDateLayouts = []string{dateLayout, "02.01.2006"}
+
+// setScoreRegex matches one set such as 6:4, 6-4 or 7:6(5)
+var setScoreRegex = regexp.MustCompile(`^(\d{1,2})[:\-](\d{1,2})(?:\((\d{1,2})\))?$`)
+
+// ImportRowError is a validation error of one CSV row. Row is the line
+// number in the file, the header being line 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportedMember is a validated member row
+type ImportedMember struct {
+	Row       int      `json:"row"`
+	Phone     string   `json:"phone"`
+	FirstName string   `json:"first_name"`
+	LastName  string   `json:"last_name,omitempty"`
+	NTRPLevel *float64 `json:"ntrp_level,omitempty"`
+}
+
+// ImportedMatch is a validated match row. Score is from player 1's side.
+type ImportedMatch struct {
+	Row          int        `json:"row"`
+	Date         string     `json:"date"`
+	Player1Phone string     `json:"player1_phone"`
+	Player2Phone string     `json:"player2_phone"`
+	Score        []SetScore `json:"score"`
+	Winner       int        `json:"winner"`
+}
+
+// ImportService imports members and historical matches of clubs moving onto
+// the platform. An upload is validated into a stored preview; committing it
+// creates placeholder users for unknown phones, adds them to the community and
+// records matches as confirmed. Placeholders are claimed on the first OTP login.
+// Phones of registered accounts are never enrolled directly: their owners get
+// a single-use invite and decide themselves.
+type ImportService struct {
+	repo     *repository.Queries
+	pool     txStarter
+	location *time.Location
+}
+
+// NewImportService creates a new ImportService
+func NewImportService(repo *repository.Queries, pool txStarter) *ImportService {
+	return &ImportService{
+		repo:     repo,
+		pool:     pool,
+		location: almatyLocation(),
+	}
+}
+
+// Preview parses and validates a CSV upload and stores it for a later commit.
+// Match rows may only reference active members, so members are imported first.
+func (s *ImportService) Preview(ctx context.Context, actorID, communityID uuid.UUID, kind string, r io.Reader) (map[string]interface{}, error) {
+	if _, ok := importColumns[kind]; !ok {
+		return nil, ErrValidation.WithMessage("kind must be members or matches")
+	}
+
+	community, err := s.repo.GetCommunityActiveState(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+	if !community.IsActive.Bool {
+		return nil, ErrForbidden.WithMessage("Community is deactivated")
+	}
+
+	header, records, err := readImportCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range importColumns[kind] {
+		if _, ok := header[col]; !ok {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("Missing column: %s", col))
+		}
+	}
+	if len(records) == 0 {
+		return nil, ErrValidation.WithMessage("File has no data rows")
+	}
+	if len(records) > importMaxRows {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("File has more than %d rows", importMaxRows))
+	}
+
+	var rows any
+	var rowErrors []ImportRowError
+	switch kind {
+	case ImportMembers:
+		rows, rowErrors = parseMemberRows(header, records)
+	case ImportMatches:
+		members, err := s.repo.ListCommunityMemberPhones(ctx, community.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list member phones: %w", err)
+		}
+		phones := make(map[string]bool, len(members))
+		for _, m := range members {
+			phones[m.Phone] = true
+		}
+		today := time.Now().In(s.location).Format(dateLayout)
+		rows, rowErrors = parseMatchRows(header, records, phones, today, s.location)
+	}
+
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("marshal rows: %w", err)
+	}
+	if rowErrors == nil {
+		rowErrors = []ImportRowError{}
+	}
+	errorsJSON, err := json.Marshal(rowErrors)
+	if err != nil {
+		return nil, fmt.Errorf("marshal row errors: %w", err)
+	}
+
+	imp, err := s.repo.CreateCommunityImport(ctx, repository.CreateCommunityImportParams{
+		CommunityID: community.ID,
+		CreatedBy:   uuidToPgtype(actorID),
+		Kind:        kind,
+		TotalRows:   int32(len(records)),
+		ValidRows:   rowsJSON,
+		RowErrors:   errorsJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create import: %w", err)
+	}
+
+	return importResponse(imp, true), nil
+}
+
+// GetImport returns an import with its rows and errors
+func (s *ImportService) GetImport(ctx context.Context, communityID, importID uuid.UUID) (map[string]interface{}, error) {
+	imp, err := s.repo.GetCommunityImport(ctx, repository.GetCommunityImportParams{
+		ID:          uuidToPgtype(importID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrImportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get import: %w", err)
+	}
+	return importResponse(imp, true), nil
+}
+
+// ListImports returns the community's recent imports without their rows
+func (s *ImportService) ListImports(ctx context.Context, communityID uuid.UUID) ([]map[string]interface{}, error) {
+	imports, err := s.repo.ListCommunityImports(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, fmt.Errorf("list imports: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(imports))
+	for _, imp := range imports {
+		result = append(result, importResponse(imp, false))
+	}
+	return result, nil
+}
+
+// Commit applies the valid rows of a previewed import in one transaction.
+// With replayRatings, imported matches update ratings and stats in
+// chronological order on top of the players' current ratings; otherwise they
+// are recorded as history only. Global ratings and stats are only replayed for
+// placeholder users; registered accounts get community-scoped changes.
+func (s *ImportService) Commit(ctx context.Context, actorID, communityID, importID uuid.UUID, replayRatings bool) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	imp, err := qtx.LockCommunityImport(ctx, repository.LockCommunityImportParams{
+		ID:          uuidToPgtype(importID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrImportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock import: %w", err)
+	}
+	if imp.Status == repository.ImportStatusCommitted {
+		return nil, ErrImportCommitted
+	}
+	active, err := communityIsActive(ctx, qtx, imp.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrForbidden.WithMessage("Community is deactivated")
+	}
+
+	var result map[string]int
+	switch imp.Kind {
+	case ImportMembers:
+		var rows []ImportedMember
+		if err := json.Unmarshal(imp.ValidRows, &rows); err != nil {
+			return nil, fmt.Errorf("unmarshal rows: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil, ErrValidation.WithMessage("Import has no valid rows")
+		}
+		replayRatings = false
+		result, err = s.commitMembers(ctx, qtx, actorID, imp.CommunityID, rows)
+	case ImportMatches:
+		var rows []ImportedMatch
+		if err := json.Unmarshal(imp.ValidRows, &rows); err != nil {
+			return nil, fmt.Errorf("unmarshal rows: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil, ErrValidation.WithMessage("Import has no valid rows")
+		}
+		result, err = s.commitMatches(ctx, qtx, actorID, imp.CommunityID, rows, replayRatings)
+	default:
+		return nil, fmt.Errorf("unknown import kind %q", imp.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	if err := qtx.CommitCommunityImport(ctx, repository.CommitCommunityImportParams{
+		ReplayRatings: replayRatings,
+		Result:        resultJSON,
+		CommittedBy:   uuidToPgtype(actorID),
+		ID:            imp.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("commit import: %w", err)
+	}
+
+	detailsJSON, _ := json.Marshal(map[string]interface{}{
+		"import_id":      importID.String(),
+		"kind":           imp.Kind,
+		"replay_ratings": replayRatings,
+		"result":         result,
+	})
+	if err := qtx.CreateAuditLog(ctx, repository.CreateAuditLogParams{
+		ActorID:    uuidToPgtype(actorID),
+		Action:     "community_import_committed",
+		EntityType: pgtype.Text{String: "community", Valid: true},
+		EntityID:   imp.CommunityID,
+		Details:    detailsJSON,
+	}); err != nil {
+		return nil, fmt.Errorf("create audit log: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	imp.Status = repository.ImportStatusCommitted
+	imp.ReplayRatings = replayRatings
+	imp.Result = resultJSON
+	imp.CommittedBy = uuidToPgtype(actorID)
+	imp.CommittedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	return importResponse(imp, false), nil
+}
+
+// commitMembers creates placeholder users for unknown phones and adds them,
+// and placeholders left by earlier imports, to the community. Registered
+// accounts get a single-use invite instead; banned and deleted accounts and
+// people the community banned are skipped.
+func (s *ImportService) commitMembers(ctx context.Context, q *repository.Queries, actorID uuid.UUID, communityID pgtype.UUID, rows []ImportedMember) (map[string]int, error) {
+	result := map[string]int{"users_created": 0, "users_existing": 0, "members_added": 0, "invites_sent": 0, "skipped": 0}
+
+	community, err := q.GetCommunityActiveState(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	for _, row := range rows {
+		var userID pgtype.UUID
+
+		existing, err := q.GetUserStatusByPhone(ctx, row.Phone)
+		switch {
+		case err == pgx.ErrNoRows:
+			ntrp := pgtype.Numeric{}
+			if row.NTRPLevel != nil {
+				ntrp = floatToNumeric(*row.NTRPLevel)
+			}
+			userID, err = q.CreatePlaceholderUser(ctx, repository.CreatePlaceholderUserParams{
+				Phone:     row.Phone,
+				FirstName: pgtype.Text{String: row.FirstName, Valid: true},
+				LastName:  pgtype.Text{String: row.LastName, Valid: row.LastName != ""},
+				NtrpLevel: ntrp,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("create placeholder user (row %d): %w", row.Row, err)
+			}
+			result["users_created"]++
+		case err != nil:
+			return nil, fmt.Errorf("get user by phone (row %d): %w", row.Row, err)
+		case existing.Status.UserStatus == repository.UserStatusBanned || existing.Status.UserStatus == repository.UserStatusDeleted:
+			result["skipped"]++
+			continue
+		case existing.Status.UserStatus == repository.UserStatusPlaceholder:
+			userID = existing.ID
+			result["users_existing"]++
+		default:
+			result["users_existing"]++
+			invited, err := s.inviteExistingUser(ctx, q, actorID, community, existing.ID)
+			if err != nil {
+				return nil, fmt.Errorf("invite user (row %d): %w", row.Row, err)
+			}
+			if invited {
+				result["invites_sent"]++
+			} else {
+				result["skipped"]++
+			}
+			continue
+		}
+
+		added, err := q.AddImportedCommunityMember(ctx, repository.AddImportedCommunityMemberParams{
+			CommunityID: communityID,
+			UserID:      userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("add member (row %d): %w", row.Row, err)
+		}
+		result["members_added"] += int(added)
+	}
+
+	return result, nil
+}
+
+// inviteExistingUser sends a registered account a single-use invite to the
+// community. Current, pending and banned members are not invited.
+func (s *ImportService) inviteExistingUser(ctx context.Context, q *repository.Queries, actorID uuid.UUID, community repository.GetCommunityActiveStateRow, userID pgtype.UUID) (bool, error) {
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: community.ID,
+		UserID:      userID,
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		return false, fmt.Errorf("get member: %w", err)
+	}
+	if err == nil {
+		switch member.Status.MemberStatus {
+		case repository.MemberStatusActive, repository.MemberStatusPending, repository.MemberStatusBanned:
+			return false, nil
+		}
+	}
+
+	// Codes are unique; a collision inserts nothing and the next code is tried,
+	// which keeps the surrounding transaction usable.
+	params := repository.CreateImportInviteParams{
+		CommunityID: community.ID,
+		ExpiresAt:   pgtype.Timestamptz{Time: time.Now().Add(importInviteTTL), Valid: true},
+		CreatedBy:   uuidToPgtype(actorID),
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt == maxInviteAttempts {
+			return false, fmt.Errorf("create invite: no free code after %d attempts", maxInviteAttempts)
+		}
+		params.Code, err = generateInviteCode()
+		if err != nil {
+			return false, fmt.Errorf("generate invite code: %w", err)
+		}
+		created, err := q.CreateImportInvite(ctx, params)
+		if err != nil {
+			return false, fmt.Errorf("create invite: %w", err)
+		}
+		if created > 0 {
+			break
+		}
+	}
+
+	dataJSON, _ := json.Marshal(map[string]any{
+		"community_id":   pgtypeUUIDToStringRequired(community.ID),
+		"community_name": community.Name,
+		"invite_code":    params.Code,
+	})
+	if _, err := q.CreateNotification(ctx, repository.CreateNotificationParams{
+		UserID: userID,
+		Type:   repository.NotificationTypeCommunityInvite,
+		Title:  community.Name,
+		Body:   "Вас приглашают вступить в сообщество",
+		Data:   dataJSON,
+	}); err != nil {
+		return false, fmt.Errorf("create notification: %w", err)
+	}
+	return true, nil
+}
+
+// commitMatches records matches in chronological order. Rows whose players
+// are no longer active members are skipped.
+func (s *ImportService) commitMatches(ctx context.Context, q *repository.Queries, actorID uuid.UUID, communityID pgtype.UUID, rows []ImportedMatch, replayRatings bool) (map[string]int, error) {
+	result := map[string]int{"matches_created": 0, "skipped": 0}
+
+	members, err := q.ListCommunityMemberPhones(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("list member phones: %w", err)
+	}
+	byPhone := make(map[string]pgtype.UUID, len(members))
+	placeholders := make(map[[16]byte]bool, len(members))
+	for _, m := range members {
+		byPhone[m.Phone] = m.UserID
+		placeholders[m.UserID.Bytes] = m.Status.UserStatus == repository.UserStatusPlaceholder
+	}
+
+	sortImportedMatches(rows)
+
+	for _, row := range rows {
+		player1, ok1 := byPhone[row.Player1Phone]
+		player2, ok2 := byPhone[row.Player2Phone]
+		if !ok1 || !ok2 {
+			result["skipped"]++
+			continue
+		}
+
+		playedAt, err := time.ParseInLocation(dateLayout, row.Date, s.location)
+		if err != nil {
+			return nil, fmt.Errorf("parse date (row %d): %w", row.Row, err)
+		}
+		scoreJSON, err := json.Marshal(row.Score)
+		if err != nil {
+			return nil, fmt.Errorf("marshal score (row %d): %w", row.Row, err)
+		}
+
+		winner, loser := player1, player2
+		if row.Winner == 2 {
+			winner, loser = player2, player1
+		}
+
+		params := repository.CreateImportedMatchParams{
+			CommunityID: communityID,
+			Player1ID:   player1,
+			Player2ID:   player2,
+			Score:       scoreJSON,
+			WinnerID:    winner,
+			ImportedBy:  uuidToPgtype(actorID),
+			PlayedAt:    pgtype.Timestamptz{Time: playedAt, Valid: true},
+		}
+
+		if !replayRatings {
+			if _, err := q.CreateImportedMatch(ctx, params); err != nil {
+				return nil, fmt.Errorf("create match (row %d): %w", row.Row, err)
+			}
+			result["matches_created"]++
+			continue
+		}
+
+		if err := s.replayMatch(ctx, q, communityID, importPlayer{winner, placeholders[winner.Bytes]}, importPlayer{loser, placeholders[loser.Bytes]}, params); err != nil {
+			return nil, fmt.Errorf("replay match (row %d): %w", row.Row, err)
+		}
+		result["matches_created"]++
+	}
+
+	return result, nil
+}
+
+// importPlayer is a side of a replayed match
+type importPlayer struct {
+	id          pgtype.UUID
+	placeholder bool
+}
+
+// replayMatch creates a match and applies its rating change the same way a
+// confirmed result does, with "import" as the rating history reason. Only
+// placeholders have their global rating, NTRP level and stats replayed; a
+// registered account is rated on its community rating and only its community
+// stats change, so an import cannot rewrite anyone's global profile.
+func (s *ImportService) replayMatch(ctx context.Context, q *repository.Queries, communityID pgtype.UUID, winner, loser importPlayer, params repository.CreateImportedMatchParams) error {
+	winnerRating, winnerGames, err := importPlayerRating(ctx, q, communityID, winner)
+	if err != nil {
+		return fmt.Errorf("get winner rating: %w", err)
+	}
+	loserRating, loserGames, err := importPlayerRating(ctx, q, communityID, loser)
+	if err != nil {
+		return fmt.Errorf("get loser rating: %w", err)
+	}
+
+	change := elo.Calculate(
+		elo.PlayerInfo{Rating: winnerRating, TotalGames: winnerGames},
+		elo.PlayerInfo{Rating: loserRating, TotalGames: loserGames},
+	)
+
+	if params.WinnerID == params.Player1ID {
+		params.Player1RatingBefore, params.Player1RatingAfter = floatToNumeric(winnerRating), floatToNumeric(change.WinnerNewRating)
+		params.Player2RatingBefore, params.Player2RatingAfter = floatToNumeric(loserRating), floatToNumeric(change.LoserNewRating)
+	} else {
+		params.Player1RatingBefore, params.Player1RatingAfter = floatToNumeric(loserRating), floatToNumeric(change.LoserNewRating)
+		params.Player2RatingBefore, params.Player2RatingAfter = floatToNumeric(winnerRating), floatToNumeric(change.WinnerNewRating)
+	}
+
+	matchID, err := q.CreateImportedMatch(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create match: %w", err)
+	}
+
+	sides := []struct {
+		player        importPlayer
+		isWinner      bool
+		before, after float64
+		delta         float64
+	}{
+		{winner, true, winnerRating, change.WinnerNewRating, change.WinnerDelta},
+		{loser, false, loserRating, change.LoserNewRating, change.LoserDelta},
+	}
+
+	for _, side := range sides {
+		userID := side.player.id
+
+		if side.player.placeholder {
+			if err := q.UpdateUserRating(ctx, repository.UpdateUserRatingParams{
+				NewRating: floatToNumeric(side.after),
+				UserID:    userID,
+			}); err != nil {
+				return fmt.Errorf("update rating: %w", err)
+			}
+
+			if ntrp, _ := elo.GetNTRPLevel(side.after); ntrp != "" {
+				if level, err := strconv.ParseFloat(ntrp, 64); err == nil && level > 0 {
+					if err := q.UpdateUserNTRPLevel(ctx, repository.UpdateUserNTRPLevelParams{
+						NtrpLevel: floatToNumeric(level),
+						UserID:    userID,
+					}); err != nil {
+						return fmt.Errorf("update NTRP level: %w", err)
+					}
+				}
+			}
+
+			if err := q.UpsertPlayerStatsGlobal(ctx, repository.UpsertPlayerStatsGlobalParams{
+				UserID:    userID,
+				IsWinner:  side.isWinner,
+				IsSingles: true,
+			}); err != nil {
+				return fmt.Errorf("upsert stats: %w", err)
+			}
+		}
+
+		if err := q.UpdateCommunityMemberStats(ctx, repository.UpdateCommunityMemberStatsParams{
+			NewRating:   floatToNumeric(side.after),
+			IsWinner:    side.isWinner,
+			CommunityID: communityID,
+			UserID:      userID,
+		}); err != nil {
+			return fmt.Errorf("update community stats: %w", err)
+		}
+
+		for _, community := range importRatingHistoryScopes(communityID, side.player.placeholder) {
+			if _, err := q.InsertRatingHistory(ctx, repository.InsertRatingHistoryParams{
+				UserID:       userID,
+				CommunityID:  community,
+				RatingBefore: floatToNumeric(side.before),
+				RatingAfter:  floatToNumeric(side.after),
+				Change:       floatToNumeric(side.delta),
+				MatchID:      matchID,
+				Reason:       pgtype.Text{String: "import", Valid: true},
+			}); err != nil {
+				return fmt.Errorf("insert rating history: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// importPlayerRating returns the rating and game count a replayed match starts
+// from: global for placeholders, the community's own for registered accounts,
+// falling back to the global rating until they have one
+func importPlayerRating(ctx context.Context, q *repository.Queries, communityID pgtype.UUID, p importPlayer) (float64, int, error) {
+	user, err := q.GetUserForRating(ctx, p.id)
+	if err != nil {
+		return 0, 0, err
+	}
+	if p.placeholder {
+		games, _ := q.GetPlayerTotalGames(ctx, p.id)
+		return numericToFloat(user.GlobalRating), int(games), nil
+	}
+
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: communityID,
+		UserID:      p.id,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	rating := numericToFloat(user.GlobalRating)
+	if member.CommunityRating.Valid {
+		rating = numericToFloat(member.CommunityRating)
+	}
+	return rating, int(member.CommunityGamesCount.Int32), nil
+}
+
+// importRatingHistoryScopes lists the rating histories a replayed match is
+// recorded in: the global one (a null community) only for placeholders
+func importRatingHistoryScopes(communityID pgtype.UUID, placeholder bool) []pgtype.UUID {
+	if placeholder {
+		return []pgtype.UUID{{}, communityID}
+	}
+	return []pgtype.UUID{communityID}
+}
+
+func importResponse(imp repository.CommunityImport, withRows bool) map[string]interface{} {
+	var rowErrors []ImportRowError
+	_ = json.Unmarshal(imp.RowErrors, &rowErrors)
+	var rows []json.RawMessage
+	_ = json.Unmarshal(imp.ValidRows, &rows)
+
+	result := map[string]interface{}{
+		"id":             pgtypeUUIDToStringRequired(imp.ID),
+		"community_id":   pgtypeUUIDToStringRequired(imp.CommunityID),
+		"kind":           imp.Kind,
+		"status":         string(imp.Status),
+		"total_rows":     imp.TotalRows,
+		"valid_rows":     len(rows),
+		"error_count":    len(rowErrors),
+		"replay_ratings": imp.ReplayRatings,
+		"created_at":     imp.CreatedAt.Time,
+	}
+	if withRows {
+		if rowErrors == nil {
+			rowErrors = []ImportRowError{}
+		}
+		if rows == nil {
+			rows = []json.RawMessage{}
+		}
+		result["errors"] = rowErrors
+		result["rows"] = rows
+	}
+	if imp.CreatedBy.Valid {
+		result["created_by"] = pgtypeUUIDToStringRequired(imp.CreatedBy)
+	}
+	if imp.Result != nil {
+		result["result"] = json.RawMessage(imp.Result)
+	}
+	if imp.CommittedBy.Valid {
+		result["committed_by"] = pgtypeUUIDToStringRequired(imp.CommittedBy)
+	}
+	if imp.CommittedAt.Valid {
+		result["committed_at"] = imp.CommittedAt.Time
+	}
+	return result
+}
+
+// readImportCSV reads a CSV upload into a lower-cased header index and data
+// records. A UTF-8 byte order mark is skipped and semicolons are accepted as
+// the delimiter, as Excel writes them in Russian locales. Blank lines are dropped.
+func readImportCSV(r io.Reader) (map[string]int, [][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read upload: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	cr := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, ErrValidation.WithMessage(fmt.Sprintf("Invalid CSV: %v", err))
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrValidation.WithMessage("File is empty")
+	}
+
+	header := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	return header, records[1:], nil
+}
+
+// importCell returns the trimmed value of a column, or "" when absent
+func importCell(header map[string]int, record []string, column string) string {
+	i, ok := header[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// blankRecord reports whether every cell of a record is empty
+func blankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseMemberRows(header map[string]int, records [][]string) ([]ImportedMember, []ImportRowError) {
+	rows := []ImportedMember{}
+	var rowErrors []ImportRowError
+	seen := make(map[string]int)
+
+	for i, record := range records {
+		line := i + 2
+		if blankRecord(record) {
+			continue
+		}
+		var errs []ImportRowError
+
+		phone, ok := normalizeImportPhone(importCell(header, record, "phone"))
+		if !ok {
+			errs = append(errs, ImportRowError{Row: line, Field: "phone", Message: "Invalid phone number"})
+		} else if first, dup := seen[phone]; dup {
+			errs = append(errs, ImportRowError{Row: line, Field: "phone", Message: fmt.Sprintf("Duplicate of row %d", first)})
+		}
+
+		firstName := importCell(header, record, "first_name")
+		if firstName == "" {
+			errs = append(errs, ImportRowError{Row: line, Field: "first_name", Message: "First name is required"})
+		}
+
+		member := ImportedMember{
+			Row:       line,
+			Phone:     phone,
+			FirstName: firstName,
+			LastName:  importCell(header, record, "last_name"),
+		}
+		if raw := importCell(header, record, "ntrp_level"); raw != "" {
+			level, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+			if err != nil || level < 1.0 || level > 7.0 {
+				errs = append(errs, ImportRowError{Row: line, Field: "ntrp_level", Message: "NTRP level must be between 1.0 and 7.0"})
+			} else {
+				member.NTRPLevel = &level
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		seen[phone] = line
+		rows = append(rows, member)
+	}
+
+	return rows, rowErrors
+}
+
+// parseMatchRows validates match rows. members holds the phones of active
+// community members and today is the current date as "2006-01-02".
+func parseMatchRows(header map[string]int, records [][]string, members map[string]bool, today string, loc *time.Location) ([]ImportedMatch, []ImportRowError) {
+	rows := []ImportedMatch{}
+	var rowErrors []ImportRowError
+
+	for i, record := range records {
+		line := i + 2
+		if blankRecord(record) {
+			continue
+		}
+		var errs []ImportRowError
+
+		date, err := parseImportDate(importCell(header, record, "date"), loc)
+		if err != nil {
+			errs = append(errs, ImportRowError{Row: line, Field: "date", Message: "Date must be YYYY-MM-DD or DD.MM.YYYY"})
+		} else if date > today {
+			errs = append(errs, ImportRowError{Row: line, Field: "date", Message: "Date is in the future"})
+		}
+
+		phones := [2]string{}
+		for p, column := range []string{"player1_phone", "player2_phone"} {
+			phone, ok := normalizeImportPhone(importCell(header, record, column))
+			switch {
+			case !ok:
+				errs = append(errs, ImportRowError{Row: line, Field: column, Message: "Invalid phone number"})
+			case !members[phone]:
+				errs = append(errs, ImportRowError{Row: line, Field: column, Message: "Player is not a member of the community"})
+			}
+			phones[p] = phone
+		}
+		if phones[0] != "" && phones[0] == phones[1] {
+			errs = append(errs, ImportRowError{Row: line, Field: "player2_phone", Message: "Players must be different"})
+		}
+
+		score, winner, err := parseImportScore(importCell(header, record, "score"))
+		if err != nil {
+			errs = append(errs, ImportRowError{Row: line, Field: "score", Message: err.Error()})
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+		rows = append(rows, ImportedMatch{
+			Row:          line,
+			Date:         date,
+			Player1Phone: phones[0],
+			Player2Phone: phones[1],
+			Score:        score,
+			Winner:       winner,
+		})
+	}
+
+	return rows, rowErrors
+}
+
+// normalizeImportPhone brings Kazakhstan numbers written as 8 701 ..., 701 ...
+// or +7 (701) ... to the +7XXXXXXXXXX form used by accounts
+func normalizeImportPhone(raw string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, raw)
+
+	switch {
+	case len(digits) == 11 && digits[0] == '8':
+		digits = "7" + digits[1:]
+	case len(digits) == 10:
+		digits = "7" + digits
+	}
+
+	phone := "+" + digits
+	if !phoneRegex.MatchString(phone) {
+		return "", false
+	}
+	return phone, true
+}
+
+// parseImportDate accepts the importDateLayouts and returns the date as "2006-01-02"
+func parseImportDate(raw string, loc *time.Location) (string, error) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t.Format(dateLayout), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q", raw)
+}
+
+// parseImportScore parses sets like "6:4 3:6 7:6(5)" from player 1's side and
+// returns the winner as 1 or 2
+func parseImportScore(raw string) ([]SetScore, int, error) {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	})
+	if len(parts) == 0 {
+		return nil, 0, fmt.Errorf("Score is required")
+	}
+	if len(parts) > importMaxSets {
+		return nil, 0, fmt.Errorf("A match has at most %d sets", importMaxSets)
+	}
+
+	sets := make([]SetScore, 0, len(parts))
+	won := [2]int{}
+	for _, part := range parts {
+		m := setScoreRegex.FindStringSubmatch(part)
+		if m == nil {
+			return nil, 0, fmt.Errorf("Invalid set %q", part)
+		}
+		set := SetScore{}
+		set.Player1, _ = strconv.Atoi(m[1])
+		set.Player2, _ = strconv.Atoi(m[2])
+		if set.Player1 == set.Player2 {
+			return nil, 0, fmt.Errorf("Set %q has no winner", part)
+		}
+		if m[3] != "" {
+			tiebreak, _ := strconv.Atoi(m[3])
+			set.Tiebreak = &tiebreak
+		}
+		if set.Player1 > set.Player2 {
+			won[0]++
+		} else {
+			won[1]++
+		}
+		sets = append(sets, set)
+	}
+
+	switch {
+	case won[0] > won[1]:
+		return sets, 1, nil
+	case won[1] > won[0]:
+		return sets, 2, nil
+	default:
+		return nil, 0, fmt.Errorf("Score does not decide a winner")
+	}
+}
+
+// sortImportedMatches orders matches by date, keeping file order within a day
+func sortImportedMatches(rows []ImportedMatch) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Date < rows[j].Date
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeImportPhone(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{"+77011234567", "+77011234567", true},
+		{"+7 (701) 123-45-67", "+77011234567", true},
+		{"87011234567", "+77011234567", true},
+		{"701 123 45 67", "+77011234567", true},
+		{"12345", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeImportPhone(tt.raw)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeImportPhone(%q) = %q, %v; want %q, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseImportScore(t *testing.T) {
+	sets, winner, err := parseImportScore("6:4 3-6, 7:6(5)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if winner != 1 || len(sets) != 3 {
+		t.Fatalf("got winner %d with %d sets, want 1 with 3", winner, len(sets))
+	}
+	if sets[2].Tiebreak == nil || *sets[2].Tiebreak != 5 {
+		t.Errorf("expected tiebreak 5 in the third set")
+	}
+
+	if _, winner, _ := parseImportScore("4:6 2:6"); winner != 2 {
+		t.Errorf("expected player 2 to win, got %d", winner)
+	}
+
+	for _, raw := range []string{"", "6:4 4:6", "6:6", "six-four", "6:4 6:4 6:4 6:4 6:4 6:4"} {
+		if _, _, err := parseImportScore(raw); err == nil {
+			t.Errorf("parseImportScore(%q) should fail", raw)
+		}
+	}
+}
+
+func TestReadImportCSV(t *testing.T) {
+	data := "\ufeffPhone;First_Name;last_name\n+77011234567;Алма;Амирсеитов\n\n"
+	header, records, err := readImportCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if header["phone"] != 0 || header["first_name"] != 1 || header["last_name"] != 2 {
+		t.Errorf("unexpected header %v", header)
+	}
+	if len(records) != 1 || records[0][1] != "Алма" {
+		t.Errorf("unexpected records %v", records)
+	}
+}
+
+func TestParseMemberRows(t *testing.T) {
+	header := map[string]int{"phone": 0, "first_name": 1, "last_name": 2, "ntrp_level": 3}
+	records := [][]string{
+		{"87011234567", "Алма", "Амирсеитов", "3,5"},
+		{"+77011234567", "Дубль", "", ""},
+		{"123", "", "", "9"},
+		{"", "", "", ""},
+	}
+
+	rows, errs := parseMemberRows(header, records)
+	if len(rows) != 1 || rows[0].Phone != "+77011234567" || rows[0].NTRPLevel == nil || *rows[0].NTRPLevel != 3.5 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+	// duplicate phone on line 3, then phone, name and level on line 4
+	if len(errs) != 4 || errs[0].Row != 3 || errs[1].Row != 4 {
+		t.Errorf("unexpected errors %+v", errs)
+	}
+}
+
+func TestParseMatchRows(t *testing.T) {
+	header := map[string]int{"date": 0, "player1_phone": 1, "player2_phone": 2, "score": 3}
+	members := map[string]bool{"+77011234567": true, "+77017654321": true}
+	records := [][]string{
+		{"15.05.2024", "87011234567", "87017654321", "6:4 6:3"},
+		{"2024-05-16", "87011234567", "87011234567", "6:4 6:3"},
+		{"2030-01-01", "87011234567", "87019999999", "6:4"},
+	}
+
+	rows, errs := parseMatchRows(header, records, members, "2025-01-01", time.UTC)
+	if len(rows) != 1 || rows[0].Date != "2024-05-15" || rows[0].Winner != 1 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+	// same players on line 3; future date and non-member on line 4
+	if len(errs) != 3 {
+		t.Errorf("unexpected errors %+v", errs)
+	}
+}
+
+func TestSortImportedMatches(t *testing.T) {
+	rows := []ImportedMatch{
+		{Row: 2, Date: "2024-05-02"},
+		{Row: 3, Date: "2024-05-01"},
+		{Row: 4, Date: "2024-05-02"},
+	}
+	sortImportedMatches(rows)
+	if rows[0].Row != 3 || rows[1].Row != 2 || rows[2].Row != 4 {
+		t.Errorf("unexpected order %+v", rows)
+	}
+}
+
+func TestImportRatingHistoryScopes(t *testing.T) {
+	communityID := uuidToPgtype(uuid.New())
+
+	scopes := importRatingHistoryScopes(communityID, true)
+	if len(scopes) != 2 || scopes[0].Valid || scopes[1] != communityID {
+		t.Errorf("placeholder scopes = %+v, want global and community", scopes)
+	}
+
+	// registered accounts must never get a global rating history entry
+	scopes = importRatingHistoryScopes(communityID, false)
+	if len(scopes) != 1 || scopes[0] != communityID {
+		t.Errorf("registered account scopes = %+v, want community only", scopes)
+	}
+}
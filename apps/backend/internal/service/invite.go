@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	inviteCodeLength   = 8
+	maxInviteAttempts  = 5
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // no 0/O, 1/I
+)
+
+// InviteService handles invite codes and links of communities
+type InviteService struct {
+	repo      *repository.Queries
+	pool      txStarter
+	news      *CommunityNews
+	publicURL string
+}
+
+// NewInviteService creates a new InviteService
+func NewInviteService(repo *repository.Queries, pool txStarter, news *CommunityNews, publicURL string) *InviteService {
+	return &InviteService{
+		repo:      repo,
+		pool:      pool,
+		news:      news,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+// CreateInviteInput represents a new invite.
+// Role defaults to member; MaxUses and ExpiresAt are unlimited when omitted.
+type CreateInviteInput struct {
+	Role      string     `json:"role"`
+	MaxUses   *int32     `json:"max_uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Create issues a new invite code. Only the owner may invite admins,
+// mirroring the rule for promoting existing members.
+func (s *InviteService) Create(ctx context.Context, actorID, communityID uuid.UUID, input CreateInviteInput) (map[string]interface{}, error) {
+	actor, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(actorID),
+	})
+	if err != nil {
+		return nil, ErrForbidden
+	}
+
+	if input.Role == "" {
+		input.Role = string(repository.CommunityRoleMember)
+	}
+	role := repository.CommunityRole(input.Role)
+	switch role {
+	case repository.CommunityRoleAdmin:
+		if actor.Role.CommunityRole != repository.CommunityRoleOwner {
+			return nil, ErrInsufficientRole.WithMessage("Only owner can invite admins")
+		}
+	case repository.CommunityRoleModerator, repository.CommunityRoleCoachReferee, repository.CommunityRoleMember:
+	default:
+		return nil, ErrValidation.WithMessage("Invalid role")
+	}
+
+	params := repository.CreateCommunityInviteParams{
+		CommunityID: actor.CommunityID,
+		Role:        role,
+		CreatedBy:   actor.UserID,
+	}
+	if input.MaxUses != nil {
+		if *input.MaxUses < 1 {
+			return nil, ErrValidation.WithMessage("max_uses must be positive")
+		}
+		params.MaxUses = pgtype.Int4{Int32: *input.MaxUses, Valid: true}
+	}
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return nil, ErrValidation.WithMessage("expires_at must be in the future")
+		}
+		params.ExpiresAt = pgtype.Timestamptz{Time: *input.ExpiresAt, Valid: true}
+	}
+
+	for attempt := 0; attempt < maxInviteAttempts; attempt++ {
+		params.Code, err = generateInviteCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate invite code: %w", err)
+		}
+
+		invite, err := s.repo.CreateCommunityInvite(ctx, params)
+		if isUniqueViolation(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("create community invite: %w", err)
+		}
+
+		return s.buildInviteResponse(invite, time.Now()), nil
+	}
+
+	return nil, fmt.Errorf("create community invite: no free code after %d attempts", maxInviteAttempts)
+}
+
+// List returns all invites of a community, including revoked and used up ones
+func (s *InviteService) List(ctx context.Context, communityID uuid.UUID) ([]map[string]interface{}, error) {
+	invites, err := s.repo.ListCommunityInvites(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, fmt.Errorf("list community invites: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]map[string]interface{}, 0, len(invites))
+	for _, i := range invites {
+		invite := s.buildInviteResponse(repository.CommunityInvite{
+			ID:          i.ID,
+			CommunityID: i.CommunityID,
+			Code:        i.Code,
+			Role:        i.Role,
+			MaxUses:     i.MaxUses,
+			UseCount:    i.UseCount,
+			ExpiresAt:   i.ExpiresAt,
+			CreatedBy:   i.CreatedBy,
+			RevokedAt:   i.RevokedAt,
+			CreatedAt:   i.CreatedAt,
+		}, now)
+		invite["created_by"] = map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(i.CreatedBy),
+			"first_name": i.CreatorFirstName.String,
+			"last_name":  i.CreatorLastName.String,
+		}
+		result = append(result, invite)
+	}
+	return result, nil
+}
+
+// Revoke disables an invite; members who already joined through it stay
+func (s *InviteService) Revoke(ctx context.Context, communityID, inviteID uuid.UUID) error {
+	revoked, err := s.repo.RevokeCommunityInvite(ctx, repository.RevokeCommunityInviteParams{
+		ID:          uuidToPgtype(inviteID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err != nil {
+		return fmt.Errorf("revoke community invite: %w", err)
+	}
+	if revoked == 0 {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// ListUses returns the members who joined through an invite
+func (s *InviteService) ListUses(ctx context.Context, communityID, inviteID uuid.UUID) ([]map[string]interface{}, error) {
+	uses, err := s.repo.ListCommunityInviteUses(ctx, repository.ListCommunityInviteUsesParams{
+		InviteID:    uuidToPgtype(inviteID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list community invite uses: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(uses))
+	for _, u := range uses {
+		result = append(result, map[string]interface{}{
+			"user_id":    pgtypeUUIDToStringRequired(u.UserID),
+			"first_name": u.FirstName.String,
+			"last_name":  u.LastName.String,
+			"avatar_url": u.AvatarUrl.String,
+			"role":       string(u.Role.CommunityRole),
+			"status":     string(u.Status.MemberStatus),
+			"joined_at":  u.JoinedAt.Time,
+		})
+	}
+	return result, nil
+}
+
+// Preview returns what accepting an invite leads to, so the app can show
+// the community before the user confirms
+func (s *InviteService) Preview(ctx context.Context, code string) (map[string]interface{}, error) {
+	invite, err := s.getInvite(ctx, s.repo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"code":  invite.Code,
+		"role":  string(invite.Role),
+		"valid": inviteState(invite.RevokedAt, invite.ExpiresAt, invite.MaxUses, invite.UseCount, time.Now()) == "active",
+		"community": map[string]interface{}{
+			"id":           pgtypeUUIDToStringRequired(invite.CommunityID),
+			"name":         invite.CommunityName,
+			"slug":         invite.CommunitySlug.String,
+			"logo_url":     invite.LogoUrl.String,
+			"access_level": string(invite.AccessLevel.CommunityAccess),
+			"member_count": invite.MemberCount.Int32,
+		},
+	}, nil
+}
+
+// Accept joins the community through an invite, skipping the review queue.
+// Paid communities still require dues: the member stays pending until paid.
+func (s *InviteService) Accept(ctx context.Context, userID uuid.UUID, code string) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	invite, err := s.getInvite(ctx, qtx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := qtx.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: invite.CommunityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get member: %w", err)
+	}
+	isMember := err == nil
+	if isMember {
+		switch existing.Status.MemberStatus {
+		case repository.MemberStatusActive:
+			return nil, ErrAlreadyMember
+		case repository.MemberStatusBanned:
+			lifted, err := banLifted(ctx, qtx, existing, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			if !lifted {
+				return nil, ErrForbidden.WithMessage("You are banned from this community")
+			}
+		}
+	}
+
+	claimed, err := qtx.ClaimCommunityInvite(ctx, invite.ID)
+	if err != nil {
+		return nil, fmt.Errorf("claim community invite: %w", err)
+	}
+	if claimed == 0 {
+		return nil, ErrInviteExpired.WithMessage("Invite is expired, revoked or used up")
+	}
+
+	paymentRequired := invite.AccessLevel.CommunityAccess == repository.CommunityAccessPaid
+	status := repository.NullMemberStatus{MemberStatus: repository.MemberStatusActive, Valid: true}
+	responseMsg := "Вы вступили в сообщество"
+	if paymentRequired {
+		status = repository.NullMemberStatus{MemberStatus: repository.MemberStatusPending, Valid: true}
+		responseMsg = "Оплатите членский взнос, чтобы вступить"
+	}
+	role := repository.NullCommunityRole{CommunityRole: invite.Role, Valid: true}
+
+	if isMember {
+		if _, err := qtx.UpdateCommunityMemberStatus(ctx, repository.UpdateCommunityMemberStatusParams{
+			CommunityID: invite.CommunityID,
+			UserID:      existing.UserID,
+			Status:      status,
+			ReviewedBy:  invite.CreatedBy,
+		}); err != nil {
+			return nil, fmt.Errorf("update member status: %w", err)
+		}
+		if _, err := qtx.UpdateCommunityMemberRole(ctx, repository.UpdateCommunityMemberRoleParams{
+			CommunityID: invite.CommunityID,
+			UserID:      existing.UserID,
+			Role:        role,
+		}); err != nil {
+			return nil, fmt.Errorf("update member role: %w", err)
+		}
+	} else {
+		if _, err := qtx.AddCommunityMember(ctx, repository.AddCommunityMemberParams{
+			CommunityID: invite.CommunityID,
+			UserID:      uuidToPgtype(userID),
+			Role:        role,
+			Status:      status,
+		}); err != nil {
+			return nil, fmt.Errorf("add member: %w", err)
+		}
+	}
+
+	if err := qtx.RecordCommunityInviteUse(ctx, repository.RecordCommunityInviteUseParams{
+		InviteID:    invite.ID,
+		UserID:      uuidToPgtype(userID),
+		CommunityID: invite.CommunityID,
+	}); err != nil {
+		return nil, fmt.Errorf("record invite use: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	if !paymentRequired {
+		s.news.Subscribe(ctx, userID, uuid.UUID(invite.CommunityID.Bytes))
+	}
+
+	return map[string]interface{}{
+		"community_id":     pgtypeUUIDToStringRequired(invite.CommunityID),
+		"status":           string(status.MemberStatus),
+		"role":             string(invite.Role),
+		"message":          responseMsg,
+		"payment_required": paymentRequired,
+	}, nil
+}
+
+func (s *InviteService) getInvite(ctx context.Context, q *repository.Queries, code string) (repository.GetCommunityInviteByCodeRow, error) {
+	invite, err := q.GetCommunityInviteByCode(ctx, normalizeInviteCode(code))
+	if err == pgx.ErrNoRows || (err == nil && invite.CommunityIsActive.Valid && !invite.CommunityIsActive.Bool) {
+		return repository.GetCommunityInviteByCodeRow{}, ErrInviteNotFound
+	}
+	if err != nil {
+		return repository.GetCommunityInviteByCodeRow{}, fmt.Errorf("get community invite: %w", err)
+	}
+	return invite, nil
+}
+
+func (s *InviteService) buildInviteResponse(i repository.CommunityInvite, now time.Time) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":         pgtypeUUIDToStringRequired(i.ID),
+		"code":       i.Code,
+		"link":       s.publicURL + "/invite/" + i.Code,
+		"role":       string(i.Role),
+		"use_count":  i.UseCount,
+		"max_uses":   nil,
+		"expires_at": nil,
+		"state":      inviteState(i.RevokedAt, i.ExpiresAt, i.MaxUses, i.UseCount, now),
+		"created_at": i.CreatedAt.Time,
+	}
+	if i.MaxUses.Valid {
+		result["max_uses"] = i.MaxUses.Int32
+	}
+	if i.ExpiresAt.Valid {
+		result["expires_at"] = i.ExpiresAt.Time
+	}
+	if i.RevokedAt.Valid {
+		result["revoked_at"] = i.RevokedAt.Time
+	}
+	return result
+}
+
+// inviteState returns "active", "revoked", "expired" or "used_up"
+func inviteState(revokedAt, expiresAt pgtype.Timestamptz, maxUses pgtype.Int4, useCount int32, now time.Time) string {
+	switch {
+	case revokedAt.Valid:
+		return "revoked"
+	case expiresAt.Valid && !expiresAt.Time.After(now):
+		return "expired"
+	case maxUses.Valid && useCount >= maxUses.Int32:
+		return "used_up"
+	default:
+		return "active"
+	}
+}
+
+// normalizeInviteCode makes codes typed by hand case-insensitive
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestInviteState(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ts := func(t time.Time) pgtype.Timestamptz { return pgtype.Timestamptz{Time: t, Valid: true} }
+	uses := func(n int32) pgtype.Int4 { return pgtype.Int4{Int32: n, Valid: true} }
+
+	tests := []struct {
+		name      string
+		revokedAt pgtype.Timestamptz
+		expiresAt pgtype.Timestamptz
+		maxUses   pgtype.Int4
+		useCount  int32
+		expected  string
+	}{
+		{name: "Unlimited", useCount: 40, expected: "active"},
+		{name: "Not yet expired", expiresAt: ts(now.Add(time.Hour)), expected: "active"},
+		{name: "Expired", expiresAt: ts(now), expected: "expired"},
+		{name: "Uses left", maxUses: uses(5), useCount: 4, expected: "active"},
+		{name: "Used up", maxUses: uses(5), useCount: 5, expected: "used_up"},
+		{name: "Revoked wins", revokedAt: ts(now.Add(-time.Hour)), expiresAt: ts(now.Add(-time.Minute)), expected: "revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inviteState(tt.revokedAt, tt.expiresAt, tt.maxUses, tt.useCount, now)
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateInviteCode(t *testing.T) {
+	code, err := generateInviteCode()
+	if err != nil {
+		t.Fatalf("generateInviteCode: %v", err)
+	}
+	if len(code) != inviteCodeLength {
+		t.Fatalf("expected %d characters, got %q", inviteCodeLength, code)
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(inviteCodeAlphabet, c) {
+			t.Errorf("unexpected character %q in %q", c, code)
+		}
+	}
+	if normalizeInviteCode(" "+strings.ToLower(code)+"\n") != code {
+		t.Errorf("normalizeInviteCode should accept lower case input")
+	}
+}
@@ -0,0 +1,905 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ladder setting defaults and limits
+const (
+	ladderDefaultRange   = 3
+	ladderDefaultAccept  = 72 // hours
+	ladderDefaultPlay    = 14 // days
+	ladderDefaultPenalty = 1
+
+	ladderMaxRange   = 20
+	ladderMaxAccept  = 14 * 24
+	ladderMaxPlay    = 60
+	ladderMaxPenalty = 10
+)
+
+// LadderService runs community challenge ladders. The ladder order is kept
+// apart from ELO: players climb by beating someone above them, and the
+// resulting swap happens when MatchService confirms the challenge match.
+// Position changes are serialised by locking the community's ladder row.
+type LadderService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+}
+
+// NewLadderService creates a new LadderService
+func NewLadderService(repo *repository.Queries, pool txStarter, notifications *NotificationService) *LadderService {
+	return &LadderService{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+	}
+}
+
+// LadderSettingsInput configures a ladder; nil fields keep their current value
+type LadderSettingsInput struct {
+	ChallengeRange *int  `json:"challenge_range"`
+	AcceptHours    *int  `json:"accept_hours"`
+	PlayDays       *int  `json:"play_days"`
+	DeclinePenalty *int  `json:"decline_penalty"`
+	IsActive       *bool `json:"is_active"`
+}
+
+// apply validates the input and writes it onto the ladder settings
+func (in LadderSettingsInput) apply(l *repository.CommunityLadder) error {
+	fields := []struct {
+		name     string
+		value    *int
+		min, max int
+		target   *int32
+	}{
+		{"challenge_range", in.ChallengeRange, 1, ladderMaxRange, &l.ChallengeRange},
+		{"accept_hours", in.AcceptHours, 1, ladderMaxAccept, &l.AcceptHours},
+		{"play_days", in.PlayDays, 1, ladderMaxPlay, &l.PlayDays},
+		{"decline_penalty", in.DeclinePenalty, 0, ladderMaxPenalty, &l.DeclinePenalty},
+	}
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		if *f.value < f.min || *f.value > f.max {
+			return ErrValidation.WithMessage(fmt.Sprintf("%s must be between %d and %d", f.name, f.min, f.max))
+		}
+		*f.target = int32(*f.value)
+	}
+	if in.IsActive != nil {
+		l.IsActive = *in.IsActive
+	}
+	return nil
+}
+
+// Configure creates the community's ladder or updates its settings
+func (s *LadderService) Configure(ctx context.Context, communityID uuid.UUID, input LadderSettingsInput) (map[string]interface{}, error) {
+	ladder, err := s.repo.GetCommunityLadder(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		ladder = repository.CommunityLadder{
+			CommunityID:    uuidToPgtype(communityID),
+			ChallengeRange: ladderDefaultRange,
+			AcceptHours:    ladderDefaultAccept,
+			PlayDays:       ladderDefaultPlay,
+			DeclinePenalty: ladderDefaultPenalty,
+			IsActive:       true,
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("get ladder: %w", err)
+	}
+
+	if err := input.apply(&ladder); err != nil {
+		return nil, err
+	}
+
+	ladder, err = s.repo.UpsertCommunityLadder(ctx, repository.UpsertCommunityLadderParams{
+		CommunityID:    ladder.CommunityID,
+		ChallengeRange: ladder.ChallengeRange,
+		AcceptHours:    ladder.AcceptHours,
+		PlayDays:       ladder.PlayDays,
+		DeclinePenalty: ladder.DeclinePenalty,
+		IsActive:       ladder.IsActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("save ladder: %w", err)
+	}
+
+	return ladderSettingsResponse(ladder), nil
+}
+
+// Get returns the ladder settings and standings. For a player on the ladder
+// each entry says whether they may challenge it.
+func (s *LadderService) Get(ctx context.Context, userID, communityID uuid.UUID) (map[string]interface{}, error) {
+	ladder, err := s.getLadder(ctx, s.repo, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.repo.ListLadderPositions(ctx, ladder.CommunityID)
+	if err != nil {
+		return nil, fmt.Errorf("list ladder positions: %w", err)
+	}
+
+	myPosition := int32(0)
+	for _, p := range positions {
+		if p.UserID == uuidToPgtype(userID) {
+			myPosition = p.Position
+		}
+	}
+
+	standings := make([]map[string]interface{}, 0, len(positions))
+	for _, p := range positions {
+		entry := map[string]interface{}{
+			"position":      p.Position,
+			"user_id":       pgtypeUUIDToStringRequired(p.UserID),
+			"first_name":    p.FirstName.String,
+			"last_name":     p.LastName.String,
+			"avatar_url":    p.AvatarUrl.String,
+			"joined_at":     p.JoinedAt.Time,
+			"can_challenge": ladder.IsActive && myPosition > 0 && challengeable(myPosition, p.Position, ladder.ChallengeRange),
+		}
+		if p.NtrpLevel.Valid {
+			entry["ntrp_level"] = numericToFloat(p.NtrpLevel)
+		}
+		standings = append(standings, entry)
+	}
+
+	result := ladderSettingsResponse(ladder)
+	result["standings"] = standings
+	result["my_position"] = nil
+	if myPosition > 0 {
+		result["my_position"] = myPosition
+	}
+	return result, nil
+}
+
+// Join puts an active community member at the bottom of the ladder
+func (s *LadderService) Join(ctx context.Context, userID, communityID uuid.UUID) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockActiveLadder(ctx, qtx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireActiveMember(ctx, qtx, ladder.CommunityID, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := qtx.GetLadderPosition(ctx, repository.GetLadderPositionParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      uuidToPgtype(userID),
+	}); err == nil {
+		return nil, ErrAlreadyMember.WithMessage("Already on the ladder")
+	} else if err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get ladder position: %w", err)
+	}
+
+	bottom, err := qtx.GetLadderBottom(ctx, ladder.CommunityID)
+	if err != nil {
+		return nil, fmt.Errorf("get ladder bottom: %w", err)
+	}
+
+	position, err := qtx.AddLadderPosition(ctx, repository.AddLadderPositionParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      uuidToPgtype(userID),
+		Position:    bottom + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add ladder position: %w", err)
+	}
+
+	if err := qtx.InsertLadderHistory(ctx, repository.InsertLadderHistoryParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      position.UserID,
+		NewPosition: pgtype.Int4{Int32: position.Position, Valid: true},
+		Reason:      repository.LadderChangeReasonJoined,
+	}); err != nil {
+		return nil, fmt.Errorf("insert ladder history: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return map[string]interface{}{"position": position.Position}, nil
+}
+
+// Leave removes the player from the ladder; everyone below moves up one place
+func (s *LadderService) Leave(ctx context.Context, userID, communityID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockLadder(ctx, qtx, communityID)
+	if err != nil {
+		return err
+	}
+
+	position, err := qtx.GetLadderPosition(ctx, repository.GetLadderPositionParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows {
+		return ErrNotFound.WithMessage("Not on the ladder")
+	}
+	if err != nil {
+		return fmt.Errorf("get ladder position: %w", err)
+	}
+
+	open, err := qtx.CountOpenLadderChallenges(ctx, repository.CountOpenLadderChallengesParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      position.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("count open challenges: %w", err)
+	}
+	if open > 0 {
+		return ErrValidation.WithMessage("Finish or cancel your open challenges before leaving the ladder")
+	}
+
+	if err := qtx.DeleteLadderPosition(ctx, repository.DeleteLadderPositionParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      position.UserID,
+	}); err != nil {
+		return fmt.Errorf("delete ladder position: %w", err)
+	}
+	if err := qtx.InsertLadderHistory(ctx, repository.InsertLadderHistoryParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      position.UserID,
+		OldPosition: pgtype.Int4{Int32: position.Position, Valid: true},
+		Reason:      repository.LadderChangeReasonLeft,
+	}); err != nil {
+		return fmt.Errorf("insert ladder history: %w", err)
+	}
+	if err := qtx.ShiftLadderPositions(ctx, repository.ShiftLadderPositionsParams{
+		Delta:        -1,
+		CommunityID:  ladder.CommunityID,
+		FromPosition: position.Position + 1,
+		ToPosition:   math.MaxInt32,
+	}); err != nil {
+		return fmt.Errorf("shift ladder positions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Challenge issues a challenge to a player up to challenge_range places above.
+// Each player can be in only one open challenge at a time.
+func (s *LadderService) Challenge(ctx context.Context, challengerID, communityID, defenderID uuid.UUID) (map[string]interface{}, error) {
+	if challengerID == defenderID {
+		return nil, ErrValidation.WithMessage("You cannot challenge yourself")
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockActiveLadder(ctx, qtx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireActiveMember(ctx, qtx, ladder.CommunityID, challengerID); err != nil {
+		return nil, err
+	}
+
+	positions := map[uuid.UUID]int32{}
+	for _, id := range []uuid.UUID{challengerID, defenderID} {
+		p, err := qtx.GetLadderPosition(ctx, repository.GetLadderPositionParams{
+			CommunityID: ladder.CommunityID,
+			UserID:      uuidToPgtype(id),
+		})
+		if err == pgx.ErrNoRows {
+			return nil, ErrValidation.WithMessage("Both players must be on the ladder")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get ladder position: %w", err)
+		}
+
+		open, err := qtx.CountOpenLadderChallenges(ctx, repository.CountOpenLadderChallengesParams{
+			CommunityID: ladder.CommunityID,
+			UserID:      uuidToPgtype(id),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("count open challenges: %w", err)
+		}
+		if open > 0 {
+			return nil, ErrValidation.WithMessage("Each player can only be in one open challenge at a time")
+		}
+		positions[id] = p.Position
+	}
+
+	if !challengeable(positions[challengerID], positions[defenderID], ladder.ChallengeRange) {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("You can only challenge players up to %d places above you", ladder.ChallengeRange))
+	}
+
+	challenge, err := qtx.CreateLadderChallenge(ctx, repository.CreateLadderChallengeParams{
+		CommunityID:        ladder.CommunityID,
+		ChallengerID:       uuidToPgtype(challengerID),
+		DefenderID:         uuidToPgtype(defenderID),
+		ChallengerPosition: positions[challengerID],
+		DefenderPosition:   positions[defenderID],
+		AcceptDeadline: pgtype.Timestamptz{
+			Time:  time.Now().Add(time.Duration(ladder.AcceptHours) * time.Hour),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create challenge: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, defenderID, challenge, "Вызов на лестнице",
+		fmt.Sprintf("Вас вызвали на матч за %d место. Ответьте до %s", challenge.DefenderPosition,
+			challenge.AcceptDeadline.Time.In(almatyLocation()).Format("02.01.2006 15:04")))
+
+	return ladderChallengeResponse(challenge), nil
+}
+
+// Accept accepts a pending challenge and creates its match
+func (s *LadderService) Accept(ctx context.Context, userID, communityID, challengeID uuid.UUID) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockLadder(ctx, qtx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.lockPendingChallenge(ctx, qtx, ladder, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.DefenderID != uuidToPgtype(userID) {
+		return nil, ErrForbidden.WithMessage("Only the challenged player can respond")
+	}
+	if time.Now().After(challenge.AcceptDeadline.Time) {
+		return nil, ErrValidation.WithMessage("The challenge has expired")
+	}
+
+	match, err := qtx.CreateMatch(ctx, repository.CreateMatchParams{
+		CommunityID: ladder.CommunityID,
+		Player1ID:   challenge.ChallengerID,
+		Player2ID:   challenge.DefenderID,
+		Composition: repository.PlayerCompositionSingles,
+		RoundName:   pgtype.Text{String: "Ladder challenge", Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create challenge match: %w", err)
+	}
+
+	challenge, err = qtx.AcceptLadderChallenge(ctx, repository.AcceptLadderChallengeParams{
+		MatchID: match.ID,
+		PlayDeadline: pgtype.Timestamptz{
+			Time:  time.Now().AddDate(0, 0, int(ladder.PlayDays)),
+			Valid: true,
+		},
+		ID: challenge.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("accept challenge: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, uuid.UUID(challenge.ChallengerID.Bytes), challenge, "Вызов принят",
+		fmt.Sprintf("Соперник принял вызов. Сыграйте матч до %s",
+			challenge.PlayDeadline.Time.In(almatyLocation()).Format("02.01.2006")))
+
+	return ladderChallengeResponse(challenge), nil
+}
+
+// Decline declines a pending challenge; the defender drops decline_penalty places
+func (s *LadderService) Decline(ctx context.Context, userID, communityID, challengeID uuid.UUID) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockLadder(ctx, qtx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.lockPendingChallenge(ctx, qtx, ladder, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.DefenderID != uuidToPgtype(userID) {
+		return nil, ErrForbidden.WithMessage("Only the challenged player can respond")
+	}
+
+	challenge, err = qtx.ResolveLadderChallenge(ctx, repository.ResolveLadderChallengeParams{
+		Status: repository.LadderChallengeStatusDeclined,
+		ID:     challenge.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decline challenge: %w", err)
+	}
+	if err := applyDeclinePenalty(ctx, qtx, ladder, challenge); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, uuid.UUID(challenge.ChallengerID.Bytes), challenge, "Вызов отклонён",
+		"Соперник отклонил ваш вызов и опускается в лестнице")
+
+	return ladderChallengeResponse(challenge), nil
+}
+
+// Cancel withdraws a challenge that has not been answered yet
+func (s *LadderService) Cancel(ctx context.Context, userID, communityID, challengeID uuid.UUID) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := s.lockLadder(ctx, qtx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.lockPendingChallenge(ctx, qtx, ladder, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ChallengerID != uuidToPgtype(userID) {
+		return nil, ErrForbidden.WithMessage("Only the challenger can cancel the challenge")
+	}
+
+	challenge, err = qtx.ResolveLadderChallenge(ctx, repository.ResolveLadderChallengeParams{
+		Status: repository.LadderChallengeStatusCancelled,
+		ID:     challenge.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cancel challenge: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return ladderChallengeResponse(challenge), nil
+}
+
+// ListChallenges returns the ladder's challenges, optionally only those of one
+// player or in one status
+func (s *LadderService) ListChallenges(ctx context.Context, communityID uuid.UUID, userID *uuid.UUID, status string, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	statusFilter := repository.NullLadderChallengeStatus{}
+	if status != "" {
+		switch repository.LadderChallengeStatus(status) {
+		case repository.LadderChallengeStatusPending, repository.LadderChallengeStatusAccepted,
+			repository.LadderChallengeStatusDeclined, repository.LadderChallengeStatusCancelled,
+			repository.LadderChallengeStatusExpired, repository.LadderChallengeStatusCompleted:
+		default:
+			return nil, nil, ErrValidation.WithMessage("Invalid challenge status")
+		}
+		statusFilter = repository.NullLadderChallengeStatus{LadderChallengeStatus: repository.LadderChallengeStatus(status), Valid: true}
+	}
+	userFilter := pgtype.UUID{}
+	if userID != nil {
+		userFilter = uuidToPgtype(*userID)
+	}
+
+	rows, err := s.repo.ListLadderChallenges(ctx, repository.ListLadderChallengesParams{
+		CommunityID:  uuidToPgtype(communityID),
+		UserID:       userFilter,
+		Status:       statusFilter,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list challenges: %w", err)
+	}
+
+	total, err := s.repo.CountLadderChallenges(ctx, repository.CountLadderChallengesParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      userFilter,
+		Status:      statusFilter,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("count challenges: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		challenge := ladderChallengeResponse(repository.LadderChallenge{
+			ID:                 r.ID,
+			CommunityID:        r.CommunityID,
+			ChallengerID:       r.ChallengerID,
+			DefenderID:         r.DefenderID,
+			ChallengerPosition: r.ChallengerPosition,
+			DefenderPosition:   r.DefenderPosition,
+			Status:             r.Status,
+			AcceptDeadline:     r.AcceptDeadline,
+			PlayDeadline:       r.PlayDeadline,
+			MatchID:            r.MatchID,
+			WinnerID:           r.WinnerID,
+			CreatedAt:          r.CreatedAt,
+			RespondedAt:        r.RespondedAt,
+			ResolvedAt:         r.ResolvedAt,
+		})
+		challenge["challenger"] = map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(r.ChallengerID),
+			"first_name": r.ChallengerFirstName.String,
+			"last_name":  r.ChallengerLastName.String,
+		}
+		challenge["defender"] = map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(r.DefenderID),
+			"first_name": r.DefenderFirstName.String,
+			"last_name":  r.DefenderLastName.String,
+		}
+		result = append(result, challenge)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// History returns the ladder's position changes, newest first
+func (s *LadderService) History(ctx context.Context, communityID uuid.UUID, userID *uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	userFilter := pgtype.UUID{}
+	if userID != nil {
+		userFilter = uuidToPgtype(*userID)
+	}
+
+	rows, err := s.repo.ListLadderHistory(ctx, repository.ListLadderHistoryParams{
+		CommunityID:  uuidToPgtype(communityID),
+		UserID:       userFilter,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list ladder history: %w", err)
+	}
+
+	total, err := s.repo.CountLadderHistory(ctx, repository.CountLadderHistoryParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      userFilter,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("count ladder history: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, map[string]interface{}{
+			"id":           pgtypeUUIDToStringRequired(r.ID),
+			"user_id":      pgtypeUUIDToStringRequired(r.UserID),
+			"first_name":   r.FirstName.String,
+			"last_name":    r.LastName.String,
+			"old_position": int4ToIntPtr(r.OldPosition),
+			"new_position": int4ToIntPtr(r.NewPosition),
+			"reason":       string(r.Reason),
+			"challenge_id": pgtypeUUIDToString(r.ChallengeID),
+			"created_at":   r.CreatedAt.Time,
+		})
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+func (s *LadderService) getLadder(ctx context.Context, q *repository.Queries, communityID uuid.UUID) (repository.CommunityLadder, error) {
+	ladder, err := q.GetCommunityLadder(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return ladder, ErrLadderNotFound
+	}
+	if err != nil {
+		return ladder, fmt.Errorf("get ladder: %w", err)
+	}
+	return ladder, nil
+}
+
+func (s *LadderService) lockLadder(ctx context.Context, q *repository.Queries, communityID uuid.UUID) (repository.CommunityLadder, error) {
+	ladder, err := q.LockCommunityLadder(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return ladder, ErrLadderNotFound
+	}
+	if err != nil {
+		return ladder, fmt.Errorf("lock ladder: %w", err)
+	}
+	return ladder, nil
+}
+
+// lockActiveLadder locks a ladder that accepts new players and challenges
+func (s *LadderService) lockActiveLadder(ctx context.Context, q *repository.Queries, communityID uuid.UUID) (repository.CommunityLadder, error) {
+	ladder, err := s.lockLadder(ctx, q, communityID)
+	if err != nil {
+		return ladder, err
+	}
+	if !ladder.IsActive {
+		return ladder, ErrForbidden.WithMessage("The ladder is paused")
+	}
+	active, err := communityIsActive(ctx, q, ladder.CommunityID)
+	if err != nil {
+		return ladder, err
+	}
+	if !active {
+		return ladder, ErrForbidden.WithMessage("Community is deactivated")
+	}
+	return ladder, nil
+}
+
+func (s *LadderService) lockPendingChallenge(ctx context.Context, q *repository.Queries, ladder repository.CommunityLadder, challengeID uuid.UUID) (repository.LadderChallenge, error) {
+	challenge, err := q.LockLadderChallenge(ctx, repository.LockLadderChallengeParams{
+		ID:          uuidToPgtype(challengeID),
+		CommunityID: ladder.CommunityID,
+	})
+	if err == pgx.ErrNoRows {
+		return challenge, ErrChallengeNotFound
+	}
+	if err != nil {
+		return challenge, fmt.Errorf("lock challenge: %w", err)
+	}
+	if challenge.Status != repository.LadderChallengeStatusPending {
+		return challenge, ErrValidation.WithMessage("The challenge is no longer pending")
+	}
+	return challenge, nil
+}
+
+// notify sends a ladder_challenge notification (best-effort)
+func (s *LadderService) notify(ctx context.Context, userID uuid.UUID, c repository.LadderChallenge, title, body string) {
+	if s.notifications == nil {
+		return
+	}
+	if _, err := s.notifications.Create(ctx, userID, string(repository.NotificationTypeLadderChallenge), title, body, ladderChallengeData(c)); err != nil {
+		slog.Warn("failed to create ladder notification", "user_id", userID, "error", err)
+	}
+}
+
+// applyLadderResult resolves the accepted ladder challenge played as the given
+// match, if any. A challenger who wins swaps places with the defender; a
+// defender who wins keeps their place. Called by MatchService inside the
+// confirmation transaction. Like every other ladder write it locks the ladder
+// before the challenge, so it cannot deadlock with the deadline scheduler.
+func applyLadderResult(ctx context.Context, q *repository.Queries, matchID pgtype.UUID, winnerID uuid.UUID) error {
+	found, err := q.GetLadderChallengeByMatch(ctx, matchID)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get ladder challenge: %w", err)
+	}
+
+	if _, err := q.LockCommunityLadder(ctx, found.CommunityID); err != nil {
+		return fmt.Errorf("lock ladder: %w", err)
+	}
+
+	// The challenge may have expired while the ladder lock was awaited
+	challenge, err := q.LockLadderChallenge(ctx, repository.LockLadderChallengeParams{
+		ID:          found.ID,
+		CommunityID: found.CommunityID,
+	})
+	if err != nil {
+		return fmt.Errorf("lock ladder challenge: %w", err)
+	}
+	if challenge.Status != repository.LadderChallengeStatusAccepted || challenge.MatchID != matchID {
+		return nil
+	}
+
+	if _, err := q.ResolveLadderChallenge(ctx, repository.ResolveLadderChallengeParams{
+		Status:   repository.LadderChallengeStatusCompleted,
+		WinnerID: uuidToPgtype(winnerID),
+		ID:       challenge.ID,
+	}); err != nil {
+		return fmt.Errorf("complete ladder challenge: %w", err)
+	}
+
+	if challenge.ChallengerID != uuidToPgtype(winnerID) {
+		return nil
+	}
+
+	challenger, err := q.GetLadderPosition(ctx, repository.GetLadderPositionParams{CommunityID: challenge.CommunityID, UserID: challenge.ChallengerID})
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get challenger position: %w", err)
+	}
+	defender, err := q.GetLadderPosition(ctx, repository.GetLadderPositionParams{CommunityID: challenge.CommunityID, UserID: challenge.DefenderID})
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get defender position: %w", err)
+	}
+
+	// The defender may already have dropped below the challenger
+	if defender.Position > challenger.Position {
+		return nil
+	}
+
+	moves := []struct {
+		user     pgtype.UUID
+		from, to int32
+		reason   repository.LadderChangeReason
+	}{
+		{challenger.UserID, challenger.Position, defender.Position, repository.LadderChangeReasonChallengeWon},
+		{defender.UserID, defender.Position, challenger.Position, repository.LadderChangeReasonChallengeLost},
+	}
+	for _, m := range moves {
+		if err := q.SetLadderPosition(ctx, repository.SetLadderPositionParams{
+			Position:    m.to,
+			CommunityID: challenge.CommunityID,
+			UserID:      m.user,
+		}); err != nil {
+			return fmt.Errorf("set ladder position: %w", err)
+		}
+		if err := q.InsertLadderHistory(ctx, repository.InsertLadderHistoryParams{
+			CommunityID: challenge.CommunityID,
+			UserID:      m.user,
+			OldPosition: pgtype.Int4{Int32: m.from, Valid: true},
+			NewPosition: pgtype.Int4{Int32: m.to, Valid: true},
+			Reason:      m.reason,
+			ChallengeID: challenge.ID,
+		}); err != nil {
+			return fmt.Errorf("insert ladder history: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyDeclinePenalty drops the defender of a declined or lapsed challenge by
+// the ladder's decline_penalty; the players passed move up one place each.
+// The ladder row must be locked by the caller.
+func applyDeclinePenalty(ctx context.Context, q *repository.Queries, ladder repository.CommunityLadder, challenge repository.LadderChallenge) error {
+	defender, err := q.GetLadderPosition(ctx, repository.GetLadderPositionParams{CommunityID: ladder.CommunityID, UserID: challenge.DefenderID})
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get defender position: %w", err)
+	}
+	bottom, err := q.GetLadderBottom(ctx, ladder.CommunityID)
+	if err != nil {
+		return fmt.Errorf("get ladder bottom: %w", err)
+	}
+
+	target := penaltyPosition(defender.Position, ladder.DeclinePenalty, bottom)
+	if target == defender.Position {
+		return nil
+	}
+
+	if err := q.ShiftLadderPositions(ctx, repository.ShiftLadderPositionsParams{
+		Delta:        -1,
+		CommunityID:  ladder.CommunityID,
+		FromPosition: defender.Position + 1,
+		ToPosition:   target,
+		ChallengeID:  challenge.ID,
+	}); err != nil {
+		return fmt.Errorf("shift ladder positions: %w", err)
+	}
+	if err := q.SetLadderPosition(ctx, repository.SetLadderPositionParams{
+		Position:    target,
+		CommunityID: ladder.CommunityID,
+		UserID:      defender.UserID,
+	}); err != nil {
+		return fmt.Errorf("set ladder position: %w", err)
+	}
+	if err := q.InsertLadderHistory(ctx, repository.InsertLadderHistoryParams{
+		CommunityID: ladder.CommunityID,
+		UserID:      defender.UserID,
+		OldPosition: pgtype.Int4{Int32: defender.Position, Valid: true},
+		NewPosition: pgtype.Int4{Int32: target, Valid: true},
+		Reason:      repository.LadderChangeReasonDeclinePenalty,
+		ChallengeID: challenge.ID,
+	}); err != nil {
+		return fmt.Errorf("insert ladder history: %w", err)
+	}
+	return nil
+}
+
+// challengeable reports whether a player at challengerPos may challenge the
+// player at defenderPos (1 is the top of the ladder)
+func challengeable(challengerPos, defenderPos, challengeRange int32) bool {
+	return defenderPos < challengerPos && challengerPos-defenderPos <= challengeRange
+}
+
+// penaltyPosition is where a player at pos lands after dropping penalty
+// places on a ladder whose last position is bottom
+func penaltyPosition(pos, penalty, bottom int32) int32 {
+	target := pos + penalty
+	if target > bottom {
+		target = bottom
+	}
+	if target < pos {
+		return pos
+	}
+	return target
+}
+
+func ladderSettingsResponse(l repository.CommunityLadder) map[string]interface{} {
+	return map[string]interface{}{
+		"community_id":    pgtypeUUIDToStringRequired(l.CommunityID),
+		"challenge_range": l.ChallengeRange,
+		"accept_hours":    l.AcceptHours,
+		"play_days":       l.PlayDays,
+		"decline_penalty": l.DeclinePenalty,
+		"is_active":       l.IsActive,
+		"updated_at":      l.UpdatedAt.Time,
+	}
+}
+
+func ladderChallengeData(c repository.LadderChallenge) map[string]any {
+	return map[string]any{
+		"challenge_id": pgtypeUUIDToStringRequired(c.ID),
+		"community_id": pgtypeUUIDToStringRequired(c.CommunityID),
+		"status":       string(c.Status),
+	}
+}
+
+func ladderChallengeResponse(c repository.LadderChallenge) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                  pgtypeUUIDToStringRequired(c.ID),
+		"community_id":        pgtypeUUIDToStringRequired(c.CommunityID),
+		"challenger_id":       pgtypeUUIDToStringRequired(c.ChallengerID),
+		"defender_id":         pgtypeUUIDToStringRequired(c.DefenderID),
+		"challenger_position": c.ChallengerPosition,
+		"defender_position":   c.DefenderPosition,
+		"status":              string(c.Status),
+		"accept_deadline":     c.AcceptDeadline.Time,
+		"play_deadline":       timestamptzToString(c.PlayDeadline),
+		"match_id":            pgtypeUUIDToString(c.MatchID),
+		"winner_id":           pgtypeUUIDToString(c.WinnerID),
+		"created_at":          c.CreatedAt.Time,
+		"responded_at":        timestamptzToString(c.RespondedAt),
+		"resolved_at":         timestamptzToString(c.ResolvedAt),
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ladderBatchSize limits how many lapsed challenges are processed per tick
+const ladderBatchSize = 200
+
+// LadderScheduler expires ladder challenges whose deadlines have passed. An
+// unanswered challenge counts as declined and costs the defender the
+// ladder's decline penalty; an accepted challenge that was not played in time
+// simply lapses without moving anyone.
+type LadderScheduler struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+	interval      time.Duration
+}
+
+// NewLadderScheduler creates a new LadderScheduler
+func NewLadderScheduler(repo *repository.Queries, pool txStarter, notifications *NotificationService, interval time.Duration) *LadderScheduler {
+	return &LadderScheduler{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+		interval:      interval,
+	}
+}
+
+// Run expires lapsed challenges every interval until ctx is cancelled.
+func (s *LadderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Warn("ladder scheduler tick failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick expires the challenges that were due at now.
+func (s *LadderScheduler) Tick(ctx context.Context, now time.Time) error {
+	due, err := s.repo.ListDueLadderChallenges(ctx, repository.ListDueLadderChallengesParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		BatchLimit: ladderBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list due challenges: %w", err)
+	}
+
+	for _, c := range due {
+		expired, err := s.expire(ctx, c, now)
+		if err != nil {
+			slog.Warn("failed to expire ladder challenge", "challenge_id", pgtypeUUIDToStringRequired(c.ID), "error", err)
+			continue
+		}
+		if expired == nil {
+			continue
+		}
+
+		body := "Матч по вызову не сыгран вовремя, вызов отменён"
+		if c.Status == repository.LadderChallengeStatusPending {
+			body = "Вызов остался без ответа и засчитан как отказ"
+		}
+		for _, id := range []pgtype.UUID{c.ChallengerID, c.DefenderID} {
+			s.notify(ctx, uuid.UUID(id.Bytes), *expired, body)
+		}
+	}
+
+	return nil
+}
+
+// expire resolves one lapsed challenge. It returns nil if the challenge was
+// answered or played in the meantime.
+func (s *LadderScheduler) expire(ctx context.Context, c repository.LadderChallenge, now time.Time) (*repository.LadderChallenge, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	ladder, err := qtx.LockCommunityLadder(ctx, c.CommunityID)
+	if err != nil {
+		return nil, fmt.Errorf("lock ladder: %w", err)
+	}
+	current, err := qtx.LockLadderChallenge(ctx, repository.LockLadderChallengeParams{ID: c.ID, CommunityID: c.CommunityID})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock challenge: %w", err)
+	}
+
+	switch {
+	case current.Status == repository.LadderChallengeStatusPending && current.AcceptDeadline.Time.Before(now):
+	case current.Status == repository.LadderChallengeStatusAccepted && current.PlayDeadline.Time.Before(now):
+	default:
+		return nil, nil
+	}
+
+	expired, err := qtx.ResolveLadderChallenge(ctx, repository.ResolveLadderChallengeParams{
+		Status: repository.LadderChallengeStatusExpired,
+		ID:     current.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("expire challenge: %w", err)
+	}
+	if current.Status == repository.LadderChallengeStatusPending {
+		if err := applyDeclinePenalty(ctx, qtx, ladder, current); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return &expired, nil
+}
+
+func (s *LadderScheduler) notify(ctx context.Context, userID uuid.UUID, c repository.LadderChallenge, body string) {
+	if _, err := s.notifications.Create(ctx, userID, string(repository.NotificationTypeLadderChallenge), "Вызов истёк", body, ladderChallengeData(c)); err != nil {
+		slog.Warn("failed to create ladder notification", "user_id", userID, "error", err)
+	}
+}
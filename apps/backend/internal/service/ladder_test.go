@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestChallengeable(t *testing.T) {
+	tests := []struct {
+		challenger, defender, rng int32
+		want                      bool
+	}{
+		{5, 4, 3, true},
+		{5, 2, 3, true},
+		{5, 1, 3, false},
+		{5, 5, 3, false},
+		{5, 6, 3, false},
+		{2, 1, 1, true},
+	}
+	for _, tt := range tests {
+		if got := challengeable(tt.challenger, tt.defender, tt.rng); got != tt.want {
+			t.Errorf("challengeable(%d, %d, %d) = %v, want %v", tt.challenger, tt.defender, tt.rng, got, tt.want)
+		}
+	}
+}
+
+func TestPenaltyPosition(t *testing.T) {
+	tests := []struct {
+		pos, penalty, bottom, want int32
+	}{
+		{3, 1, 10, 4},
+		{3, 2, 10, 5},
+		{9, 3, 10, 10},
+		{10, 1, 10, 10},
+		{3, 0, 10, 3},
+	}
+	for _, tt := range tests {
+		if got := penaltyPosition(tt.pos, tt.penalty, tt.bottom); got != tt.want {
+			t.Errorf("penaltyPosition(%d, %d, %d) = %d, want %d", tt.pos, tt.penalty, tt.bottom, got, tt.want)
+		}
+	}
+}
+
+func TestLadderSettingsInputApply(t *testing.T) {
+	value := func(i int) *int { return &i }
+	off := false
+
+	ladder := repository.CommunityLadder{ChallengeRange: 3, AcceptHours: 72, PlayDays: 14, DeclinePenalty: 1, IsActive: true}
+	if err := (LadderSettingsInput{ChallengeRange: value(5), DeclinePenalty: value(0), IsActive: &off}).apply(&ladder); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ladder.ChallengeRange != 5 || ladder.DeclinePenalty != 0 || ladder.IsActive || ladder.AcceptHours != 72 || ladder.PlayDays != 14 {
+		t.Errorf("settings not applied: %+v", ladder)
+	}
+
+	invalid := []LadderSettingsInput{
+		{ChallengeRange: value(0)},
+		{ChallengeRange: value(ladderMaxRange + 1)},
+		{AcceptHours: value(0)},
+		{PlayDays: value(ladderMaxPlay + 1)},
+		{DeclinePenalty: value(-1)},
+	}
+	for i, in := range invalid {
+		if err := in.apply(&ladder); err == nil {
+			t.Errorf("case %d: expected a validation error", i)
+		}
+	}
+}
+
+// fakeLadderChallenge serves an accepted challenge for applyLadderResult;
+// locked is the challenge as seen once the ladder lock is held
+func fakeLadderChallenge(db *fakeDB, found, locked repository.LadderChallenge) {
+	db.rows("GetLadderChallengeByMatch", []any{found})
+	db.rows("LockCommunityLadder", []any{repository.CommunityLadder{CommunityID: found.CommunityID, IsActive: true}})
+	db.rows("LockLadderChallenge", []any{locked})
+	db.on("ResolveLadderChallenge", func(args []any) ([][]any, error) {
+		resolved := locked
+		resolved.Status = args[0].(repository.LadderChallengeStatus)
+		return [][]any{{resolved}}, nil
+	})
+	db.on("GetLadderPosition", func(args []any) ([][]any, error) {
+		position := int32(3)
+		if args[1].(pgtype.UUID) == found.DefenderID {
+			position = 2
+		}
+		return [][]any{{repository.LadderPosition{CommunityID: args[0].(pgtype.UUID), UserID: args[1].(pgtype.UUID), Position: position}}}, nil
+	})
+	db.rows("SetLadderPosition", []any{})
+	db.rows("InsertLadderHistory", []any{})
+}
+
+func TestApplyLadderResultLocksLadderFirst(t *testing.T) {
+	challengerID := uuid.New()
+	challenge := repository.LadderChallenge{
+		ID:           uuidToPgtype(uuid.New()),
+		CommunityID:  uuidToPgtype(uuid.New()),
+		ChallengerID: uuidToPgtype(challengerID),
+		DefenderID:   uuidToPgtype(uuid.New()),
+		Status:       repository.LadderChallengeStatusAccepted,
+		MatchID:      uuidToPgtype(uuid.New()),
+	}
+
+	db := newFakeDB()
+	fakeLadderChallenge(db, challenge, challenge)
+	if err := applyLadderResult(context.Background(), db.queries(), challenge.MatchID, challengerID); err != nil {
+		t.Fatalf("applyLadderResult: %v", err)
+	}
+
+	want := []string{
+		"GetLadderChallengeByMatch",
+		"LockCommunityLadder",
+		"LockLadderChallenge",
+		"ResolveLadderChallenge",
+		"GetLadderPosition", "GetLadderPosition",
+		"SetLadderPosition", "InsertLadderHistory",
+		"SetLadderPosition", "InsertLadderHistory",
+	}
+	if got := db.names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("queries = %v\nwant %v", got, want)
+	}
+
+	// the challenger takes the defender's place
+	moves := db.called("SetLadderPosition")
+	if moves[0].Args[0] != int32(2) || moves[0].Args[2] != challenge.ChallengerID {
+		t.Errorf("challenger move = %+v, want position 2", moves[0].Args)
+	}
+}
+
+func TestApplyLadderResultChallengeExpiredMeanwhile(t *testing.T) {
+	challenge := repository.LadderChallenge{
+		ID:           uuidToPgtype(uuid.New()),
+		CommunityID:  uuidToPgtype(uuid.New()),
+		ChallengerID: uuidToPgtype(uuid.New()),
+		DefenderID:   uuidToPgtype(uuid.New()),
+		Status:       repository.LadderChallengeStatusAccepted,
+		MatchID:      uuidToPgtype(uuid.New()),
+	}
+	expired := challenge
+	expired.Status = repository.LadderChallengeStatusExpired
+
+	db := newFakeDB()
+	fakeLadderChallenge(db, challenge, expired)
+	if err := applyLadderResult(context.Background(), db.queries(), challenge.MatchID, uuid.UUID(challenge.ChallengerID.Bytes)); err != nil {
+		t.Fatalf("applyLadderResult: %v", err)
+	}
+	if calls := db.called("ResolveLadderChallenge"); len(calls) != 0 {
+		t.Errorf("resolved an expired challenge: %+v", calls)
+	}
+}
@@ -12,18 +12,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // MatchService handles match business logic
 type MatchService struct {
 	repo          *repository.Queries
-	pool          *pgxpool.Pool
+	pool          txStarter
 	notifications *NotificationService
 }
 
 // NewMatchService creates a new MatchService
-func NewMatchService(repo *repository.Queries, pool *pgxpool.Pool, notifications *NotificationService) *MatchService {
+func NewMatchService(repo *repository.Queries, pool txStarter, notifications *NotificationService) *MatchService {
 	return &MatchService{
 		repo:          repo,
 		pool:          pool,
@@ -350,7 +349,14 @@ func (s *MatchService) confirmMatch(ctx context.Context, confirmerID uuid.UUID,
 	}
 
 	// 7. Update community member stats if match is within a community
+	// (a deactivated community's leaderboard stays frozen)
+	inActiveCommunity := false
 	if match.CommunityID.Valid {
+		if inActiveCommunity, err = communityIsActive(ctx, qtx, match.CommunityID); err != nil {
+			return nil, err
+		}
+	}
+	if inActiveCommunity {
 		if err := qtx.UpdateCommunityMemberStats(ctx, repository.UpdateCommunityMemberStatsParams{
 			NewRating:   floatToNumeric(ratingChange.WinnerNewRating),
 			IsWinner:    true,
@@ -393,6 +399,11 @@ func (s *MatchService) confirmMatch(ctx context.Context, confirmerID uuid.UUID,
 		}
 	}
 
+	// 8. Swap ladder positions if this was a ladder challenge match
+	if err := applyLadderResult(ctx, qtx, match.ID, winnerID); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -404,6 +415,9 @@ func (s *MatchService) confirmMatch(ctx context.Context, confirmerID uuid.UUID,
 		s.notifyRatingChanged(ctx, winnerID, loserID, match.ID, ratingChange)
 	}
 
+	// Share the result to the feed for players who opted in (best-effort)
+	s.postMatchResult(ctx, confirmed)
+
 	return &resp, nil
 }
 
@@ -574,7 +588,13 @@ func (s *MatchService) AdminConfirm(ctx context.Context, adminID uuid.UUID, matc
 		return nil, fmt.Errorf("insert loser rating history: %w", err)
 	}
 
+	inActiveCommunity := false
 	if match.CommunityID.Valid {
+		if inActiveCommunity, err = communityIsActive(ctx, qtx, match.CommunityID); err != nil {
+			return nil, err
+		}
+	}
+	if inActiveCommunity {
 		if err := qtx.UpdateCommunityMemberStats(ctx, repository.UpdateCommunityMemberStatsParams{
 			NewRating:   floatToNumeric(ratingChange.WinnerNewRating),
 			IsWinner:    true,
@@ -593,6 +613,10 @@ func (s *MatchService) AdminConfirm(ctx context.Context, adminID uuid.UUID, matc
 		}
 	}
 
+	if err := applyLadderResult(ctx, qtx, match.ID, winnerUUID); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -604,6 +628,9 @@ func (s *MatchService) AdminConfirm(ctx context.Context, adminID uuid.UUID, matc
 		s.notifyRatingChanged(ctx, winnerUUID, loserID, match.ID, ratingChange)
 	}
 
+	// Share the result to the feed for players who opted in (best-effort)
+	s.postMatchResult(ctx, confirmed)
+
 	return &resp, nil
 }
 
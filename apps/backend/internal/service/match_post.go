@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// matchResultRating is one player's rating line in a result post
+type matchResultRating struct {
+	Name   string
+	Before float64
+	After  float64
+}
+
+// matchResultSummary is what a result post shows. Score is from side 1's
+// point of view; Ratings only holds players who share their results.
+type matchResultSummary struct {
+	Side1    string
+	Side2    string
+	Side1Won bool
+	Score    []SetScore
+	Ratings  []matchResultRating
+}
+
+// postMatchResult publishes a confirmed match to the feed, best effort. Each
+// participant, partners included, who opted in through share_match_results
+// gets a post on their own feed; a community match is also posted to the
+// community feed when every participant opted in. Partners who opted out are
+// not named, and rating changes are only shown for players who opted in.
+func (s *MatchService) postMatchResult(ctx context.Context, m repository.Match) {
+	ids := []pgtype.UUID{m.Player1ID, m.Player2ID}
+	for _, partner := range []pgtype.UUID{m.Player1PartnerID, m.Player2PartnerID} {
+		if partner.Valid {
+			ids = append(ids, partner)
+		}
+	}
+
+	players, err := s.repo.ListMatchPostPlayers(ctx, ids)
+	if err != nil {
+		slog.Warn("failed to load players for match post", "match_id", pgtypeUUIDToStringRequired(m.ID), "error", err)
+		return
+	}
+	byID := make(map[[16]byte]repository.ListMatchPostPlayersRow, len(players))
+	for _, p := range players {
+		byID[p.ID.Bytes] = p
+	}
+
+	sharing := make([]repository.ListMatchPostPlayersRow, 0, len(ids))
+	for _, id := range ids {
+		if p := byID[id.Bytes]; p.ShareMatchResults {
+			sharing = append(sharing, p)
+		}
+	}
+	if len(sharing) == 0 {
+		return
+	}
+
+	player1, player2 := byID[m.Player1ID.Bytes], byID[m.Player2ID.Bytes]
+	side := func(player, partner pgtype.UUID) string {
+		name := fullName(byID[player.Bytes].FirstName, byID[player.Bytes].LastName)
+		if partner.Valid {
+			if p := byID[partner.Bytes]; p.ShareMatchResults {
+				name += " / " + fullName(p.FirstName, p.LastName)
+			} else {
+				name += " / партнёр"
+			}
+		}
+		return name
+	}
+
+	summary := matchResultSummary{
+		Side1:    side(m.Player1ID, m.Player1PartnerID),
+		Side2:    side(m.Player2ID, m.Player2PartnerID),
+		Side1Won: m.WinnerID.Bytes == m.Player1ID.Bytes,
+	}
+	if len(m.Score) > 0 {
+		_ = json.Unmarshal(m.Score, &summary.Score)
+	}
+	if player1.ShareMatchResults && m.Player1RatingAfter.Valid {
+		summary.Ratings = append(summary.Ratings, matchResultRating{
+			Name:   fullName(player1.FirstName, player1.LastName),
+			Before: numericToFloat(m.Player1RatingBefore),
+			After:  numericToFloat(m.Player1RatingAfter),
+		})
+	}
+	if player2.ShareMatchResults && m.Player2RatingAfter.Valid {
+		summary.Ratings = append(summary.Ratings, matchResultRating{
+			Name:   fullName(player2.FirstName, player2.LastName),
+			Before: numericToFloat(m.Player2RatingBefore),
+			After:  numericToFloat(m.Player2RatingAfter),
+		})
+	}
+	content := matchResultContent(summary)
+
+	posts := make([]repository.CreateMatchResultPostParams, 0, len(sharing)+1)
+	for _, p := range sharing {
+		posts = append(posts, repository.CreateMatchResultPostParams{
+			AuthorType:   repository.PostAuthorTypeUser,
+			AuthorUserID: p.ID,
+		})
+	}
+	if m.CommunityID.Valid && len(sharing) == len(ids) {
+		active, err := communityIsActive(ctx, s.repo, m.CommunityID)
+		if err != nil {
+			slog.Warn("failed to check community for match post", "match_id", pgtypeUUIDToStringRequired(m.ID), "error", err)
+		}
+		if active {
+			posts = append(posts, repository.CreateMatchResultPostParams{
+				AuthorType:        repository.PostAuthorTypeCommunity,
+				AuthorCommunityID: m.CommunityID,
+			})
+		}
+	}
+
+	for _, post := range posts {
+		post.Content = content
+		post.MatchID = m.ID
+		if _, err := s.repo.CreateMatchResultPost(ctx, post); err != nil {
+			slog.Warn("failed to create match result post", "match_id", pgtypeUUIDToStringRequired(m.ID), "author_type", post.AuthorType, "error", err)
+		}
+	}
+}
+
+// matchResultContent renders the text of a result post
+func matchResultContent(r matchResultSummary) string {
+	winner := r.Side2
+	if r.Side1Won {
+		winner = r.Side1
+	}
+
+	lines := []string{fmt.Sprintf("Результат матча: %s — %s", r.Side1, r.Side2)}
+	if len(r.Score) > 0 {
+		sets := make([]string, 0, len(r.Score))
+		for _, set := range r.Score {
+			sets = append(sets, formatSetScore(set))
+		}
+		lines = append(lines, "Счёт: "+strings.Join(sets, ", "))
+	}
+	lines = append(lines, "Победа: "+winner)
+	for _, rating := range r.Ratings {
+		lines = append(lines, fmt.Sprintf("%s: %.1f → %.1f (%+.1f)", rating.Name, rating.Before, rating.After, rating.After-rating.Before))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatSetScore renders a set as 6:4, or 7:6(5) with the tiebreak points
+func formatSetScore(set SetScore) string {
+	score := fmt.Sprintf("%d:%d", set.Player1, set.Player2)
+	if set.Tiebreak != nil {
+		score += fmt.Sprintf("(%d)", *set.Tiebreak)
+	}
+	return score
+}
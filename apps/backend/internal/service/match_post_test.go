@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestFormatSetScore(t *testing.T) {
+	five := 5
+
+	tests := []struct {
+		set  SetScore
+		want string
+	}{
+		{SetScore{Player1: 6, Player2: 4}, "6:4"},
+		{SetScore{Player1: 3, Player2: 6}, "3:6"},
+		{SetScore{Player1: 7, Player2: 6, Tiebreak: &five}, "7:6(5)"},
+	}
+	for _, tt := range tests {
+		if got := formatSetScore(tt.set); got != tt.want {
+			t.Errorf("formatSetScore(%+v) = %q, want %q", tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestMatchResultContent(t *testing.T) {
+	five := 5
+
+	tests := []struct {
+		name    string
+		summary matchResultSummary
+		want    string
+	}{
+		{
+			name: "singles with both ratings",
+			summary: matchResultSummary{
+				Side1:    "Алма Амирсеитов",
+				Side2:    "Данияр Ержанов",
+				Side1Won: true,
+				Score:    []SetScore{{Player1: 6, Player2: 4}, {Player1: 3, Player2: 6}, {Player1: 7, Player2: 6, Tiebreak: &five}},
+				Ratings: []matchResultRating{
+					{Name: "Алма Амирсеитов", Before: 3.5, After: 3.62},
+					{Name: "Данияр Ержанов", Before: 3.8, After: 3.68},
+				},
+			},
+			want: "Результат матча: Алма Амирсеитов — Данияр Ержанов\n" +
+				"Счёт: 6:4, 3:6, 7:6(5)\n" +
+				"Победа: Алма Амирсеитов\n" +
+				"Алма Амирсеитов: 3.5 → 3.6 (+0.1)\n" +
+				"Данияр Ержанов: 3.8 → 3.7 (-0.1)",
+		},
+		{
+			name: "doubles without score or ratings",
+			summary: matchResultSummary{
+				Side1: "Алма Амирсеитов / Айгерим Касымова",
+				Side2: "Данияр Ержанов / Тимур Ахметов",
+			},
+			want: "Результат матча: Алма Амирсеитов / Айгерим Касымова — Данияр Ержанов / Тимур Ахметов\n" +
+				"Победа: Данияр Ержанов / Тимур Ахметов",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchResultContent(tt.summary); got != tt.want {
+				t.Errorf("matchResultContent() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostMatchResultChecksEveryDoublesPlayer(t *testing.T) {
+	player := func(first, last string, shares bool) repository.ListMatchPostPlayersRow {
+		return repository.ListMatchPostPlayersRow{
+			ID:                uuidToPgtype(uuid.New()),
+			FirstName:         pgtype.Text{String: first, Valid: true},
+			LastName:          pgtype.Text{String: last, Valid: true},
+			ShareMatchResults: shares,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		shares     [4]bool // player1, partner1, player2, partner2
+		wantUsers  int
+		wantHidden bool
+		wantCommon bool
+	}{
+		{"partner opted in, opponent partner opted out", [4]bool{false, true, true, false}, 2, true, false},
+		{"everyone opted in", [4]bool{true, true, true, true}, 4, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p1 := player("Алма", "Амирсеитов", tt.shares[0])
+			p1Partner := player("Айгерим", "Серикова", tt.shares[1])
+			p2 := player("Данияр", "Ержанов", tt.shares[2])
+			p2Partner := player("Ерлан", "Касымов", tt.shares[3])
+
+			db := newFakeDB()
+			db.rows("ListMatchPostPlayers", []any{p1}, []any{p1Partner}, []any{p2}, []any{p2Partner})
+			db.rows("GetCommunityActiveState", []any{repository.GetCommunityActiveStateRow{IsActive: pgtype.Bool{Bool: true, Valid: true}}})
+			db.rows("CreateMatchResultPost", []any{})
+
+			service := &MatchService{repo: db.queries()}
+			service.postMatchResult(context.Background(), repository.Match{
+				ID:               uuidToPgtype(uuid.New()),
+				CommunityID:      uuidToPgtype(uuid.New()),
+				Player1ID:        p1.ID,
+				Player1PartnerID: p1Partner.ID,
+				Player2ID:        p2.ID,
+				Player2PartnerID: p2Partner.ID,
+				WinnerID:         p1.ID,
+			})
+
+			var users, community int
+			for _, call := range db.called("CreateMatchResultPost") {
+				switch call.Args[0].(repository.PostAuthorType) {
+				case repository.PostAuthorTypeUser:
+					users++
+					for i, p := range []repository.ListMatchPostPlayersRow{p1, p1Partner, p2, p2Partner} {
+						if call.Args[1] == p.ID && !tt.shares[i] {
+							t.Errorf("posted to %s, who opted out", p.FirstName.String)
+						}
+					}
+				case repository.PostAuthorTypeCommunity:
+					community++
+				}
+				content := call.Args[3].(string)
+				if hidden := !strings.Contains(content, "Ерлан Касымов"); hidden != tt.wantHidden {
+					t.Errorf("opted-out partner hidden = %v, want %v in %q", hidden, tt.wantHidden, content)
+				}
+			}
+			if users != tt.wantUsers || (community == 1) != tt.wantCommon {
+				t.Errorf("user posts = %d, community posts = %d, want %d and community %v", users, community, tt.wantUsers, tt.wantCommon)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxModerationReasonLength limits the reason attached to a kick or ban
+const maxModerationReasonLength = 500
+
+// ChatRooms drops users from live chat rooms (implemented by ws.Hub)
+type ChatRooms interface {
+	LeaveRoom(chatID, userID uuid.UUID)
+}
+
+// ModerationService handles kicks and bans issued by community staff.
+// Every action is kept in the member's moderation history and in audit_logs.
+type ModerationService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	notifications *NotificationService
+	rooms         ChatRooms
+	news          *CommunityNews
+}
+
+// NewModerationService creates a new ModerationService
+func NewModerationService(repo *repository.Queries, pool txStarter, notifications *NotificationService, rooms ChatRooms, news *CommunityNews) *ModerationService {
+	return &ModerationService{
+		repo:          repo,
+		pool:          pool,
+		notifications: notifications,
+		rooms:         rooms,
+		news:          news,
+	}
+}
+
+// BanInput represents a ban. Until is optional: without it the ban is permanent.
+type BanInput struct {
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until"`
+}
+
+// Kick removes a member from the community. Unlike a ban, they may join again.
+func (s *ModerationService) Kick(ctx context.Context, actorID, communityID, targetUserID uuid.UUID, reason string) error {
+	reason, err := normalizeModerationReason(reason, false)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	target, err := s.authorize(ctx, qtx, actorID, communityID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target.Status.MemberStatus == repository.MemberStatusBanned {
+		return ErrValidation.WithMessage("Member is banned; unban them instead")
+	}
+
+	if err := qtx.DeleteCommunityMember(ctx, repository.DeleteCommunityMemberParams{
+		CommunityID: target.CommunityID,
+		UserID:      target.UserID,
+	}); err != nil {
+		return fmt.Errorf("delete member: %w", err)
+	}
+
+	if err := s.record(ctx, qtx, actorID, target, repository.ModerationActionKick, reason, pgtype.Timestamptz{}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.leaveCommunityChat(ctx, target)
+	s.news.Unsubscribe(ctx, targetUserID, communityID)
+	s.notify(ctx, targetUserID, target.CommunityID, "Вас исключили из сообщества", reason)
+	return nil
+}
+
+// Ban blocks a member from the community, its chat and from rejoining
+func (s *ModerationService) Ban(ctx context.Context, actorID, communityID, targetUserID uuid.UUID, input BanInput) error {
+	reason, err := normalizeModerationReason(input.Reason, true)
+	if err != nil {
+		return err
+	}
+
+	var until pgtype.Timestamptz
+	if input.Until != nil {
+		if !input.Until.After(time.Now()) {
+			return ErrValidation.WithMessage("until must be in the future")
+		}
+		until = pgtype.Timestamptz{Time: *input.Until, Valid: true}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	target, err := s.authorize(ctx, qtx, actorID, communityID, targetUserID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := qtx.UpdateCommunityMemberStatus(ctx, repository.UpdateCommunityMemberStatusParams{
+		CommunityID: target.CommunityID,
+		UserID:      target.UserID,
+		Status:      repository.NullMemberStatus{MemberStatus: repository.MemberStatusBanned, Valid: true},
+		ReviewedBy:  uuidToPgtype(actorID),
+	}); err != nil {
+		return fmt.Errorf("ban member: %w", err)
+	}
+
+	if err := s.record(ctx, qtx, actorID, target, repository.ModerationActionBan, reason, until); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.leaveCommunityChat(ctx, target)
+	s.news.Unsubscribe(ctx, targetUserID, communityID)
+
+	title := "Вы заблокированы в сообществе"
+	if until.Valid {
+		title = fmt.Sprintf("Вы заблокированы в сообществе до %s", until.Time.In(almatyLocation()).Format("02.01.2006 15:04"))
+	}
+	s.notify(ctx, targetUserID, target.CommunityID, title, reason)
+	return nil
+}
+
+// Unban lifts a ban. The user is no longer a member and may join again.
+func (s *ModerationService) Unban(ctx context.Context, actorID, communityID, targetUserID uuid.UUID) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	target, err := s.authorize(ctx, qtx, actorID, communityID, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target.Status.MemberStatus != repository.MemberStatusBanned {
+		return ErrValidation.WithMessage("Member is not banned")
+	}
+
+	if _, err := qtx.UpdateCommunityMemberStatus(ctx, repository.UpdateCommunityMemberStatusParams{
+		CommunityID: target.CommunityID,
+		UserID:      target.UserID,
+		Status:      repository.NullMemberStatus{MemberStatus: repository.MemberStatusLeft, Valid: true},
+		ReviewedBy:  uuidToPgtype(actorID),
+	}); err != nil {
+		return fmt.Errorf("unban member: %w", err)
+	}
+
+	if err := s.record(ctx, qtx, actorID, target, repository.ModerationActionUnban, "", pgtype.Timestamptz{}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notify(ctx, targetUserID, target.CommunityID, "Блокировка в сообществе снята", "Вы можете снова вступить в сообщество")
+	return nil
+}
+
+// History returns the moderation actions taken against a user, newest first
+func (s *ModerationService) History(ctx context.Context, communityID, targetUserID uuid.UUID) ([]map[string]interface{}, error) {
+	actions, err := s.repo.ListModerationActions(ctx, repository.ListModerationActionsParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(targetUserID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list moderation actions: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(actions))
+	for _, a := range actions {
+		action := map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(a.ID),
+			"action":     string(a.Action),
+			"reason":     a.Reason.String,
+			"created_at": a.CreatedAt.Time,
+			"actor":      nil,
+		}
+		if a.ActorID.Valid {
+			action["actor"] = map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(a.ActorID),
+				"first_name": a.ActorFirstName.String,
+				"last_name":  a.ActorLastName.String,
+			}
+		}
+		if a.Action == repository.ModerationActionBan {
+			action["banned_until"] = nil
+			if a.BannedUntil.Valid {
+				action["banned_until"] = a.BannedUntil.Time
+			}
+		}
+		result = append(result, action)
+	}
+	return result, nil
+}
+
+// authorize loads the target member and checks the actor outranks them
+func (s *ModerationService) authorize(ctx context.Context, q *repository.Queries, actorID, communityID, targetUserID uuid.UUID) (repository.CommunityMember, error) {
+	if actorID == targetUserID {
+		return repository.CommunityMember{}, ErrForbidden.WithMessage("You cannot moderate yourself")
+	}
+
+	actor, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(actorID),
+	})
+	if err != nil {
+		return repository.CommunityMember{}, ErrForbidden
+	}
+
+	target, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(targetUserID),
+	})
+	if err == pgx.ErrNoRows {
+		return repository.CommunityMember{}, ErrNotCommunityMember.WithMessage("User is not a member of this community")
+	}
+	if err != nil {
+		return repository.CommunityMember{}, fmt.Errorf("get target member: %w", err)
+	}
+
+	if communityRoleRank(actor.Role.CommunityRole) <= communityRoleRank(target.Role.CommunityRole) {
+		return repository.CommunityMember{}, ErrInsufficientRole.WithMessage("You can only moderate members with a lower role")
+	}
+
+	return target, nil
+}
+
+// record stores the action in the member's history and in audit_logs
+func (s *ModerationService) record(
+	ctx context.Context,
+	q *repository.Queries,
+	actorID uuid.UUID,
+	target repository.CommunityMember,
+	action repository.ModerationAction,
+	reason string,
+	until pgtype.Timestamptz,
+) error {
+	if _, err := q.CreateModerationAction(ctx, repository.CreateModerationActionParams{
+		CommunityID: target.CommunityID,
+		UserID:      target.UserID,
+		ActorID:     uuidToPgtype(actorID),
+		Action:      action,
+		Reason:      pgtype.Text{String: reason, Valid: reason != ""},
+		BannedUntil: until,
+	}); err != nil {
+		return fmt.Errorf("create moderation action: %w", err)
+	}
+
+	details := map[string]interface{}{
+		"user_id": pgtypeUUIDToStringRequired(target.UserID),
+		"role":    string(target.Role.CommunityRole),
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	if until.Valid {
+		details["until"] = until.Time
+	}
+	detailsJSON, _ := json.Marshal(details)
+
+	if err := q.CreateAuditLog(ctx, repository.CreateAuditLogParams{
+		ActorID:    uuidToPgtype(actorID),
+		Action:     "community.member_" + string(action),
+		EntityType: pgtype.Text{String: "community", Valid: true},
+		EntityID:   target.CommunityID,
+		Details:    detailsJSON,
+	}); err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+	return nil
+}
+
+// leaveCommunityChat stops live chat updates for a removed member
+func (s *ModerationService) leaveCommunityChat(ctx context.Context, target repository.CommunityMember) {
+	if s.rooms == nil {
+		return
+	}
+	chat, err := s.repo.GetCommunityChatByCommunityID(ctx, target.CommunityID)
+	if err != nil {
+		return
+	}
+	s.rooms.LeaveRoom(uuid.UUID(chat.ID.Bytes), uuid.UUID(target.UserID.Bytes))
+}
+
+func (s *ModerationService) notify(ctx context.Context, userID uuid.UUID, communityID pgtype.UUID, title, body string) {
+	if body == "" {
+		body = title
+	}
+	_, err := s.notifications.Create(ctx, userID,
+		string(repository.NotificationTypeCommunityModeration),
+		title,
+		body,
+		map[string]any{
+			"community_id": pgtypeUUIDToStringRequired(communityID),
+		},
+	)
+	if err != nil {
+		slog.Warn("failed to send moderation notification", "error", err)
+	}
+}
+
+// banLifted reports whether a member's temporary ban has run out
+func banLifted(ctx context.Context, q *repository.Queries, member repository.CommunityMember, now time.Time) (bool, error) {
+	ban, err := q.GetLatestBan(ctx, repository.GetLatestBanParams{
+		CommunityID: member.CommunityID,
+		UserID:      member.UserID,
+	})
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get latest ban: %w", err)
+	}
+	return ban.BannedUntil.Valid && !ban.BannedUntil.Time.After(now), nil
+}
+
+// communityRoleRank orders roles for moderation: staff may only act on lower ranks
+func communityRoleRank(role repository.CommunityRole) int {
+	switch role {
+	case repository.CommunityRoleOwner:
+		return 4
+	case repository.CommunityRoleAdmin:
+		return 3
+	case repository.CommunityRoleModerator:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func normalizeModerationReason(reason string, required bool) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if required && reason == "" {
+		return "", ErrValidation.WithMessage("A reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxModerationReasonLength {
+		return "", ErrValidation.WithMessage(fmt.Sprintf("Reason must be at most %d characters", maxModerationReasonLength))
+	}
+	return reason, nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+)
+
+func TestCommunityRoleRank(t *testing.T) {
+	ordered := []repository.CommunityRole{
+		repository.CommunityRoleMember,
+		repository.CommunityRoleModerator,
+		repository.CommunityRoleAdmin,
+		repository.CommunityRoleOwner,
+	}
+	for i := 1; i < len(ordered); i++ {
+		if communityRoleRank(ordered[i]) <= communityRoleRank(ordered[i-1]) {
+			t.Errorf("%s should outrank %s", ordered[i], ordered[i-1])
+		}
+	}
+
+	if communityRoleRank(repository.CommunityRoleCoachReferee) != communityRoleRank(repository.CommunityRoleMember) {
+		t.Error("coach_referee should rank as a regular member")
+	}
+}
+
+func TestNormalizeModerationReason(t *testing.T) {
+	if _, err := normalizeModerationReason("  ", true); err == nil {
+		t.Error("expected error for missing required reason")
+	}
+	if reason, err := normalizeModerationReason("  спам ", false); err != nil || reason != "спам" {
+		t.Errorf("expected trimmed reason, got %q (%v)", reason, err)
+	}
+	if _, err := normalizeModerationReason(strings.Repeat("я", maxModerationReasonLength+1), false); err == nil {
+		t.Error("expected error for too long reason")
+	}
+}
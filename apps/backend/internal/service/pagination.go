@@ -0,0 +1,15 @@
+package service
+
+// newPaginationInfo describes the page of a listing with total matching rows
+func newPaginationInfo(page, perPage int, total int64) *PaginationInfo {
+	totalPages := int(total) / perPage
+	if int(total)%perPage > 0 {
+		totalPages++
+	}
+	return &PaginationInfo{
+		Page:       page,
+		PerPage:    perPage,
+		Total:      int(total),
+		TotalPages: totalPages,
+	}
+}
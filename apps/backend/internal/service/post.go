@@ -0,0 +1,528 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	postMaxContent = 5000
+	postMaxPhotos  = 10
+	feedMaxLimit   = 50
+	// postMaxScheduleAhead bounds how far ahead a community post can be scheduled
+	postMaxScheduleAhead = 90 * 24 * time.Hour
+)
+
+// PostService manages posts, likes and the personal feed. A user post is seen
+// by its author and their friends; a community post is seen by the
+// community's active members and can only be written by its owner or admins.
+// Community posts can be scheduled; they stay unpublished until the
+// PostScheduler releases them.
+type PostService struct {
+	repo    *repository.Queries
+	pool    txStarter
+	storage *StorageService
+}
+
+// NewPostService creates a new PostService
+func NewPostService(repo *repository.Queries, pool txStarter, storage *StorageService) *PostService {
+	return &PostService{
+		repo:    repo,
+		pool:    pool,
+		storage: storage,
+	}
+}
+
+// CreatePostInput is a new post. Photos hold the raw image files; CommunityID
+// publishes the post on behalf of the community, at ScheduledAt if set.
+type CreatePostInput struct {
+	Content     string
+	CommunityID *uuid.UUID
+	ScheduledAt *time.Time
+	Photos      [][]byte
+}
+
+// validate trims the content and checks the post is neither empty nor too large.
+// It returns the content type of each photo.
+func (in *CreatePostInput) validate() ([]string, error) {
+	in.Content = strings.TrimSpace(in.Content)
+	if in.Content == "" && len(in.Photos) == 0 {
+		return nil, ErrValidation.WithMessage("content or photos are required")
+	}
+	if utf8.RuneCountInString(in.Content) > postMaxContent {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("content must be at most %d characters", postMaxContent))
+	}
+	if len(in.Photos) > postMaxPhotos {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("at most %d photos are allowed", postMaxPhotos))
+	}
+	if in.ScheduledAt != nil {
+		if in.CommunityID == nil {
+			return nil, ErrValidation.WithMessage("only community posts can be scheduled")
+		}
+		if err := validatePostSchedule(*in.ScheduledAt, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	contentTypes := make([]string, 0, len(in.Photos))
+	for _, photo := range in.Photos {
+		contentType, err := ValidateImage(photo)
+		if err != nil {
+			return nil, err
+		}
+		contentTypes = append(contentTypes, contentType)
+	}
+	return contentTypes, nil
+}
+
+// Create uploads the photos and publishes the post, or schedules it
+func (s *PostService) Create(ctx context.Context, userID uuid.UUID, input CreatePostInput) (map[string]interface{}, error) {
+	contentTypes, err := input.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	params := repository.CreatePostParams{
+		AuthorType:    repository.PostAuthorTypeUser,
+		AuthorUserID:  uuidToPgtype(userID),
+		Content:       input.Content,
+		IsMatchResult: pgtype.Bool{Bool: false, Valid: true},
+		IsPublished:   pgtype.Bool{Bool: input.ScheduledAt == nil, Valid: true},
+	}
+	if input.ScheduledAt != nil {
+		params.ScheduledAt = pgtype.Timestamptz{Time: *input.ScheduledAt, Valid: true}
+	}
+	if input.CommunityID != nil {
+		if err := s.requireCommunityAdmin(ctx, *input.CommunityID, userID); err != nil {
+			return nil, err
+		}
+		params.AuthorType = repository.PostAuthorTypeCommunity
+		params.AuthorCommunityID = uuidToPgtype(*input.CommunityID)
+	}
+
+	urls, err := s.uploadPhotos(ctx, userID, input.Photos, contentTypes)
+	if err != nil {
+		return nil, err
+	}
+	params.Photos, _ = json.Marshal(urls)
+
+	post, err := s.repo.CreatePost(ctx, params)
+	if err != nil {
+		s.deletePhotos(ctx, urls)
+		return nil, fmt.Errorf("create post: %w", err)
+	}
+
+	if !post.IsPublished.Bool {
+		return scheduledPostResponse(post), nil
+	}
+	return s.Get(ctx, userID, uuid.UUID(post.ID.Bytes))
+}
+
+// Get returns a post the user is allowed to see
+func (s *PostService) Get(ctx context.Context, userID, postID uuid.UUID) (map[string]interface{}, error) {
+	post, err := s.repo.GetVisiblePost(ctx, repository.GetVisiblePostParams{
+		UserID: uuidToPgtype(userID),
+		ID:     uuidToPgtype(postID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get post: %w", err)
+	}
+	return postResponse(repository.ListFeedRow(post)), nil
+}
+
+// Delete removes a post. Users delete their own posts; community posts can be
+// deleted by any owner or admin of the community.
+func (s *PostService) Delete(ctx context.Context, userID, postID uuid.UUID) error {
+	post, err := s.repo.GetPostByID(ctx, uuidToPgtype(postID))
+	if err == pgx.ErrNoRows {
+		return ErrPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get post: %w", err)
+	}
+
+	if post.AuthorType == repository.PostAuthorTypeCommunity {
+		if err := s.requireCommunityAdmin(ctx, uuid.UUID(post.AuthorCommunityID.Bytes), userID); err != nil {
+			return err
+		}
+	} else if post.AuthorUserID.Bytes != userID {
+		return ErrForbidden.WithMessage("You can only delete your own posts")
+	}
+
+	if err := s.repo.DeletePost(ctx, post.ID); err != nil {
+		return fmt.Errorf("delete post: %w", err)
+	}
+
+	s.deletePhotos(ctx, postPhotos(post.Photos))
+	return nil
+}
+
+// Like adds the user's like; liking twice is a no-op
+func (s *PostService) Like(ctx context.Context, userID, postID uuid.UUID) (map[string]interface{}, error) {
+	if _, err := s.Get(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.LikePost(ctx, repository.LikePostParams{
+		PostID: uuidToPgtype(postID),
+		UserID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("like post: %w", err)
+	}
+
+	return map[string]interface{}{
+		"liked":      true,
+		"like_count": count.Int32,
+	}, nil
+}
+
+// Unlike removes the user's like; unliking a post that is not liked is a no-op
+func (s *PostService) Unlike(ctx context.Context, userID, postID uuid.UUID) (map[string]interface{}, error) {
+	if _, err := s.Get(ctx, userID, postID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.UnlikePost(ctx, repository.UnlikePostParams{
+		PostID: uuidToPgtype(postID),
+		UserID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unlike post: %w", err)
+	}
+
+	return map[string]interface{}{
+		"liked":      false,
+		"like_count": count.Int32,
+	}, nil
+}
+
+// Feed returns the user's own posts, their friends' posts and the posts of
+// their communities, newest first. cursor is the next_cursor of the previous
+// page and is empty for the first one.
+func (s *PostService) Feed(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]map[string]interface{}, string, bool, error) {
+	if limit <= 0 || limit > feedMaxLimit {
+		limit = 20
+	}
+
+	params := repository.ListFeedParams{
+		UserID:      uuidToPgtype(userID),
+		ResultLimit: int32(limit + 1), // Fetch one extra to determine has_more
+	}
+	if cursor != "" {
+		before, beforeID, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, "", false, err
+		}
+		params.BeforeTime = pgtype.Timestamptz{Time: before, Valid: true}
+		params.BeforeID = uuidToPgtype(beforeID)
+	}
+
+	rows, err := s.repo.ListFeed(ctx, params)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("list feed: %w", err)
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	posts := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, postResponse(row))
+	}
+
+	nextCursor := ""
+	if hasMore {
+		last := rows[len(rows)-1]
+		nextCursor = encodeFeedCursor(last.CreatedAt.Time, uuid.UUID(last.ID.Bytes))
+	}
+
+	return posts, nextCursor, hasMore, nil
+}
+
+// ListScheduled returns the community's scheduled posts, soonest first
+func (s *PostService) ListScheduled(ctx context.Context, communityID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	posts, err := s.repo.ListScheduledPosts(ctx, repository.ListScheduledPostsParams{
+		CommunityID:  uuidToPgtype(communityID),
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list scheduled posts: %w", err)
+	}
+
+	total, err := s.repo.CountScheduledPosts(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count scheduled posts: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(posts))
+	for _, p := range posts {
+		result = append(result, scheduledPostResponse(p))
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// UpdateScheduledPostInput changes the text or the publish time of a scheduled post
+type UpdateScheduledPostInput struct {
+	Content     *string    `json:"content"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// UpdateScheduled edits a community post that has not been published yet.
+// Posts of a deactivated community cannot be edited.
+func (s *PostService) UpdateScheduled(ctx context.Context, communityID, postID uuid.UUID, input UpdateScheduledPostInput) (map[string]interface{}, error) {
+	if err := s.requireActiveCommunity(ctx, communityID); err != nil {
+		return nil, err
+	}
+
+	post, err := s.repo.GetScheduledPost(ctx, repository.GetScheduledPostParams{
+		ID:          uuidToPgtype(postID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrPostNotFound.WithMessage("Scheduled post not found or already published")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get scheduled post: %w", err)
+	}
+
+	content := post.Content
+	if input.Content != nil {
+		content = strings.TrimSpace(*input.Content)
+		if content == "" && len(postPhotos(post.Photos)) == 0 {
+			return nil, ErrValidation.WithMessage("content or photos are required")
+		}
+		if utf8.RuneCountInString(content) > postMaxContent {
+			return nil, ErrValidation.WithMessage(fmt.Sprintf("content must be at most %d characters", postMaxContent))
+		}
+	}
+	scheduledAt := post.ScheduledAt
+	if input.ScheduledAt != nil {
+		if err := validatePostSchedule(*input.ScheduledAt, time.Now()); err != nil {
+			return nil, err
+		}
+		scheduledAt = pgtype.Timestamptz{Time: *input.ScheduledAt, Valid: true}
+	}
+
+	updated, err := s.repo.UpdateScheduledPost(ctx, repository.UpdateScheduledPostParams{
+		Content:     content,
+		ScheduledAt: scheduledAt,
+		ID:          post.ID,
+		CommunityID: post.AuthorCommunityID,
+	})
+	if err == pgx.ErrNoRows {
+		// Published by the scheduler in the meantime
+		return nil, ErrPostNotFound.WithMessage("Scheduled post not found or already published")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update scheduled post: %w", err)
+	}
+	return scheduledPostResponse(updated), nil
+}
+
+// CancelScheduled deletes a community post that has not been published yet
+func (s *PostService) CancelScheduled(ctx context.Context, communityID, postID uuid.UUID) error {
+	photos, err := s.repo.CancelScheduledPost(ctx, repository.CancelScheduledPostParams{
+		ID:          uuidToPgtype(postID),
+		CommunityID: uuidToPgtype(communityID),
+	})
+	if err == pgx.ErrNoRows {
+		return ErrPostNotFound.WithMessage("Scheduled post not found or already published")
+	}
+	if err != nil {
+		return fmt.Errorf("cancel scheduled post: %w", err)
+	}
+
+	s.deletePhotos(ctx, postPhotos(photos))
+	return nil
+}
+
+// requireCommunityAdmin checks the community is active and the user is one of its owners or admins
+func (s *PostService) requireCommunityAdmin(ctx context.Context, communityID, userID uuid.UUID) error {
+	if err := s.requireActiveCommunity(ctx, communityID); err != nil {
+		return err
+	}
+
+	member, err := s.repo.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: uuidToPgtype(communityID),
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows || (err == nil && member.Status.MemberStatus != repository.MemberStatusActive) {
+		return ErrNotCommunityMember
+	}
+	if err != nil {
+		return fmt.Errorf("get community member: %w", err)
+	}
+	if communityRoleRank(member.Role.CommunityRole) < communityRoleRank(repository.CommunityRoleAdmin) {
+		return ErrInsufficientRole.WithMessage("Only community owners and admins can post on behalf of the community")
+	}
+	return nil
+}
+
+// requireActiveCommunity checks the community exists and is not deactivated
+func (s *PostService) requireActiveCommunity(ctx context.Context, communityID uuid.UUID) error {
+	community, err := s.repo.GetCommunityActiveState(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return ErrCommunityNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get community: %w", err)
+	}
+	if !community.IsActive.Bool {
+		return ErrForbidden.WithMessage("Community is deactivated")
+	}
+	return nil
+}
+
+// uploadPhotos stores the photos and returns their URLs. Photos already
+// uploaded are removed again when a later one fails.
+func (s *PostService) uploadPhotos(ctx context.Context, userID uuid.UUID, photos [][]byte, contentTypes []string) ([]string, error) {
+	urls := make([]string, 0, len(photos))
+	if len(photos) == 0 {
+		return urls, nil
+	}
+	if s.storage == nil {
+		return nil, fmt.Errorf("upload post photos: storage service not configured")
+	}
+
+	for i, photo := range photos {
+		key := GeneratePostPhotoKey(userID, contentTypes[i])
+		url, err := s.storage.Upload(ctx, "", key, bytes.NewReader(photo), contentTypes[i])
+		if err != nil {
+			s.deletePhotos(ctx, urls)
+			return nil, fmt.Errorf("upload post photo: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	return urls, nil
+}
+
+// deletePhotos removes post photos from storage, best effort
+func (s *PostService) deletePhotos(ctx context.Context, urls []string) {
+	if s.storage == nil {
+		return
+	}
+	for _, url := range urls {
+		if err := s.storage.Delete(ctx, ExtractKeyFromURL(url, s.storage.publicURL)); err != nil {
+			slog.Warn("failed to delete post photo", "url", url, "error", err)
+		}
+	}
+}
+
+// encodeFeedCursor builds the opaque cursor pointing after the given post
+func encodeFeedCursor(createdAt time.Time, postID uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "_" + postID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (time.Time, uuid.UUID, error) {
+	invalid := ErrValidation.WithMessage("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	return t, postID, nil
+}
+
+// validatePostSchedule checks a publish time is in the future and not too far ahead
+func validatePostSchedule(at, now time.Time) error {
+	if !at.After(now) {
+		return ErrValidation.WithMessage("scheduled_at must be in the future")
+	}
+	if at.After(now.Add(postMaxScheduleAhead)) {
+		return ErrValidation.WithMessage(fmt.Sprintf("posts can be scheduled at most %d days ahead", int(postMaxScheduleAhead.Hours()/24)))
+	}
+	return nil
+}
+
+// postPhotos decodes the photos column; malformed values yield no photos
+func postPhotos(raw []byte) []string {
+	photos := []string{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &photos)
+	}
+	return photos
+}
+
+func postResponse(p repository.ListFeedRow) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":              pgtypeUUIDToStringRequired(p.ID),
+		"author_type":     string(p.AuthorType),
+		"content":         p.Content,
+		"photos":          postPhotos(p.Photos),
+		"like_count":      p.LikeCount.Int32,
+		"comment_count":   p.CommentCount.Int32,
+		"liked_by_me":     p.LikedByMe,
+		"is_match_result": p.IsMatchResult.Bool,
+		"match_id":        pgtypeUUIDToString(p.MatchID),
+		"created_at":      p.CreatedAt.Time,
+	}
+	if p.AuthorType == repository.PostAuthorTypeCommunity {
+		result["community"] = map[string]interface{}{
+			"id":       pgtypeUUIDToStringRequired(p.AuthorCommunityID),
+			"name":     p.CommunityName.String,
+			"logo_url": p.CommunityLogoUrl.String,
+		}
+	} else {
+		result["author"] = map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(p.AuthorUserID),
+			"first_name": p.AuthorFirstName.String,
+			"last_name":  p.AuthorLastName.String,
+			"avatar_url": p.AuthorAvatarUrl.String,
+		}
+	}
+	return result
+}
+
+func scheduledPostResponse(p repository.Post) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(p.ID),
+		"community_id": pgtypeUUIDToStringRequired(p.AuthorCommunityID),
+		"author_id":    pgtypeUUIDToString(p.AuthorUserID),
+		"content":      p.Content,
+		"photos":       postPhotos(p.Photos),
+		"scheduled_at": p.ScheduledAt.Time,
+		"created_at":   p.CreatedAt.Time,
+		"updated_at":   p.UpdatedAt.Time,
+	}
+}
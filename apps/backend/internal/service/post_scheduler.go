@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// postPublishBatchSize limits how many scheduled posts are published per tick
+const postPublishBatchSize = 100
+
+// PostScheduler publishes scheduled community posts once they are due. Due
+// posts are claimed with FOR UPDATE SKIP LOCKED and flipped to published in
+// the same statement, so with several instances running each post is
+// published, and its followers notified, exactly once. Followers are the
+// community's active members who have community_news enabled; like
+// announcements to all members, they get an in-app community_news
+// notification and a push through the community news topic. Posts of a
+// deactivated community are held and go out if the community is reactivated.
+type PostScheduler struct {
+	repo     *repository.Queries
+	pool     txStarter
+	firebase *FirebaseService
+	interval time.Duration
+}
+
+// NewPostScheduler creates a new PostScheduler
+func NewPostScheduler(repo *repository.Queries, pool txStarter, firebase *FirebaseService, interval time.Duration) *PostScheduler {
+	return &PostScheduler{
+		repo:     repo,
+		pool:     pool,
+		firebase: firebase,
+		interval: interval,
+	}
+}
+
+// Run publishes due posts every interval until ctx is cancelled.
+func (s *PostScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Warn("post scheduler tick failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick publishes the posts that were due at now and pushes them to the
+// community topics once the batch is committed.
+func (s *PostScheduler) Tick(ctx context.Context, now time.Time) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	published, err := qtx.PublishDuePosts(ctx, repository.PublishDuePostsParams{
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		BatchLimit: postPublishBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("publish due posts: %w", err)
+	}
+	if len(published) == 0 {
+		return nil
+	}
+
+	for _, p := range published {
+		dataJSON, _ := json.Marshal(publishedPostData(p))
+		if err := qtx.CreateCommunityPostNotifications(ctx, repository.CreateCommunityPostNotificationsParams{
+			Title:       p.CommunityName,
+			Body:        publishedPostBody(p.Content),
+			Data:        dataJSON,
+			CommunityID: p.AuthorCommunityID,
+			AuthorID:    p.AuthorUserID,
+		}); err != nil {
+			return fmt.Errorf("create post notifications: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	for _, p := range published {
+		topic := CommunityNewsTopic(uuid.UUID(p.AuthorCommunityID.Bytes))
+		if err := s.firebase.SendToTopic(ctx, topic, p.CommunityName, publishedPostBody(p.Content), publishedPostData(p)); err != nil {
+			slog.Warn("failed to push published post", "post_id", pgtypeUUIDToStringRequired(p.ID), "topic", topic, "error", err)
+		}
+	}
+	return nil
+}
+
+// publishedPostData is the payload of the notification and the push
+func publishedPostData(p repository.PublishDuePostsRow) map[string]any {
+	return map[string]any{
+		"post_id":        pgtypeUUIDToStringRequired(p.ID),
+		"community_id":   pgtypeUUIDToStringRequired(p.AuthorCommunityID),
+		"community_name": p.CommunityName,
+	}
+}
+
+// publishedPostBody quotes the beginning of the post, or announces a photo post
+func publishedPostBody(content string) string {
+	if content == "" {
+		return "Новая публикация с фото"
+	}
+	return notificationExcerpt(content)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestFeedCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 5, 3, 18, 30, 15, 123456000, time.FixedZone("ALMT", 5*3600))
+	id := uuid.New()
+
+	gotTime, gotID, err := decodeFeedCursor(encodeFeedCursor(createdAt, id))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !gotTime.Equal(createdAt) {
+		t.Errorf("time = %v, want %v", gotTime, createdAt)
+	}
+	if gotID != id {
+		t.Errorf("id = %v, want %v", gotID, id)
+	}
+}
+
+func TestDecodeFeedCursorInvalid(t *testing.T) {
+	for _, cursor := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("noseparator")),
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday_" + uuid.NewString())),
+		encodeFeedCursor(time.Now(), uuid.Nil)[:20],
+	} {
+		var appErr *AppError
+		if _, _, err := decodeFeedCursor(cursor); !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+			t.Errorf("decodeFeedCursor(%q) error = %v, want validation error", cursor, err)
+		}
+	}
+}
+
+func TestCreatePostInputValidate(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		name    string
+		input   CreatePostInput
+		wantErr bool
+	}{
+		{"text only", CreatePostInput{Content: "  Отличная игра!  "}, false},
+		{"photo only", CreatePostInput{Photos: [][]byte{png}}, false},
+		{"empty", CreatePostInput{Content: "   "}, true},
+		{"too long", CreatePostInput{Content: strings.Repeat("a", postMaxContent+1)}, true},
+		{"too many photos", CreatePostInput{Photos: make([][]byte, postMaxPhotos+1)}, true},
+		{"not an image", CreatePostInput{Photos: [][]byte{[]byte("hello")}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.input.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	in := CreatePostInput{Content: "  hi  ", Photos: [][]byte{png}}
+	types, err := in.validate()
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if in.Content != "hi" {
+		t.Errorf("content = %q, want trimmed", in.Content)
+	}
+	if !reflect.DeepEqual(types, []string{"image/png"}) {
+		t.Errorf("content types = %v", types)
+	}
+}
+
+func TestPostPhotos(t *testing.T) {
+	if got := postPhotos([]byte(`["a.jpg","b.png"]`)); !reflect.DeepEqual(got, []string{"a.jpg", "b.png"}) {
+		t.Errorf("postPhotos = %v", got)
+	}
+	for _, raw := range [][]byte{nil, []byte(`{}`)} {
+		if got := postPhotos(raw); got == nil || len(got) != 0 {
+			t.Errorf("postPhotos(%q) = %v, want empty", raw, got)
+		}
+	}
+}
+
+func TestValidatePostSchedule(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantErr bool
+	}{
+		{"in an hour", now.Add(time.Hour), false},
+		{"at the limit", now.Add(postMaxScheduleAhead), false},
+		{"now", now, true},
+		{"in the past", now.Add(-time.Minute), true},
+		{"too far ahead", now.Add(postMaxScheduleAhead + time.Minute), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePostSchedule(tt.at, now); (err != nil) != tt.wantErr {
+				t.Fatalf("validatePostSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreatePostInputValidateSchedule(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+	communityID := uuid.New()
+
+	user := CreatePostInput{Content: "Итоги недели", ScheduledAt: &tomorrow}
+	if _, err := user.validate(); err == nil {
+		t.Error("scheduling a user post should fail")
+	}
+
+	community := CreatePostInput{Content: "Итоги недели", CommunityID: &communityID, ScheduledAt: &tomorrow}
+	if _, err := community.validate(); err != nil {
+		t.Errorf("scheduling a community post: %v", err)
+	}
+}
+
+func TestPublishedPostBody(t *testing.T) {
+	if got := publishedPostBody(""); got != "Новая публикация с фото" {
+		t.Errorf("photo-only body = %q", got)
+	}
+	if got := publishedPostBody("Турнир в субботу"); got != "Турнир в субботу" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestUpdateScheduledDeactivatedCommunity(t *testing.T) {
+	db := newFakeDB()
+	communityID := uuid.New()
+	db.rows("GetCommunityActiveState", []any{repository.GetCommunityActiveStateRow{
+		ID:       uuidToPgtype(communityID),
+		IsActive: pgtype.Bool{Bool: false, Valid: true},
+	}})
+
+	content := "Турнир переносится на воскресенье"
+	service := NewPostService(db.queries(), nil, nil)
+	_, err := service.UpdateScheduled(context.Background(), communityID, uuid.New(), UpdateScheduledPostInput{Content: &content})
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr.Code != ErrForbidden.Code {
+		t.Errorf("error = %v, want forbidden", err)
+	}
+	if calls := db.called("UpdateScheduledPost"); len(calls) != 0 {
+		t.Errorf("post was updated: %+v", calls)
+	}
+}
+
+func TestPublishDuePostsHoldsDeactivatedCommunities(t *testing.T) {
+	db := newFakeDB()
+	db.rows("PublishDuePosts")
+
+	scheduler := &PostScheduler{repo: db.queries(), pool: db}
+	if err := scheduler.Tick(context.Background(), time.Now()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	sql := db.called("PublishDuePosts")[0].SQL
+	for _, condition := range []string{
+		"AND c.is_active = TRUE",
+		"AND author_community_id IN (SELECT id FROM communities WHERE is_active = TRUE)",
+	} {
+		if !strings.Contains(sql, condition) {
+			t.Errorf("publish query lacks %q", condition)
+		}
+	}
+}
+
+func TestPublishDuePostsNotifiesOnlyOptedInMembers(t *testing.T) {
+	db := newFakeDB()
+	authorID := uuid.New()
+	db.rows("PublishDuePosts", []any{repository.PublishDuePostsRow{
+		ID:                uuidToPgtype(uuid.New()),
+		AuthorCommunityID: uuidToPgtype(uuid.New()),
+		AuthorUserID:      uuidToPgtype(authorID),
+		Content:           "Турнир переносится на воскресенье",
+		CommunityName:     "Теннисный клуб Алматы",
+	}})
+	db.rows("CreateCommunityPostNotifications", []any{})
+
+	scheduler := &PostScheduler{repo: db.queries(), pool: db}
+	if err := scheduler.Tick(context.Background(), time.Now()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+	if !db.committed() {
+		t.Fatal("tick did not commit")
+	}
+
+	calls := db.called("CreateCommunityPostNotifications")
+	if len(calls) != 1 {
+		t.Fatalf("created notifications %d times, want once", len(calls))
+	}
+	if calls[0].Args[4] != uuidToPgtype(authorID) {
+		t.Errorf("notifications excluded %v, want the author", calls[0].Args[4])
+	}
+	if !strings.Contains(calls[0].SQL, "AND COALESCE((u.notification_settings ->> 'community_news')::boolean, TRUE)") {
+		t.Error("notifications do not skip members who turned community_news off")
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reminderBatchSize limits how many reminders are sent per window per tick.
+const reminderBatchSize = 500
+
+// reminderWindow describes one reminder sent before a game starts.
+// A game falls into the window when it starts within (Until, Before] from now.
+type reminderWindow struct {
+	Type   repository.NotificationType
+	Before time.Duration
+	Until  time.Duration
+}
+
+var reminderWindows = []reminderWindow{
+	{Type: repository.NotificationTypeGameReminder24h, Before: 24 * time.Hour, Until: time.Hour},
+	{Type: repository.NotificationTypeGameReminder1h, Before: time.Hour, Until: 0},
+}
+
+// startsIn describes how long until a game starts, e.g. "через 3 часа".
+// Under an hour it counts minutes, otherwise whole hours.
+func startsIn(d time.Duration) string {
+	if minutes := int(d.Round(time.Minute) / time.Minute); minutes < 60 {
+		minutes = max(minutes, 1)
+		return fmt.Sprintf("через %d %s", minutes, pluralRu(minutes, "минуту", "минуты", "минут"))
+	}
+	hours := int(d.Round(time.Hour) / time.Hour)
+	return fmt.Sprintf("через %d %s", hours, pluralRu(hours, "час", "часа", "часов"))
+}
+
+// pluralRu picks the Russian form of a noun for n: one, few or many
+func pluralRu(n int, one, few, many string) string {
+	switch n10, n100 := n%10, n%100; {
+	case n10 == 1 && n100 != 11:
+		return one
+	case n10 >= 2 && n10 <= 4 && (n100 < 12 || n100 > 14):
+		return few
+	default:
+		return many
+	}
+}
+
+// ReminderScheduler sends game reminders for upcoming events and scheduled matches.
+// Every reminder is claimed in sent_reminders before it is delivered, so each
+// participant gets at most one reminder per window even with several instances.
+// A claim whose notification could not be created is released for the next tick.
+type ReminderScheduler struct {
+	repo          *repository.Queries
+	notifications *NotificationService
+	interval      time.Duration
+	location      *time.Location
+}
+
+// NewReminderScheduler creates a new ReminderScheduler
+func NewReminderScheduler(repo *repository.Queries, notifications *NotificationService, interval time.Duration) *ReminderScheduler {
+	return &ReminderScheduler{
+		repo:          repo,
+		notifications: notifications,
+		interval:      interval,
+		location:      almatyLocation(),
+	}
+}
+
+// Run sends due reminders every interval until ctx is cancelled.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Warn("game reminder tick failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick sends all reminders that are due at the given time.
+func (s *ReminderScheduler) Tick(ctx context.Context, now time.Time) error {
+	for _, w := range reminderWindows {
+		if err := s.sendEventReminders(ctx, w, now); err != nil {
+			return err
+		}
+		if err := s.sendMatchReminders(ctx, w, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ReminderScheduler) sendEventReminders(ctx context.Context, w reminderWindow, now time.Time) error {
+	rows, err := s.repo.ListDueEventReminders(ctx, repository.ListDueEventRemindersParams{
+		WindowStart:  pgtype.Timestamptz{Time: now.Add(w.Until), Valid: true},
+		WindowEnd:    pgtype.Timestamptz{Time: now.Add(w.Before), Valid: true},
+		ReminderType: string(w.Type),
+		BatchLimit:   reminderBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list event reminders: %w", err)
+	}
+
+	for _, r := range rows {
+		body := fmt.Sprintf("«%s» начнётся %s, в %s", r.Title, startsIn(r.StartTime.Time.Sub(now)), r.StartTime.Time.In(s.location).Format("15:04"))
+		if r.LocationName.Valid && r.LocationName.String != "" {
+			body += ", " + r.LocationName.String
+		}
+
+		s.send(ctx, r.UserID, "event", r.EventID, w.Type, body, map[string]any{
+			"event_id": pgtypeUUIDToStringRequired(r.EventID),
+		})
+	}
+
+	return nil
+}
+
+func (s *ReminderScheduler) sendMatchReminders(ctx context.Context, w reminderWindow, now time.Time) error {
+	rows, err := s.repo.ListDueMatchReminders(ctx, repository.ListDueMatchRemindersParams{
+		WindowStart:  pgtype.Timestamptz{Time: now.Add(w.Until), Valid: true},
+		WindowEnd:    pgtype.Timestamptz{Time: now.Add(w.Before), Valid: true},
+		ReminderType: string(w.Type),
+		BatchLimit:   reminderBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list match reminders: %w", err)
+	}
+
+	for _, r := range rows {
+		body := fmt.Sprintf("Ваш матч начнётся %s, в %s", startsIn(r.ScheduledTime.Time.Sub(now)), r.ScheduledTime.Time.In(s.location).Format("15:04"))
+		if r.CourtNumber.Valid {
+			body += fmt.Sprintf(", корт №%d", r.CourtNumber.Int32)
+		}
+
+		s.send(ctx, r.UserID, "match", r.MatchID, w.Type, body, map[string]any{
+			"match_id": pgtypeUUIDToStringRequired(r.MatchID),
+		})
+	}
+
+	return nil
+}
+
+// send claims the reminder and delivers it only if this call won the claim.
+// The claim is released when the notification cannot be created, so the
+// reminder is retried instead of being lost.
+func (s *ReminderScheduler) send(
+	ctx context.Context,
+	userID pgtype.UUID,
+	targetType string,
+	targetID pgtype.UUID,
+	reminderType repository.NotificationType,
+	body string,
+	data map[string]any,
+) {
+	claimed, err := s.repo.ClaimReminder(ctx, repository.ClaimReminderParams{
+		UserID:       userID,
+		TargetType:   targetType,
+		TargetID:     targetID,
+		ReminderType: string(reminderType),
+	})
+	if err != nil {
+		slog.Warn("failed to claim reminder", "target_id", pgtypeUUIDToStringRequired(targetID), "error", err)
+		return
+	}
+	if claimed == 0 {
+		return
+	}
+
+	uid, err := uuid.FromBytes(userID.Bytes[:])
+	if err != nil {
+		return
+	}
+
+	if _, err := s.notifications.Create(ctx, uid, string(reminderType), "Напоминание об игре", body, data); err != nil {
+		slog.Warn("failed to create game reminder notification", "user_id", uid, "type", reminderType, "error", err)
+
+		if err := s.repo.ReleaseReminder(ctx, repository.ReleaseReminderParams{
+			UserID:       userID,
+			TargetID:     targetID,
+			ReminderType: string(reminderType),
+		}); err != nil {
+			slog.Warn("failed to release reminder", "target_id", pgtypeUUIDToStringRequired(targetID), "error", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestStartsIn(t *testing.T) {
+	tests := []struct {
+		until time.Duration
+		want  string
+	}{
+		{24 * time.Hour, "через 24 часа"},
+		{23*time.Hour + 50*time.Minute, "через 24 часа"},
+		{5 * time.Hour, "через 5 часов"},
+		{2 * time.Hour, "через 2 часа"},
+		{time.Hour, "через 1 час"},
+		{21 * time.Minute, "через 21 минуту"},
+		{3 * time.Minute, "через 3 минуты"},
+		{45 * time.Minute, "через 45 минут"},
+		{10 * time.Second, "через 1 минуту"},
+	}
+	for _, tt := range tests {
+		if got := startsIn(tt.until); got != tt.want {
+			t.Errorf("startsIn(%s) = %q, want %q", tt.until, got, tt.want)
+		}
+	}
+}
+
+// fakeEventReminder serves one event starting at start in the 24h window
+func fakeEventReminder(db *fakeDB, start time.Time) {
+	db.on("ListDueEventReminders", func(args []any) ([][]any, error) {
+		if args[2].(string) != string(repository.NotificationTypeGameReminder24h) {
+			return nil, nil
+		}
+		return [][]any{{repository.ListDueEventRemindersRow{
+			EventID:   uuidToPgtype(uuid.New()),
+			Title:     "Турнир выходного дня",
+			StartTime: pgtype.Timestamptz{Time: start, Valid: true},
+			UserID:    uuidToPgtype(uuid.New()),
+		}}}, nil
+	})
+	db.rows("ListDueMatchReminders")
+	db.rows("ClaimReminder", []any{})
+	db.rows("ReleaseReminder", []any{})
+}
+
+func TestReminderLabelUsesTimeUntilStart(t *testing.T) {
+	db := newFakeDB()
+	now := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	fakeEventReminder(db, now.Add(3*time.Hour))
+	db.on("CreateNotification", func(args []any) ([][]any, error) {
+		return [][]any{{repository.Notification{ID: uuidToPgtype(uuid.New()), Body: args[3].(string)}}}, nil
+	})
+
+	notifications := NewNotificationService(db.queries(), slog.Default(), nil)
+	scheduler := NewReminderScheduler(db.queries(), notifications, time.Minute)
+	if err := scheduler.Tick(context.Background(), now); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	created := db.called("CreateNotification")
+	if len(created) != 1 {
+		t.Fatalf("notifications = %d, want 1", len(created))
+	}
+	if body := created[0].Args[3].(string); !strings.Contains(body, "через 3 часа") {
+		t.Errorf("body = %q, want the real time until the start", body)
+	}
+	if released := db.called("ReleaseReminder"); len(released) != 0 {
+		t.Errorf("released = %+v, want none", released)
+	}
+}
+
+func TestReminderReleasedWhenNotificationFails(t *testing.T) {
+	db := newFakeDB()
+	now := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	fakeEventReminder(db, now.Add(20*time.Hour))
+	db.on("CreateNotification", func([]any) ([][]any, error) {
+		return nil, errors.New("connection reset")
+	})
+
+	notifications := NewNotificationService(db.queries(), slog.Default(), nil)
+	scheduler := NewReminderScheduler(db.queries(), notifications, time.Minute)
+	if err := scheduler.Tick(context.Background(), now); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	claimed, released := db.called("ClaimReminder"), db.called("ReleaseReminder")
+	if len(claimed) != 1 || len(released) != 1 {
+		t.Fatalf("claimed = %d, released = %d, want the claim released", len(claimed), len(released))
+	}
+	if claimed[0].Args[0] != released[0].Args[0] || claimed[0].Args[2] != released[0].Args[1] || claimed[0].Args[3] != released[0].Args[2] {
+		t.Errorf("released %+v, claimed %+v", released[0].Args, claimed[0].Args)
+	}
+}
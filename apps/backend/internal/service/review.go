@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxReviewLength limits the text part of a court review (in characters)
+const maxReviewLength = 2000
+
+// ReviewService handles court reviews and their aggregated ratings
+type ReviewService struct {
+	repo *repository.Queries
+}
+
+// NewReviewService creates a new ReviewService
+func NewReviewService(repo *repository.Queries) *ReviewService {
+	return &ReviewService{repo: repo}
+}
+
+// ReviewInput represents a player's review of a court.
+// Each score is 1-5; criteria the player can't judge may be omitted.
+type ReviewInput struct {
+	SurfaceScore       *int16 `json:"surface_score"`
+	LightingScore      *int16 `json:"lighting_score"`
+	ChangingRoomsScore *int16 `json:"changing_rooms_score"`
+	ValueScore         *int16 `json:"value_score"`
+	Body               string `json:"body"`
+}
+
+// Upsert creates or replaces the user's review of a court.
+// Only players who took part in a past event or a confirmed match at the court may review it.
+func (s *ReviewService) Upsert(ctx context.Context, userID, courtID uuid.UUID, input ReviewInput) (map[string]interface{}, error) {
+	if err := validateReviewInput(input); err != nil {
+		return nil, err
+	}
+
+	court, err := s.repo.GetCourtByID(ctx, uuidToPgtype(courtID))
+	if err == pgx.ErrNoRows || (err == nil && court.Status.CourtStatus != repository.CourtStatusApproved) {
+		return nil, ErrCourtNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get court: %w", err)
+	}
+
+	played, err := s.repo.HasPlayedAtCourt(ctx, repository.HasPlayedAtCourtParams{
+		CourtID: court.ID,
+		UserID:  uuidToPgtype(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("check played at court: %w", err)
+	}
+	if !played {
+		return nil, ErrNotPlayedAtCourt.WithMessage("Only players who played at this court can review it")
+	}
+
+	review, err := s.repo.UpsertCourtReview(ctx, repository.UpsertCourtReviewParams{
+		CourtID:            court.ID,
+		UserID:             uuidToPgtype(userID),
+		SurfaceScore:       scoreToPgtype(input.SurfaceScore),
+		LightingScore:      scoreToPgtype(input.LightingScore),
+		ChangingRoomsScore: scoreToPgtype(input.ChangingRoomsScore),
+		ValueScore:         scoreToPgtype(input.ValueScore),
+		Body:               pgtype.Text{String: input.Body, Valid: input.Body != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert court review: %w", err)
+	}
+
+	if err := s.refreshRating(ctx, court.ID); err != nil {
+		return nil, err
+	}
+
+	return buildReviewResponse(review), nil
+}
+
+// List returns published reviews of a court, newest first
+func (s *ReviewService) List(ctx context.Context, courtID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+
+	offset := (page - 1) * perPage
+
+	reviews, err := s.repo.ListCourtReviews(ctx, repository.ListCourtReviewsParams{
+		CourtID:      uuidToPgtype(courtID),
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list court reviews: %w", err)
+	}
+
+	total, err := s.repo.CountCourtReviews(ctx, uuidToPgtype(courtID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count court reviews: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(reviews))
+	for _, r := range reviews {
+		result = append(result, map[string]interface{}{
+			"id":       pgtypeUUIDToStringRequired(r.ID),
+			"court_id": pgtypeUUIDToStringRequired(r.CourtID),
+			"scores":   buildReviewScores(r.SurfaceScore, r.LightingScore, r.ChangingRoomsScore, r.ValueScore),
+			"body":     r.Body.String,
+			"author": map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(r.UserID),
+				"first_name": r.FirstName.String,
+				"last_name":  r.LastName.String,
+				"avatar_url": r.AvatarUrl.String,
+			},
+			"created_at": r.CreatedAt.Time,
+			"updated_at": r.UpdatedAt.Time,
+		})
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Delete removes the user's own review
+func (s *ReviewService) Delete(ctx context.Context, userID, reviewID uuid.UUID) error {
+	review, err := s.repo.GetCourtReviewByID(ctx, uuidToPgtype(reviewID))
+	if err == pgx.ErrNoRows {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get court review: %w", err)
+	}
+
+	deleted, err := s.repo.DeleteCourtReview(ctx, repository.DeleteCourtReviewParams{
+		ID:     review.ID,
+		UserID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete court review: %w", err)
+	}
+	if deleted == 0 {
+		return ErrForbidden.WithMessage("You can only delete your own review")
+	}
+
+	return s.refreshRating(ctx, review.CourtID)
+}
+
+// ListForModeration returns reviews with the given status (published by default) for superadmins
+func (s *ReviewService) ListForModeration(ctx context.Context, status string, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if status == "" {
+		status = string(repository.ReviewStatusPublished)
+	}
+
+	reviewStatus := repository.ReviewStatus(status)
+	switch reviewStatus {
+	case repository.ReviewStatusPublished, repository.ReviewStatusHidden:
+	default:
+		return nil, nil, ErrValidation.WithMessage("Invalid status")
+	}
+
+	offset := (page - 1) * perPage
+
+	reviews, err := s.repo.ListCourtReviewsByStatus(ctx, repository.ListCourtReviewsByStatusParams{
+		Status:       reviewStatus,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list court reviews by status: %w", err)
+	}
+
+	total, err := s.repo.CountCourtReviewsByStatus(ctx, reviewStatus)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count court reviews by status: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(reviews))
+	for _, r := range reviews {
+		review := map[string]interface{}{
+			"id":         pgtypeUUIDToStringRequired(r.ID),
+			"court_id":   pgtypeUUIDToStringRequired(r.CourtID),
+			"court_name": r.CourtName,
+			"scores":     buildReviewScores(r.SurfaceScore, r.LightingScore, r.ChangingRoomsScore, r.ValueScore),
+			"body":       r.Body.String,
+			"status":     string(r.Status),
+			"author": map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(r.UserID),
+				"first_name": r.FirstName.String,
+				"last_name":  r.LastName.String,
+			},
+			"created_at": r.CreatedAt.Time,
+			"updated_at": r.UpdatedAt.Time,
+		}
+		if r.ModerationNote.Valid {
+			review["moderation_note"] = r.ModerationNote.String
+		}
+		if r.ModeratedAt.Valid {
+			review["moderated_at"] = r.ModeratedAt.Time
+		}
+		result = append(result, review)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Moderate hides a review from the court page (or restores it) and updates the court rating
+func (s *ReviewService) Moderate(ctx context.Context, adminID, reviewID uuid.UUID, action, note string) (map[string]interface{}, error) {
+	var status repository.ReviewStatus
+	switch action {
+	case "hide":
+		status = repository.ReviewStatusHidden
+		if note == "" {
+			return nil, ErrValidation.WithMessage("A note is required when hiding a review")
+		}
+	case "restore":
+		status = repository.ReviewStatusPublished
+	default:
+		return nil, ErrValidation.WithMessage("Action must be 'hide' or 'restore'")
+	}
+
+	review, err := s.repo.ModerateCourtReview(ctx, repository.ModerateCourtReviewParams{
+		Status:         status,
+		ModerationNote: pgtype.Text{String: note, Valid: note != ""},
+		ModeratedBy:    uuidToPgtype(adminID),
+		ID:             uuidToPgtype(reviewID),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("moderate court review: %w", err)
+	}
+
+	if err := s.refreshRating(ctx, review.CourtID); err != nil {
+		return nil, err
+	}
+
+	return buildReviewResponse(review), nil
+}
+
+// refreshRating recomputes the court's aggregated scores after a review changes
+func (s *ReviewService) refreshRating(ctx context.Context, courtID pgtype.UUID) error {
+	if err := s.repo.RefreshCourtRating(ctx, courtID); err != nil {
+		return fmt.Errorf("refresh court rating: %w", err)
+	}
+	return nil
+}
+
+func validateReviewInput(input ReviewInput) error {
+	scores := []*int16{input.SurfaceScore, input.LightingScore, input.ChangingRoomsScore, input.ValueScore}
+	rated := false
+	for _, score := range scores {
+		if score == nil {
+			continue
+		}
+		if *score < 1 || *score > 5 {
+			return ErrValidation.WithMessage("Scores must be between 1 and 5")
+		}
+		rated = true
+	}
+	if !rated {
+		return ErrValidation.WithMessage("At least one score is required")
+	}
+	if utf8.RuneCountInString(input.Body) > maxReviewLength {
+		return ErrValidation.WithMessage(fmt.Sprintf("Review text must be at most %d characters", maxReviewLength))
+	}
+	return nil
+}
+
+func scoreToPgtype(score *int16) pgtype.Int2 {
+	if score == nil {
+		return pgtype.Int2{}
+	}
+	return pgtype.Int2{Int16: *score, Valid: true}
+}
+
+func buildReviewScores(surface, lighting, changingRooms, value pgtype.Int2) map[string]interface{} {
+	scores := map[string]interface{}{}
+	if surface.Valid {
+		scores["surface_quality"] = surface.Int16
+	}
+	if lighting.Valid {
+		scores["lighting"] = lighting.Int16
+	}
+	if changingRooms.Valid {
+		scores["changing_rooms"] = changingRooms.Int16
+	}
+	if value.Valid {
+		scores["price_value"] = value.Int16
+	}
+	return scores
+}
+
+func buildReviewResponse(r repository.CourtReview) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":         pgtypeUUIDToStringRequired(r.ID),
+		"court_id":   pgtypeUUIDToStringRequired(r.CourtID),
+		"user_id":    pgtypeUUIDToStringRequired(r.UserID),
+		"scores":     buildReviewScores(r.SurfaceScore, r.LightingScore, r.ChangingRoomsScore, r.ValueScore),
+		"body":       r.Body.String,
+		"status":     string(r.Status),
+		"created_at": r.CreatedAt.Time,
+		"updated_at": r.UpdatedAt.Time,
+	}
+	if r.ModerationNote.Valid {
+		result["moderation_note"] = r.ModerationNote.String
+	}
+	if r.ModeratedAt.Valid {
+		result["moderated_at"] = r.ModeratedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValidateReviewInput(t *testing.T) {
+	score := func(v int16) *int16 { return &v }
+
+	tests := []struct {
+		name    string
+		input   ReviewInput
+		wantErr bool
+	}{
+		{"single score", ReviewInput{SurfaceScore: score(4)}, false},
+		{"all scores with text", ReviewInput{
+			SurfaceScore: score(5), LightingScore: score(3), ChangingRoomsScore: score(1), ValueScore: score(2),
+			Body: "Good clay, dim lights in the evening",
+		}, false},
+		{"no scores", ReviewInput{Body: "Nice place"}, true},
+		{"score too low", ReviewInput{LightingScore: score(0)}, true},
+		{"score too high", ReviewInput{ValueScore: score(6)}, true},
+		{"text too long", ReviewInput{SurfaceScore: score(4), Body: strings.Repeat("a", maxReviewLength+1)}, true},
+	}
+
+	for _, tt := range tests {
+		err := validateReviewInput(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: validateReviewInput() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUpsertReviewRequiresPlayedAtCourt(t *testing.T) {
+	score := int16(4)
+
+	for _, played := range []bool{false, true} {
+		db := newFakeDB()
+		court := repository.Court{
+			ID:     uuidToPgtype(uuid.New()),
+			Status: repository.NullCourtStatus{CourtStatus: repository.CourtStatusApproved, Valid: true},
+		}
+		db.rows("GetCourtByID", []any{court})
+		db.rows("HasPlayedAtCourt", []any{played})
+		db.on("UpsertCourtReview", func(args []any) ([][]any, error) {
+			return [][]any{{repository.CourtReview{
+				ID:           uuidToPgtype(uuid.New()),
+				CourtID:      args[0].(pgtype.UUID),
+				UserID:       args[1].(pgtype.UUID),
+				SurfaceScore: args[2].(pgtype.Int2),
+			}}}, nil
+		})
+		db.rows("RefreshCourtRating", []any{})
+
+		service := NewReviewService(db.queries())
+		courtID, _ := uuid.FromBytes(court.ID.Bytes[:])
+		_, err := service.Upsert(context.Background(), uuid.New(), courtID, ReviewInput{SurfaceScore: &score})
+
+		check := db.called("HasPlayedAtCourt")
+		if len(check) != 1 || !strings.Contains(check[0].SQL, "m.result_status IN ('confirmed', 'admin_confirmed')") {
+			t.Fatalf("played check = %+v, want one that only counts confirmed matches", check)
+		}
+
+		upserts := db.called("UpsertCourtReview")
+		if !played {
+			var appErr *AppError
+			if !errors.As(err, &appErr) || appErr.Code != ErrNotPlayedAtCourt.Code || len(upserts) != 0 {
+				t.Errorf("not played: error = %v, upserts = %d, want rejection", err, len(upserts))
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("played: %v", err)
+		}
+		if len(upserts) != 1 || len(db.called("RefreshCourtRating")) != 1 {
+			t.Errorf("played: upserts = %d, want the review saved and the rating refreshed", len(upserts))
+		}
+	}
+}
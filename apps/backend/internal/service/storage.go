@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -16,18 +17,22 @@ import (
 	"github.com/google/uuid"
 )
 
-// StorageService handles file operations with S3-compatible storage (MinIO/R2)
+// StorageService handles file operations with S3-compatible storage (MinIO/R2).
+// Files served to everyone go to the public bucket; personal data such as
+// identity documents and exports goes to the private bucket, which is only
+// reachable through short-lived presigned links.
 type StorageService struct {
-	client    *s3.Client
-	bucket    string
-	publicURL string
+	client        *s3.Client
+	bucket        string
+	privateBucket string
+	publicURL     string
 }
 
 // NewStorageService creates a new StorageService
-func NewStorageService(endpoint, accessKey, secretKey, bucket, publicURL string) (*StorageService, error) {
+func NewStorageService(endpoint, accessKey, secretKey, bucket, privateBucket, publicURL string) (*StorageService, error) {
 	if endpoint == "" {
 		slog.Warn("S3 endpoint not configured, storage service disabled")
-		return &StorageService{bucket: bucket, publicURL: publicURL}, nil
+		return &StorageService{bucket: bucket, privateBucket: privateBucket, publicURL: publicURL}, nil
 	}
 
 	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
@@ -51,9 +56,10 @@ func NewStorageService(endpoint, accessKey, secretKey, bucket, publicURL string)
 	})
 
 	return &StorageService{
-		client:    client,
-		bucket:    bucket,
-		publicURL: publicURL,
+		client:        client,
+		bucket:        bucket,
+		privateBucket: privateBucket,
+		publicURL:     publicURL,
 	}, nil
 }
 
@@ -99,24 +105,77 @@ func (s *StorageService) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-// EnsureBucket creates the bucket if it doesn't exist
-func (s *StorageService) EnsureBucket(ctx context.Context) error {
+// UploadPrivate uploads a file to the private bucket under key. The file has
+// no public URL; hand out PresignGet links instead.
+func (s *StorageService) UploadPrivate(ctx context.Context, key string, reader io.Reader, contentType string) error {
 	if s.client == nil {
-		return nil
+		return fmt.Errorf("storage service not configured")
 	}
 
-	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
-		Bucket: &s.bucket,
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &s.privateBucket,
+		Key:         &key,
+		Body:        reader,
+		ContentType: &contentType,
 	})
 	if err != nil {
-		// Bucket doesn't exist, create it
-		_, err = s.client.CreateBucket(ctx, &s3.CreateBucketInput{
-			Bucket: &s.bucket,
+		return fmt.Errorf("upload private file: %w", err)
+	}
+	return nil
+}
+
+// PresignGet returns a download link to a private file that expires after ttl
+func (s *StorageService) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if s.client == nil {
+		return "", fmt.Errorf("storage service not configured")
+	}
+
+	req, err := s3.NewPresignClient(s.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.privateBucket,
+		Key:    &key,
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("presign file: %w", err)
+	}
+	return req.URL, nil
+}
+
+// DeletePrivate removes a file from the private bucket
+func (s *StorageService) DeletePrivate(ctx context.Context, key string) error {
+	if s.client == nil {
+		return fmt.Errorf("storage service not configured")
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &s.privateBucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return fmt.Errorf("delete private file: %w", err)
+	}
+	return nil
+}
+
+// EnsureBucket creates the public and private buckets if they don't exist
+func (s *StorageService) EnsureBucket(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+
+	for _, bucket := range []string{s.bucket, s.privateBucket} {
+		_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket: &bucket,
 		})
 		if err != nil {
-			return fmt.Errorf("create bucket: %w", err)
+			// Bucket doesn't exist, create it
+			_, err = s.client.CreateBucket(ctx, &s3.CreateBucketInput{
+				Bucket: &bucket,
+			})
+			if err != nil {
+				return fmt.Errorf("create bucket: %w", err)
+			}
+			slog.Info("created S3 bucket", "bucket", bucket)
 		}
-		slog.Info("created S3 bucket", "bucket", s.bucket)
 	}
 	return nil
 }
@@ -143,6 +202,29 @@ func ValidateImage(data []byte) (string, error) {
 	return contentType, nil
 }
 
+const maxDocumentSize = 10 * 1024 * 1024 // 10 MB
+
+var allowedDocumentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// ValidateDocument validates an uploaded document (image or PDF) and returns its content type
+func ValidateDocument(data []byte) (string, error) {
+	if len(data) > maxDocumentSize {
+		return "", ErrFileTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := allowedDocumentTypes[contentType]; !ok {
+		return "", ErrInvalidFileType
+	}
+
+	return contentType, nil
+}
+
 // GenerateAvatarKey generates a unique key for user avatar
 func GenerateAvatarKey(userID uuid.UUID, contentType string) string {
 	ext := allowedImageTypes[contentType]
@@ -152,6 +234,29 @@ func GenerateAvatarKey(userID uuid.UUID, contentType string) string {
 	return fmt.Sprintf("avatars/%s/avatar%s", userID.String(), ext)
 }
 
+// GenerateCommunityImageKey generates the key for a community logo or banner
+func GenerateCommunityImageKey(communityID uuid.UUID, kind, contentType string) string {
+	ext := allowedImageTypes[contentType]
+	if ext == "" {
+		ext = ".jpg"
+	}
+	return fmt.Sprintf("communities/%s/%s%s", communityID.String(), kind, ext)
+}
+
+// GeneratePostPhotoKey generates a unique key for a photo attached to a post
+func GeneratePostPhotoKey(authorID uuid.UUID, contentType string) string {
+	ext := allowedImageTypes[contentType]
+	if ext == "" {
+		ext = ".jpg"
+	}
+	return fmt.Sprintf("posts/%s/%s%s", authorID.String(), uuid.New().String(), ext)
+}
+
+// GenerateVerificationDocumentKey generates a unique key for a community verification document
+func GenerateVerificationDocumentKey(communityID uuid.UUID, contentType string) string {
+	return fmt.Sprintf("communities/%s/verification/%s%s", communityID.String(), uuid.New().String(), allowedDocumentTypes[contentType])
+}
+
 // ExtractKeyFromURL extracts the S3 key from a full URL
 func ExtractKeyFromURL(url, publicURL string) string {
 	if publicURL != "" {
@@ -0,0 +1,976 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	teamMaxName   = 100
+	tieMaxRubbers = 9
+
+	// Standings points per tie
+	tieWinPoints  = 2
+	tieDrawPoints = 1
+)
+
+const (
+	TieAwaitingLineups = "awaiting_lineups"
+	TieInProgress      = "in_progress"
+	TieCompleted       = "completed"
+)
+
+// TeamService manages club teams and interclub ties. A tie owns numbered
+// singles and doubles rubbers; once both captains have submitted lineups each
+// rubber gets a match, which is played and confirmed like any other match.
+// Tie scores and league standings are derived from confirmed rubbers.
+type TeamService struct {
+	repo *repository.Queries
+	pool txStarter
+}
+
+// NewTeamService creates a new TeamService
+func NewTeamService(repo *repository.Queries, pool txStarter) *TeamService {
+	return &TeamService{
+		repo: repo,
+		pool: pool,
+	}
+}
+
+// CreateTeamInput is a new team of a community
+type CreateTeamInput struct {
+	Name      string     `json:"name"`
+	CaptainID *uuid.UUID `json:"captain_id"`
+}
+
+// UpdateTeamInput is a partial team update; nil fields are left unchanged
+type UpdateTeamInput struct {
+	Name      *string    `json:"name"`
+	CaptainID *uuid.UUID `json:"captain_id"`
+}
+
+// CreateTieInput is a fixture between two teams. Rubbers lists the
+// composition of each rubber in order: "singles" or "doubles".
+type CreateTieInput struct {
+	HomeTeamID    uuid.UUID  `json:"home_team_id"`
+	AwayTeamID    uuid.UUID  `json:"away_team_id"`
+	EventID       *uuid.UUID `json:"event_id"`
+	ScheduledTime *time.Time `json:"scheduled_time"`
+	Rubbers       []string   `json:"rubbers"`
+}
+
+// LineupEntry assigns players to one rubber. PartnerID is required for doubles.
+type LineupEntry struct {
+	Rubber    int        `json:"rubber"`
+	PlayerID  uuid.UUID  `json:"player_id"`
+	PartnerID *uuid.UUID `json:"partner_id"`
+}
+
+// TeamStanding is a team's row in a league table
+type TeamStanding struct {
+	Rank        int    `json:"rank"`
+	TeamID      string `json:"team_id"`
+	TeamName    string `json:"team_name"`
+	Played      int    `json:"played"`
+	Won         int    `json:"won"`
+	Drawn       int    `json:"drawn"`
+	Lost        int    `json:"lost"`
+	RubbersWon  int    `json:"rubbers_won"`
+	RubbersLost int    `json:"rubbers_lost"`
+	Points      int    `json:"points"`
+}
+
+// CreateTeam creates a team; the captain, if any, joins its roster
+func (s *TeamService) CreateTeam(ctx context.Context, communityID uuid.UUID, input CreateTeamInput) (map[string]interface{}, error) {
+	name, err := normalizeTeamName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	captain := pgtype.UUID{}
+	if input.CaptainID != nil {
+		if err := requireActiveMember(ctx, qtx, uuidToPgtype(communityID), *input.CaptainID); err != nil {
+			return nil, err
+		}
+		captain = uuidToPgtype(*input.CaptainID)
+	}
+
+	team, err := qtx.CreateTeam(ctx, repository.CreateTeamParams{
+		CommunityID: uuidToPgtype(communityID),
+		Name:        name,
+		CaptainID:   captain,
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists.WithMessage("The community already has a team with this name")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create team: %w", err)
+	}
+
+	if captain.Valid {
+		if _, err := qtx.AddTeamMember(ctx, repository.AddTeamMemberParams{TeamID: team.ID, UserID: captain}); err != nil {
+			return nil, fmt.Errorf("add captain: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return teamResponse(team), nil
+}
+
+// ListTeams returns a community's teams with roster sizes
+func (s *TeamService) ListTeams(ctx context.Context, communityID uuid.UUID) ([]map[string]interface{}, error) {
+	teams, err := s.repo.ListCommunityTeams(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(teams))
+	for _, t := range teams {
+		team := teamResponse(repository.Team{
+			ID:          t.ID,
+			CommunityID: t.CommunityID,
+			Name:        t.Name,
+			CaptainID:   t.CaptainID,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   t.UpdatedAt,
+		})
+		if t.CaptainID.Valid {
+			team["captain"] = map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(t.CaptainID),
+				"first_name": t.CaptainFirstName.String,
+				"last_name":  t.CaptainLastName.String,
+			}
+		}
+		team["member_count"] = t.MemberCount
+		result = append(result, team)
+	}
+	return result, nil
+}
+
+// GetTeam returns a team with its roster
+func (s *TeamService) GetTeam(ctx context.Context, teamID uuid.UUID) (map[string]interface{}, error) {
+	team, err := s.getTeam(ctx, s.repo, uuidToPgtype(teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list team members: %w", err)
+	}
+
+	roster := make([]map[string]interface{}, 0, len(members))
+	for _, m := range members {
+		member := map[string]interface{}{
+			"user_id":    pgtypeUUIDToStringRequired(m.UserID),
+			"first_name": m.FirstName.String,
+			"last_name":  m.LastName.String,
+			"avatar_url": m.AvatarUrl.String,
+			"is_captain": m.UserID == team.CaptainID,
+			"joined_at":  m.JoinedAt.Time,
+		}
+		if m.NtrpLevel.Valid {
+			member["ntrp_level"] = numericToFloat(m.NtrpLevel)
+		}
+		roster = append(roster, member)
+	}
+
+	result := teamResponse(team)
+	result["members"] = roster
+	return result, nil
+}
+
+// UpdateTeam renames a team or changes its captain (captain or community owner/admin)
+func (s *TeamService) UpdateTeam(ctx context.Context, actorID, teamID uuid.UUID, input UpdateTeamInput) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	team, err := s.getTeam(ctx, qtx, uuidToPgtype(teamID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamManager(ctx, qtx, actorID, team); err != nil {
+		return nil, err
+	}
+
+	params := repository.UpdateTeamParams{ID: team.ID}
+	if input.Name != nil {
+		name, err := normalizeTeamName(*input.Name)
+		if err != nil {
+			return nil, err
+		}
+		params.Name = pgtype.Text{String: name, Valid: true}
+	}
+	if input.CaptainID != nil {
+		if err := requireActiveMember(ctx, qtx, team.CommunityID, *input.CaptainID); err != nil {
+			return nil, err
+		}
+		params.CaptainID = uuidToPgtype(*input.CaptainID)
+		if _, err := qtx.AddTeamMember(ctx, repository.AddTeamMemberParams{TeamID: team.ID, UserID: params.CaptainID}); err != nil {
+			return nil, fmt.Errorf("add captain: %w", err)
+		}
+	}
+
+	updated, err := qtx.UpdateTeam(ctx, params)
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists.WithMessage("The community already has a team with this name")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update team: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return teamResponse(updated), nil
+}
+
+// AddMember adds an active member of the team's community to the roster
+func (s *TeamService) AddMember(ctx context.Context, actorID, teamID, userID uuid.UUID) error {
+	team, err := s.getTeam(ctx, s.repo, uuidToPgtype(teamID))
+	if err != nil {
+		return err
+	}
+	if err := s.requireTeamManager(ctx, s.repo, actorID, team); err != nil {
+		return err
+	}
+	if err := requireActiveMember(ctx, s.repo, team.CommunityID, userID); err != nil {
+		return err
+	}
+
+	added, err := s.repo.AddTeamMember(ctx, repository.AddTeamMemberParams{
+		TeamID: team.ID,
+		UserID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("add team member: %w", err)
+	}
+	if added == 0 {
+		return ErrAlreadyMember.WithMessage("User is already on the team")
+	}
+	return nil
+}
+
+// RemoveMember takes a player off the roster. The captain has to be replaced first.
+func (s *TeamService) RemoveMember(ctx context.Context, actorID, teamID, userID uuid.UUID) error {
+	team, err := s.getTeam(ctx, s.repo, uuidToPgtype(teamID))
+	if err != nil {
+		return err
+	}
+	if err := s.requireTeamManager(ctx, s.repo, actorID, team); err != nil {
+		return err
+	}
+	if team.CaptainID == uuidToPgtype(userID) {
+		return ErrValidation.WithMessage("Assign another captain before removing the current one")
+	}
+
+	removed, err := s.repo.RemoveTeamMember(ctx, repository.RemoveTeamMemberParams{
+		TeamID: team.ID,
+		UserID: uuidToPgtype(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("remove team member: %w", err)
+	}
+	if removed == 0 {
+		return ErrNotFound.WithMessage("User is not on the team")
+	}
+	return nil
+}
+
+// CreateTie schedules a tie organised by a community. The teams may belong to
+// any community; the event, if given, must be one of the organiser's.
+func (s *TeamService) CreateTie(ctx context.Context, actorID, communityID uuid.UUID, input CreateTieInput) (map[string]interface{}, error) {
+	compositions, err := parseRubbers(input.Rubbers)
+	if err != nil {
+		return nil, err
+	}
+	if input.HomeTeamID == uuid.Nil || input.AwayTeamID == uuid.Nil {
+		return nil, ErrValidation.WithMessage("home_team_id and away_team_id are required")
+	}
+	if input.HomeTeamID == input.AwayTeamID {
+		return nil, ErrValidation.WithMessage("A team cannot play itself")
+	}
+
+	for _, id := range []uuid.UUID{input.HomeTeamID, input.AwayTeamID} {
+		if _, err := s.getTeam(ctx, s.repo, uuidToPgtype(id)); err != nil {
+			return nil, err
+		}
+	}
+
+	eventID := pgtype.UUID{}
+	if input.EventID != nil {
+		event, err := s.repo.GetEventByID(ctx, uuidToPgtype(*input.EventID))
+		if err == pgx.ErrNoRows {
+			return nil, ErrEventNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get event: %w", err)
+		}
+		if event.CommunityID != uuidToPgtype(communityID) {
+			return nil, ErrValidation.WithMessage("Event belongs to another community")
+		}
+		eventID = event.ID
+	}
+
+	scheduled := pgtype.Timestamptz{}
+	if input.ScheduledTime != nil {
+		scheduled = pgtype.Timestamptz{Time: *input.ScheduledTime, Valid: true}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	tie, err := qtx.CreateTeamTie(ctx, repository.CreateTeamTieParams{
+		CommunityID:   uuidToPgtype(communityID),
+		EventID:       eventID,
+		HomeTeamID:    uuidToPgtype(input.HomeTeamID),
+		AwayTeamID:    uuidToPgtype(input.AwayTeamID),
+		ScheduledTime: scheduled,
+		CreatedBy:     uuidToPgtype(actorID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create tie: %w", err)
+	}
+
+	for i, composition := range compositions {
+		if err := qtx.CreateTeamTieRubber(ctx, repository.CreateTeamTieRubberParams{
+			TieID:        tie.ID,
+			RubberNumber: int32(i + 1),
+			Composition:  composition,
+		}); err != nil {
+			return nil, fmt.Errorf("create rubber: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return s.GetTie(ctx, actorID, uuid.UUID(tie.ID.Bytes))
+}
+
+// ListTies returns the ties organised by a community with their scores
+func (s *TeamService) ListTies(ctx context.Context, communityID uuid.UUID, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	ties, err := s.repo.ListCommunityTeamTies(ctx, repository.ListCommunityTeamTiesParams{
+		CommunityID:  uuidToPgtype(communityID),
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list ties: %w", err)
+	}
+
+	total, err := s.repo.CountCommunityTeamTies(ctx, uuidToPgtype(communityID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("count ties: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(ties))
+	for _, t := range ties {
+		result = append(result, tieSummaryResponse(repository.ListEventTeamTiesRow(t)))
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// GetTie returns a tie with its rubbers and score. Until both lineups are in,
+// a lineup is only shown to those who manage that team.
+func (s *TeamService) GetTie(ctx context.Context, userID, tieID uuid.UUID) (map[string]interface{}, error) {
+	tie, err := s.repo.GetTeamTie(ctx, uuidToPgtype(tieID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrTieNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get tie: %w", err)
+	}
+
+	home, err := s.getTeam(ctx, s.repo, tie.HomeTeamID)
+	if err != nil {
+		return nil, err
+	}
+	away, err := s.getTeam(ctx, s.repo, tie.AwayTeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	rubbers, err := s.repo.ListTeamTieRubbers(ctx, tie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list rubbers: %w", err)
+	}
+	lineups, err := s.repo.ListTeamTieLineups(ctx, tie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list lineups: %w", err)
+	}
+
+	locked := tie.LineupsLockedAt.Valid
+	visible := map[pgtype.UUID]bool{}
+	submitted := map[pgtype.UUID]bool{}
+	for _, team := range []repository.Team{home, away} {
+		visible[team.ID] = locked || s.canManageTeam(ctx, s.repo, userID, team)
+	}
+
+	lineupByRubber := map[pgtype.UUID]map[int32]map[string]interface{}{home.ID: {}, away.ID: {}}
+	for _, l := range lineups {
+		submitted[l.TeamID] = true
+		if !visible[l.TeamID] {
+			continue
+		}
+		side := map[string]interface{}{
+			"player": map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(l.PlayerID),
+				"first_name": l.PlayerFirstName.String,
+				"last_name":  l.PlayerLastName.String,
+			},
+		}
+		if l.PartnerID.Valid {
+			side["partner"] = map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(l.PartnerID),
+				"first_name": l.PartnerFirstName.String,
+				"last_name":  l.PartnerLastName.String,
+			}
+		}
+		lineupByRubber[l.TeamID][l.RubberNumber] = side
+	}
+
+	var homePoints, awayPoints int
+	rubberList := make([]map[string]interface{}, 0, len(rubbers))
+	for _, r := range rubbers {
+		rubber := map[string]interface{}{
+			"number":      r.RubberNumber,
+			"composition": string(r.Composition),
+			"home":        lineupByRubber[home.ID][r.RubberNumber],
+			"away":        lineupByRubber[away.ID][r.RubberNumber],
+			"winner":      nil,
+		}
+		if r.MatchID.Valid {
+			rubber["match_id"] = pgtypeUUIDToStringRequired(r.MatchID)
+			rubber["result_status"] = string(r.ResultStatus.ResultStatus)
+			if len(r.Score) > 0 {
+				rubber["score"] = json.RawMessage(r.Score)
+			}
+		}
+		switch rubberWinner(r) {
+		case "home":
+			homePoints++
+			rubber["winner"] = "home"
+		case "away":
+			awayPoints++
+			rubber["winner"] = "away"
+		}
+		rubberList = append(rubberList, rubber)
+	}
+
+	result := tieSummaryResponse(repository.ListEventTeamTiesRow{
+		ID:              tie.ID,
+		CommunityID:     tie.CommunityID,
+		EventID:         tie.EventID,
+		HomeTeamID:      tie.HomeTeamID,
+		AwayTeamID:      tie.AwayTeamID,
+		ScheduledTime:   tie.ScheduledTime,
+		LineupsLockedAt: tie.LineupsLockedAt,
+		CreatedBy:       tie.CreatedBy,
+		CreatedAt:       tie.CreatedAt,
+		HomeTeamName:    home.Name,
+		AwayTeamName:    away.Name,
+		RubberCount:     int32(len(rubbers)),
+		HomePoints:      int32(homePoints),
+		AwayPoints:      int32(awayPoints),
+	})
+	result["home_team"].(map[string]interface{})["lineup_submitted"] = submitted[home.ID]
+	result["away_team"].(map[string]interface{})["lineup_submitted"] = submitted[away.ID]
+	result["rubbers"] = rubberList
+	return result, nil
+}
+
+// SubmitLineup sets or replaces a team's lineup (captain or owner/admin of the
+// team's community). When the second lineup arrives the lineups are locked
+// and a match is created for every rubber.
+func (s *TeamService) SubmitLineup(ctx context.Context, actorID, tieID, teamID uuid.UUID, entries []LineupEntry) (map[string]interface{}, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	tie, err := qtx.LockTeamTie(ctx, uuidToPgtype(tieID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrTieNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock tie: %w", err)
+	}
+	if tie.LineupsLockedAt.Valid {
+		return nil, ErrValidation.WithMessage("Lineups are already locked")
+	}
+
+	pgTeamID := uuidToPgtype(teamID)
+	if pgTeamID != tie.HomeTeamID && pgTeamID != tie.AwayTeamID {
+		return nil, ErrValidation.WithMessage("Team does not play in this tie")
+	}
+	team, err := s.getTeam(ctx, qtx, pgTeamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireTeamManager(ctx, qtx, actorID, team); err != nil {
+		return nil, err
+	}
+
+	rubbers, err := qtx.ListTeamTieRubbers(ctx, tie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list rubbers: %w", err)
+	}
+	compositions := make(map[int]repository.PlayerComposition, len(rubbers))
+	for _, r := range rubbers {
+		compositions[int(r.RubberNumber)] = r.Composition
+	}
+
+	members, err := qtx.ListTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list team members: %w", err)
+	}
+	roster := make(map[uuid.UUID]bool, len(members))
+	for _, m := range members {
+		roster[uuid.UUID(m.UserID.Bytes)] = true
+	}
+
+	if err := validateLineup(compositions, roster, entries); err != nil {
+		return nil, err
+	}
+
+	if err := qtx.DeleteTeamLineup(ctx, repository.DeleteTeamLineupParams{TieID: tie.ID, TeamID: team.ID}); err != nil {
+		return nil, fmt.Errorf("delete lineup: %w", err)
+	}
+	for _, e := range entries {
+		partner := pgtype.UUID{}
+		if e.PartnerID != nil {
+			partner = uuidToPgtype(*e.PartnerID)
+		}
+		if err := qtx.CreateTeamLineupEntry(ctx, repository.CreateTeamLineupEntryParams{
+			TieID:        tie.ID,
+			TeamID:       team.ID,
+			RubberNumber: int32(e.Rubber),
+			PlayerID:     uuidToPgtype(e.PlayerID),
+			PartnerID:    partner,
+			SubmittedBy:  uuidToPgtype(actorID),
+		}); err != nil {
+			return nil, fmt.Errorf("create lineup entry: %w", err)
+		}
+	}
+
+	if err := s.lockIfComplete(ctx, qtx, tie, len(rubbers)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return s.GetTie(ctx, actorID, tieID)
+}
+
+// lockIfComplete creates the rubber matches once both lineups are complete
+func (s *TeamService) lockIfComplete(ctx context.Context, q *repository.Queries, tie repository.TeamTie, rubberCount int) error {
+	lineups, err := q.ListTeamTieLineups(ctx, tie.ID)
+	if err != nil {
+		return fmt.Errorf("list lineups: %w", err)
+	}
+
+	home := map[int32]repository.ListTeamTieLineupsRow{}
+	away := map[int32]repository.ListTeamTieLineupsRow{}
+	homePlayers := map[pgtype.UUID]bool{}
+	for _, l := range lineups {
+		if l.TeamID == tie.HomeTeamID {
+			home[l.RubberNumber] = l
+			homePlayers[l.PlayerID] = true
+			if l.PartnerID.Valid {
+				homePlayers[l.PartnerID] = true
+			}
+		} else {
+			away[l.RubberNumber] = l
+		}
+	}
+	if len(home) < rubberCount || len(away) < rubberCount {
+		return nil
+	}
+
+	for _, l := range away {
+		if homePlayers[l.PlayerID] || (l.PartnerID.Valid && homePlayers[l.PartnerID]) {
+			return ErrValidation.WithMessage("A player cannot appear in both lineups")
+		}
+	}
+
+	rubbers, err := q.ListTeamTieRubbers(ctx, tie.ID)
+	if err != nil {
+		return fmt.Errorf("list rubbers: %w", err)
+	}
+
+	for _, r := range rubbers {
+		h, a := home[r.RubberNumber], away[r.RubberNumber]
+		match, err := q.CreateMatch(ctx, repository.CreateMatchParams{
+			EventID:          tie.EventID,
+			CommunityID:      tie.CommunityID,
+			Player1ID:        h.PlayerID,
+			Player2ID:        a.PlayerID,
+			Player1PartnerID: h.PartnerID,
+			Player2PartnerID: a.PartnerID,
+			Composition:      r.Composition,
+			RoundName:        pgtype.Text{String: fmt.Sprintf("Rubber %d", r.RubberNumber), Valid: true},
+			RoundNumber:      pgtype.Int4{Int32: r.RubberNumber, Valid: true},
+			ScheduledTime:    tie.ScheduledTime,
+		})
+		if err != nil {
+			return fmt.Errorf("create rubber match: %w", err)
+		}
+		if err := q.SetTeamTieRubberMatch(ctx, repository.SetTeamTieRubberMatchParams{
+			MatchID:      match.ID,
+			TieID:        tie.ID,
+			RubberNumber: r.RubberNumber,
+		}); err != nil {
+			return fmt.Errorf("link rubber match: %w", err)
+		}
+	}
+
+	if err := q.SetTeamTieLineupsLocked(ctx, tie.ID); err != nil {
+		return fmt.Errorf("lock lineups: %w", err)
+	}
+	return nil
+}
+
+// EventStandings returns the league table of an event's ties
+func (s *TeamService) EventStandings(ctx context.Context, eventID uuid.UUID) ([]TeamStanding, error) {
+	if _, err := s.repo.GetEventByID(ctx, uuidToPgtype(eventID)); err == pgx.ErrNoRows {
+		return nil, ErrEventNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+
+	ties, err := s.repo.ListEventTeamTies(ctx, uuidToPgtype(eventID))
+	if err != nil {
+		return nil, fmt.Errorf("list event ties: %w", err)
+	}
+	return teamStandings(ties), nil
+}
+
+func (s *TeamService) getTeam(ctx context.Context, q *repository.Queries, id pgtype.UUID) (repository.Team, error) {
+	team, err := q.GetTeam(ctx, id)
+	if err == pgx.ErrNoRows {
+		return team, ErrTeamNotFound
+	}
+	if err != nil {
+		return team, fmt.Errorf("get team: %w", err)
+	}
+	return team, nil
+}
+
+// canManageTeam reports whether the user is the captain or an owner/admin of the team's community
+func (s *TeamService) canManageTeam(ctx context.Context, q *repository.Queries, userID uuid.UUID, team repository.Team) bool {
+	if team.CaptainID == uuidToPgtype(userID) {
+		return true
+	}
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: team.CommunityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err != nil || member.Status.MemberStatus != repository.MemberStatusActive {
+		return false
+	}
+	return communityRoleRank(member.Role.CommunityRole) >= communityRoleRank(repository.CommunityRoleAdmin)
+}
+
+func (s *TeamService) requireTeamManager(ctx context.Context, q *repository.Queries, userID uuid.UUID, team repository.Team) error {
+	if !s.canManageTeam(ctx, q, userID, team) {
+		return ErrForbidden.WithMessage("Only the team captain or a community admin can manage the team")
+	}
+	return nil
+}
+
+// requireActiveMember checks that the user is an active member of the community
+func requireActiveMember(ctx context.Context, q *repository.Queries, communityID pgtype.UUID, userID uuid.UUID) error {
+	member, err := q.GetCommunityMember(ctx, repository.GetCommunityMemberParams{
+		CommunityID: communityID,
+		UserID:      uuidToPgtype(userID),
+	})
+	if err == pgx.ErrNoRows || (err == nil && member.Status.MemberStatus != repository.MemberStatusActive) {
+		return ErrNotCommunityMember.WithMessage("User must be an active member of the community")
+	}
+	if err != nil {
+		return fmt.Errorf("get community member: %w", err)
+	}
+	return nil
+}
+
+func normalizeTeamName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrValidation.WithMessage("name is required")
+	}
+	if utf8.RuneCountInString(name) > teamMaxName {
+		return "", ErrValidation.WithMessage(fmt.Sprintf("name must be at most %d characters", teamMaxName))
+	}
+	return name, nil
+}
+
+// parseRubbers validates the rubber compositions of a new tie
+func parseRubbers(rubbers []string) ([]repository.PlayerComposition, error) {
+	if len(rubbers) == 0 || len(rubbers) > tieMaxRubbers {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("A tie has between 1 and %d rubbers", tieMaxRubbers))
+	}
+	result := make([]repository.PlayerComposition, 0, len(rubbers))
+	for _, r := range rubbers {
+		composition := repository.PlayerComposition(r)
+		if composition != repository.PlayerCompositionSingles && composition != repository.PlayerCompositionDoubles {
+			return nil, ErrValidation.WithMessage("Each rubber must be singles or doubles")
+		}
+		result = append(result, composition)
+	}
+	return result, nil
+}
+
+// validateLineup checks a lineup against the tie's rubbers and the team roster.
+// Every rubber needs exactly one entry, and a player may play at most one
+// singles and one doubles rubber.
+func validateLineup(compositions map[int]repository.PlayerComposition, roster map[uuid.UUID]bool, entries []LineupEntry) error {
+	if len(entries) != len(compositions) {
+		return ErrValidation.WithMessage(fmt.Sprintf("The lineup must cover all %d rubbers", len(compositions)))
+	}
+
+	seenRubber := map[int]bool{}
+	playing := map[repository.PlayerComposition]map[uuid.UUID]bool{
+		repository.PlayerCompositionSingles: {},
+		repository.PlayerCompositionDoubles: {},
+	}
+	for _, e := range entries {
+		composition, ok := compositions[e.Rubber]
+		if !ok {
+			return ErrValidation.WithMessage(fmt.Sprintf("Rubber %d does not exist", e.Rubber))
+		}
+		if seenRubber[e.Rubber] {
+			return ErrValidation.WithMessage(fmt.Sprintf("Rubber %d appears twice", e.Rubber))
+		}
+		seenRubber[e.Rubber] = true
+
+		players := []uuid.UUID{e.PlayerID}
+		switch {
+		case composition == repository.PlayerCompositionDoubles && e.PartnerID == nil:
+			return ErrValidation.WithMessage(fmt.Sprintf("Rubber %d is doubles and needs a partner", e.Rubber))
+		case composition == repository.PlayerCompositionSingles && e.PartnerID != nil:
+			return ErrValidation.WithMessage(fmt.Sprintf("Rubber %d is singles and takes no partner", e.Rubber))
+		case e.PartnerID != nil:
+			if *e.PartnerID == e.PlayerID {
+				return ErrValidation.WithMessage(fmt.Sprintf("Rubber %d needs two different players", e.Rubber))
+			}
+			players = append(players, *e.PartnerID)
+		}
+
+		for _, p := range players {
+			if !roster[p] {
+				return ErrValidation.WithMessage(fmt.Sprintf("Player %s is not on the team", p))
+			}
+			if playing[composition][p] {
+				return ErrValidation.WithMessage(fmt.Sprintf("Player %s is already in another %s rubber", p, composition))
+			}
+			playing[composition][p] = true
+		}
+	}
+	return nil
+}
+
+// rubberWinner returns "home" or "away" for a confirmed rubber, or "" otherwise.
+// The home team always plays as player 1.
+func rubberWinner(r repository.ListTeamTieRubbersRow) string {
+	if !r.WinnerID.Valid || !r.ResultStatus.Valid {
+		return ""
+	}
+	if r.ResultStatus.ResultStatus != repository.ResultStatusConfirmed && r.ResultStatus.ResultStatus != repository.ResultStatusAdminConfirmed {
+		return ""
+	}
+	switch r.WinnerID {
+	case r.Player1ID, r.Player1PartnerID:
+		return "home"
+	case r.Player2ID, r.Player2PartnerID:
+		return "away"
+	}
+	return ""
+}
+
+// tieStatus derives the state of a tie from its lineups and decided rubbers
+func tieStatus(locked bool, rubbers, decided int) string {
+	switch {
+	case !locked:
+		return TieAwaitingLineups
+	case decided >= rubbers:
+		return TieCompleted
+	default:
+		return TieInProgress
+	}
+}
+
+// tieWinner returns "home", "away" or "draw" for a completed tie, or nil
+func tieWinner(status string, home, away int) interface{} {
+	if status != TieCompleted {
+		return nil
+	}
+	switch {
+	case home > away:
+		return "home"
+	case away > home:
+		return "away"
+	default:
+		return "draw"
+	}
+}
+
+// teamStandings builds a league table from completed ties: two points for a
+// win and one for a draw, then rubber difference and rubbers won decide
+func teamStandings(ties []repository.ListEventTeamTiesRow) []TeamStanding {
+	byTeam := map[pgtype.UUID]*TeamStanding{}
+	standing := func(id pgtype.UUID, name string) *TeamStanding {
+		if t, ok := byTeam[id]; ok {
+			return t
+		}
+		t := &TeamStanding{TeamID: pgtypeUUIDToStringRequired(id), TeamName: name}
+		byTeam[id] = t
+		return t
+	}
+
+	for _, tie := range ties {
+		home := standing(tie.HomeTeamID, tie.HomeTeamName)
+		away := standing(tie.AwayTeamID, tie.AwayTeamName)
+
+		status := tieStatus(tie.LineupsLockedAt.Valid, int(tie.RubberCount), int(tie.HomePoints+tie.AwayPoints))
+		if status != TieCompleted {
+			continue
+		}
+
+		home.Played++
+		away.Played++
+		home.RubbersWon += int(tie.HomePoints)
+		home.RubbersLost += int(tie.AwayPoints)
+		away.RubbersWon += int(tie.AwayPoints)
+		away.RubbersLost += int(tie.HomePoints)
+
+		switch tieWinner(status, int(tie.HomePoints), int(tie.AwayPoints)) {
+		case "home":
+			home.Won++
+			away.Lost++
+			home.Points += tieWinPoints
+		case "away":
+			away.Won++
+			home.Lost++
+			away.Points += tieWinPoints
+		default:
+			home.Drawn++
+			away.Drawn++
+			home.Points += tieDrawPoints
+			away.Points += tieDrawPoints
+		}
+	}
+
+	table := make([]TeamStanding, 0, len(byTeam))
+	for _, t := range byTeam {
+		table = append(table, *t)
+	}
+	sort.Slice(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if a.RubbersWon-a.RubbersLost != b.RubbersWon-b.RubbersLost {
+			return a.RubbersWon-a.RubbersLost > b.RubbersWon-b.RubbersLost
+		}
+		if a.RubbersWon != b.RubbersWon {
+			return a.RubbersWon > b.RubbersWon
+		}
+		return a.TeamName < b.TeamName
+	})
+	for i := range table {
+		table[i].Rank = i + 1
+	}
+	return table
+}
+
+func teamResponse(t repository.Team) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(t.ID),
+		"community_id": pgtypeUUIDToStringRequired(t.CommunityID),
+		"name":         t.Name,
+		"captain_id":   pgtypeUUIDToString(t.CaptainID),
+		"created_at":   t.CreatedAt.Time,
+	}
+	return result
+}
+
+func tieSummaryResponse(t repository.ListEventTeamTiesRow) map[string]interface{} {
+	status := tieStatus(t.LineupsLockedAt.Valid, int(t.RubberCount), int(t.HomePoints+t.AwayPoints))
+
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(t.ID),
+		"community_id": pgtypeUUIDToStringRequired(t.CommunityID),
+		"event_id":     pgtypeUUIDToString(t.EventID),
+		"home_team": map[string]interface{}{
+			"id":   pgtypeUUIDToStringRequired(t.HomeTeamID),
+			"name": t.HomeTeamName,
+		},
+		"away_team": map[string]interface{}{
+			"id":   pgtypeUUIDToStringRequired(t.AwayTeamID),
+			"name": t.AwayTeamName,
+		},
+		"status":       status,
+		"rubber_count": t.RubberCount,
+		"score": map[string]interface{}{
+			"home": t.HomePoints,
+			"away": t.AwayPoints,
+		},
+		"winner":     tieWinner(status, int(t.HomePoints), int(t.AwayPoints)),
+		"created_at": t.CreatedAt.Time,
+	}
+	if t.ScheduledTime.Valid {
+		result["scheduled_time"] = t.ScheduledTime.Time
+	}
+	if t.LineupsLockedAt.Valid {
+		result["lineups_locked_at"] = t.LineupsLockedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValidateLineup(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	outsider := uuid.New()
+	roster := map[uuid.UUID]bool{a: true, b: true, c: true, d: true}
+	compositions := map[int]repository.PlayerComposition{
+		1: repository.PlayerCompositionSingles,
+		2: repository.PlayerCompositionSingles,
+		3: repository.PlayerCompositionDoubles,
+	}
+	partner := func(id uuid.UUID) *uuid.UUID { return &id }
+
+	valid := []LineupEntry{
+		{Rubber: 1, PlayerID: a},
+		{Rubber: 2, PlayerID: b},
+		{Rubber: 3, PlayerID: a, PartnerID: partner(c)},
+	}
+	if err := validateLineup(compositions, roster, valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string][]LineupEntry{
+		"missing rubber": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+		"duplicate rubber": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 1, PlayerID: b},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+		"unknown rubber": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 4, PlayerID: b},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+		"doubles without partner": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 2, PlayerID: b},
+			{Rubber: 3, PlayerID: c},
+		},
+		"singles with partner": {
+			{Rubber: 1, PlayerID: a, PartnerID: partner(d)},
+			{Rubber: 2, PlayerID: b},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+		"partner is the player": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 2, PlayerID: b},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(c)},
+		},
+		"player off the roster": {
+			{Rubber: 1, PlayerID: outsider},
+			{Rubber: 2, PlayerID: b},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+		"two singles rubbers": {
+			{Rubber: 1, PlayerID: a},
+			{Rubber: 2, PlayerID: a},
+			{Rubber: 3, PlayerID: c, PartnerID: partner(d)},
+		},
+	}
+	for name, entries := range tests {
+		if err := validateLineup(compositions, roster, entries); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestTieStatusAndWinner(t *testing.T) {
+	tests := []struct {
+		locked     bool
+		rubbers    int
+		home, away int
+		wantStatus string
+		wantWinner interface{}
+	}{
+		{false, 3, 0, 0, TieAwaitingLineups, nil},
+		{true, 3, 1, 0, TieInProgress, nil},
+		{true, 3, 2, 1, TieCompleted, "home"},
+		{true, 3, 0, 3, TieCompleted, "away"},
+		{true, 2, 1, 1, TieCompleted, "draw"},
+	}
+	for _, tt := range tests {
+		status := tieStatus(tt.locked, tt.rubbers, tt.home+tt.away)
+		if status != tt.wantStatus {
+			t.Errorf("tieStatus(%v, %d, %d) = %s, want %s", tt.locked, tt.rubbers, tt.home+tt.away, status, tt.wantStatus)
+		}
+		if winner := tieWinner(status, tt.home, tt.away); winner != tt.wantWinner {
+			t.Errorf("tieWinner(%s, %d, %d) = %v, want %v", status, tt.home, tt.away, winner, tt.wantWinner)
+		}
+	}
+}
+
+func TestTeamStandings(t *testing.T) {
+	id := func() pgtype.UUID { return uuidToPgtype(uuid.New()) }
+	locked := pgtype.Timestamptz{Valid: true}
+	alpha, bravo, charlie, delta := id(), id(), id(), id()
+
+	tie := func(home, away pgtype.UUID, homeName, awayName string, homePts, awayPts int32) repository.ListEventTeamTiesRow {
+		return repository.ListEventTeamTiesRow{
+			HomeTeamID:      home,
+			AwayTeamID:      away,
+			HomeTeamName:    homeName,
+			AwayTeamName:    awayName,
+			LineupsLockedAt: locked,
+			RubberCount:     3,
+			HomePoints:      homePts,
+			AwayPoints:      awayPts,
+		}
+	}
+
+	unfinished := tie(alpha, delta, "Alpha", "Delta", 1, 0)
+	notLocked := tie(charlie, delta, "Charlie", "Delta", 0, 0)
+	notLocked.LineupsLockedAt = pgtype.Timestamptz{}
+
+	table := teamStandings([]repository.ListEventTeamTiesRow{
+		tie(alpha, bravo, "Alpha", "Bravo", 2, 1),
+		tie(charlie, alpha, "Charlie", "Alpha", 3, 0),
+		tie(bravo, charlie, "Bravo", "Charlie", 2, 1),
+		unfinished,
+		notLocked,
+	})
+
+	want := []struct {
+		name   string
+		points int
+		played int
+	}{
+		// Charlie, Alpha and Bravo have one win each; rubber difference decides
+		{"Charlie", 2, 2},
+		{"Bravo", 2, 2},
+		{"Alpha", 2, 2},
+		{"Delta", 0, 0},
+	}
+	if len(table) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(table), len(want))
+	}
+	for i, w := range want {
+		row := table[i]
+		if row.TeamName != w.name || row.Points != w.points || row.Played != w.played || row.Rank != i+1 {
+			t.Errorf("row %d = %+v, want %s with %d points from %d ties", i, row, w.name, w.points, w.played)
+		}
+	}
+}
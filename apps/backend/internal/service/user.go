@@ -3,8 +3,10 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
 	"github.com/google/uuid"
@@ -16,13 +18,15 @@ import (
 type UserService struct {
 	repo    *repository.Queries
 	storage *StorageService
+	news    *CommunityNews
 }
 
 // NewUserService creates a new UserService
-func NewUserService(repo *repository.Queries, storage *StorageService) *UserService {
+func NewUserService(repo *repository.Queries, storage *StorageService, news *CommunityNews) *UserService {
 	return &UserService{
 		repo:    repo,
 		storage: storage,
+		news:    news,
 	}
 }
 
@@ -97,6 +101,9 @@ type UpdateProfileInput struct {
 	District  *string `json:"district"`
 	Language  *string `json:"language"`
 	City      *string `json:"city"`
+
+	// ShareMatchResults opts in to automatic feed posts of confirmed matches
+	ShareMatchResults *bool `json:"share_match_results"`
 }
 
 // UpdateProfile updates user profile fields
@@ -123,6 +130,9 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, input
 	if input.City != nil {
 		params.City = pgtype.Text{String: *input.City, Valid: true}
 	}
+	if input.ShareMatchResults != nil {
+		params.ShareMatchResults = pgtype.Bool{Bool: *input.ShareMatchResults, Valid: true}
+	}
 
 	user, err := s.repo.UpdateUser(ctx, params)
 	if err == pgx.ErrNoRows {
@@ -135,6 +145,59 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, input
 	return buildUserProfile(user, true), nil
 }
 
+// UpdateNotificationSettingsInput represents notification settings that can be updated
+type UpdateNotificationSettingsInput struct {
+	EventResponse   *bool   `json:"event_response,omitempty"`
+	GameReminder24h *bool   `json:"game_reminder_24h,omitempty"`
+	GameReminder1h  *bool   `json:"game_reminder_1h,omitempty"`
+	ResultConfirm   *bool   `json:"result_confirm,omitempty"`
+	CommunityNews   *bool   `json:"community_news,omitempty"`
+	NewMessage      *bool   `json:"new_message,omitempty"`
+	RatingChange    *bool   `json:"rating_change,omitempty"`
+	NewBadge        *bool   `json:"new_badge,omitempty"`
+	QuietHoursStart *string `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string `json:"quiet_hours_end,omitempty"`
+}
+
+// UpdateNotificationSettings merges the given settings into the user's
+// notification settings. Changing community_news also subscribes or
+// unsubscribes the user's devices from the news of their communities.
+func (s *UserService) UpdateNotificationSettings(ctx context.Context, userID uuid.UUID, input UpdateNotificationSettingsInput) (map[string]interface{}, error) {
+	for _, v := range []*string{input.QuietHoursStart, input.QuietHoursEnd} {
+		if v == nil {
+			continue
+		}
+		if _, err := time.Parse("15:04", *v); err != nil {
+			return nil, ErrValidation.WithMessage("Quiet hours must be in HH:MM format")
+		}
+	}
+
+	// Unset fields are omitted, so only the given settings are merged
+	patch, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("marshal notification settings: %w", err)
+	}
+
+	settings, err := s.repo.MergeNotificationSettings(ctx, repository.MergeNotificationSettingsParams{
+		Settings: patch,
+		ID:       pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update notification settings: %w", err)
+	}
+
+	if input.CommunityNews != nil {
+		s.news.Sync(ctx, userID)
+	}
+
+	return map[string]interface{}{
+		"notification_settings": json.RawMessage(settings),
+	}, nil
+}
+
 // GetPublicProfile returns a public view of a user's profile
 func (s *UserService) GetPublicProfile(ctx context.Context, currentUserID, targetUserID uuid.UUID) (map[string]interface{}, error) {
 	user, err := s.repo.GetUserByID(ctx, pgtype.UUID{Bytes: targetUserID, Valid: true})
@@ -201,6 +264,13 @@ func (s *UserService) GetPublicProfile(ctx context.Context, currentUserID, targe
 	})
 	profile["mutual_communities"] = mutualCount
 
+	// Count mutual friends
+	mutualFriends, _ := s.repo.CountMutualFriends(ctx, repository.CountMutualFriendsParams{
+		UserID:  pgtype.UUID{Bytes: currentUserID, Valid: true},
+		OtherID: pgtype.UUID{Bytes: targetUserID, Valid: true},
+	})
+	profile["mutual_friends"] = mutualFriends
+
 	return profile, nil
 }
 
@@ -316,6 +386,91 @@ func (s *UserService) SearchUsers(ctx context.Context, input SearchUsersInput) (
 	}, nil
 }
 
+// NearbyPlayers returns players who opted in to location search, closest first.
+// Only an approximate distance is returned, never coordinates.
+func (s *UserService) NearbyPlayers(ctx context.Context, userID uuid.UUID, input NearbyInput) ([]map[string]interface{}, error) {
+	if err := input.normalize(); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListPlayersNearby(ctx, repository.ListPlayersNearbyParams{
+		Lat:           input.Lat,
+		Lng:           input.Lng,
+		ExcludeUserID: pgtype.UUID{Bytes: userID, Valid: true},
+		RadiusMeters:  input.radiusMeters(),
+		ResultLimit:   int32(input.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list players nearby: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(rows))
+	for _, u := range rows {
+		uid, _ := uuid.FromBytes(u.ID.Bytes[:])
+		result = append(result, map[string]interface{}{
+			"id":            uid.String(),
+			"first_name":    u.FirstName.String,
+			"last_name":     u.LastName.String,
+			"avatar_url":    u.AvatarUrl.String,
+			"ntrp_level":    numericToFloat(u.NtrpLevel),
+			"level_label":   u.LevelLabel.String,
+			"global_rating": numericToFloat(u.GlobalRating),
+			"district":      u.District.String,
+			"distance_km":   approximateDistanceKm(u.DistanceMeters),
+		})
+	}
+
+	return result, nil
+}
+
+// GetLocation returns the user's stored (rounded) home location, or nil if not shared
+func (s *UserService) GetLocation(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
+	loc, err := s.repo.GetUserLocation(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err == pgx.ErrNoRows {
+		return map[string]interface{}{"shared": false}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get user location: %w", err)
+	}
+
+	return buildUserLocationResponse(loc), nil
+}
+
+// SetLocation opts the user in to nearby search with an approximate home location
+func (s *UserService) SetLocation(ctx context.Context, userID uuid.UUID, lat, lng float64) (map[string]interface{}, error) {
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, ErrValidation.WithMessage("lat must be between -90 and 90, lng between -180 and 180")
+	}
+
+	loc, err := s.repo.UpsertUserLocation(ctx, repository.UpsertUserLocationParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		Latitude:  roundCoordinate(lat),
+		Longitude: roundCoordinate(lng),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert user location: %w", err)
+	}
+
+	return buildUserLocationResponse(loc), nil
+}
+
+// DeleteLocation opts the user out of nearby search
+func (s *UserService) DeleteLocation(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.DeleteUserLocation(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("delete user location: %w", err)
+	}
+	return nil
+}
+
+func buildUserLocationResponse(loc repository.UserLocation) map[string]interface{} {
+	return map[string]interface{}{
+		"shared":     true,
+		"latitude":   loc.Latitude,
+		"longitude":  loc.Longitude,
+		"updated_at": loc.UpdatedAt.Time,
+	}
+}
+
 // UploadAvatar handles avatar upload
 func (s *UserService) UploadAvatar(ctx context.Context, userID uuid.UUID, fileData []byte) (string, error) {
 	// Validate image
@@ -382,6 +537,7 @@ func buildUserProfile(user repository.User, includePrivate bool) map[string]inte
 		profile["profile_visibility"] = user.ProfileVisibility.String
 		profile["allow_messages_from"] = user.AllowMessagesFrom.String
 		profile["show_stats"] = user.ShowStats.Bool
+		profile["share_match_results"] = user.ShareMatchResults
 	} else {
 		// For public profile: mask last name
 		if user.LastName.Valid && len(user.LastName.String) > 0 {
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// maxVerificationDocuments limits how many files one verification request may carry
+	maxVerificationDocuments = 5
+	// verificationDocumentTTL is how long a document link in a response works
+	verificationDocumentTTL = time.Hour
+)
+
+// VerificationService handles the community verification workflow. Documents
+// are identity papers, so they are kept in private storage and only handed
+// out as short-lived presigned links.
+type VerificationService struct {
+	repo          *repository.Queries
+	pool          txStarter
+	storage       *StorageService
+	notifications *NotificationService
+}
+
+// NewVerificationService creates a new VerificationService
+func NewVerificationService(repo *repository.Queries, pool txStarter, storage *StorageService, notifications *NotificationService) *VerificationService {
+	return &VerificationService{
+		repo:          repo,
+		pool:          pool,
+		storage:       storage,
+		notifications: notifications,
+	}
+}
+
+// VerificationDocument is an uploaded file attached to a verification request.
+// Key is the private storage key; requests made before documents went private
+// carry a public URL instead.
+type VerificationDocument struct {
+	Name        string `json:"name"`
+	Key         string `json:"key,omitempty"`
+	URL         string `json:"url,omitempty"`
+	ContentType string `json:"content_type"`
+}
+
+// VerificationFile is a raw file submitted by the owner
+type VerificationFile struct {
+	Name string
+	Data []byte
+}
+
+// Submit uploads the owner's documents and puts the community in the verification queue
+func (s *VerificationService) Submit(ctx context.Context, userID, communityID uuid.UUID, files []VerificationFile, comment string) (map[string]interface{}, error) {
+	if len(files) == 0 {
+		return nil, ErrValidation.WithMessage("At least one document is required")
+	}
+	if len(files) > maxVerificationDocuments {
+		return nil, ErrValidation.WithMessage(fmt.Sprintf("At most %d documents can be attached", maxVerificationDocuments))
+	}
+
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+	if community.VerificationStatus.VerificationStatus == repository.VerificationStatusVerified {
+		return nil, ErrAlreadyExists.WithMessage("The community is already verified")
+	}
+
+	latest, err := s.repo.GetLatestVerificationRequest(ctx, community.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get latest verification request: %w", err)
+	}
+	if err == nil && latest.Status == repository.VerificationStatusPending {
+		return nil, ErrAlreadyExists.WithMessage("A verification request is already pending")
+	}
+
+	contentTypes := make([]string, len(files))
+	for i, f := range files {
+		contentType, err := ValidateDocument(f.Data)
+		if err != nil {
+			return nil, err
+		}
+		contentTypes[i] = contentType
+	}
+
+	if s.storage == nil {
+		return nil, fmt.Errorf("upload verification documents: storage service not configured")
+	}
+
+	// Uploaded files are removed again unless the request is stored
+	documents := make([]VerificationDocument, 0, len(files))
+	committed := false
+	defer func() {
+		if !committed {
+			s.deleteDocuments(ctx, documents)
+		}
+	}()
+
+	for i, f := range files {
+		key := GenerateVerificationDocumentKey(communityID, contentTypes[i])
+		if err := s.storage.UploadPrivate(ctx, key, bytes.NewReader(f.Data), contentTypes[i]); err != nil {
+			return nil, fmt.Errorf("upload verification document: %w", err)
+		}
+		documents = append(documents, VerificationDocument{Name: f.Name, Key: key, ContentType: contentTypes[i]})
+	}
+	rawDocuments, err := json.Marshal(documents)
+	if err != nil {
+		return nil, fmt.Errorf("marshal verification documents: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	request, err := qtx.CreateVerificationRequest(ctx, repository.CreateVerificationRequestParams{
+		CommunityID: community.ID,
+		SubmittedBy: uuidToPgtype(userID),
+		Documents:   rawDocuments,
+		Comment:     pgtype.Text{String: comment, Valid: comment != ""},
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyExists.WithMessage("A verification request is already pending")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create verification request: %w", err)
+	}
+
+	if err := qtx.SetCommunityVerification(ctx, repository.SetCommunityVerificationParams{
+		VerificationStatus:    repository.NullVerificationStatus{VerificationStatus: repository.VerificationStatusPending, Valid: true},
+		VerificationDocuments: rawDocuments,
+		ID:                    community.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("set community verification: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	committed = true
+
+	return s.requestResponse(ctx, request), nil
+}
+
+// deleteDocuments removes uploaded documents from storage, best effort
+func (s *VerificationService) deleteDocuments(ctx context.Context, documents []VerificationDocument) {
+	for _, d := range documents {
+		if err := s.storage.DeletePrivate(ctx, d.Key); err != nil {
+			slog.Warn("failed to delete verification document", "key", d.Key, "error", err)
+		}
+	}
+}
+
+// documentsResponse lists stored documents with presigned download links
+func (s *VerificationService) documentsResponse(ctx context.Context, raw []byte) []VerificationDocument {
+	var documents []VerificationDocument
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &documents); err != nil {
+			slog.Warn("invalid verification documents", "error", err)
+		}
+	}
+
+	result := make([]VerificationDocument, 0, len(documents))
+	for _, d := range documents {
+		if d.Key != "" {
+			if s.storage == nil {
+				continue
+			}
+			url, err := s.storage.PresignGet(ctx, d.Key, verificationDocumentTTL)
+			if err != nil {
+				slog.Warn("failed to sign verification document", "key", d.Key, "error", err)
+				continue
+			}
+			d.URL, d.Key = url, ""
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// GetStatus returns the community's verification status and its latest request
+func (s *VerificationService) GetStatus(ctx context.Context, communityID uuid.UUID) (map[string]interface{}, error) {
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"community_id":        pgtypeUUIDToStringRequired(community.ID),
+		"verification_status": string(community.VerificationStatus.VerificationStatus),
+		"is_verified":         community.VerificationStatus.VerificationStatus == repository.VerificationStatusVerified,
+		"latest_request":      nil,
+	}
+	if community.VerifiedAt.Valid {
+		result["verified_at"] = community.VerifiedAt.Time
+	}
+
+	latest, err := s.repo.GetLatestVerificationRequest(ctx, community.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("get latest verification request: %w", err)
+	}
+	if err == nil {
+		result["latest_request"] = s.requestResponse(ctx, latest)
+	}
+
+	return result, nil
+}
+
+// ListRequests returns the verification queue (pending by default), oldest first
+func (s *VerificationService) ListRequests(ctx context.Context, status string, page, perPage int) ([]map[string]interface{}, *PaginationInfo, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	if status == "" {
+		status = string(repository.VerificationStatusPending)
+	}
+
+	requestStatus := repository.VerificationStatus(status)
+	switch requestStatus {
+	case repository.VerificationStatusPending, repository.VerificationStatusVerified, repository.VerificationStatusRejected:
+	default:
+		return nil, nil, ErrValidation.WithMessage("Invalid status")
+	}
+
+	offset := (page - 1) * perPage
+
+	requests, err := s.repo.ListVerificationRequests(ctx, repository.ListVerificationRequestsParams{
+		Status:       requestStatus,
+		ResultOffset: int32(offset),
+		ResultLimit:  int32(perPage),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list verification requests: %w", err)
+	}
+
+	total, err := s.repo.CountVerificationRequests(ctx, requestStatus)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count verification requests: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(requests))
+	for _, r := range requests {
+		request := map[string]interface{}{
+			"id":        pgtypeUUIDToStringRequired(r.ID),
+			"status":    string(r.Status),
+			"documents": s.documentsResponse(ctx, r.Documents),
+			"comment":   r.Comment.String,
+			"community": map[string]interface{}{
+				"id":             pgtypeUUIDToStringRequired(r.CommunityID),
+				"name":           r.CommunityName,
+				"slug":           r.CommunitySlug.String,
+				"community_type": string(r.CommunityType),
+				"logo_url":       r.LogoUrl.String,
+				"member_count":   r.MemberCount.Int32,
+			},
+			"submitted_by": map[string]interface{}{
+				"id":         pgtypeUUIDToStringRequired(r.SubmittedBy),
+				"first_name": r.SubmitterFirstName.String,
+				"last_name":  r.SubmitterLastName.String,
+			},
+			"created_at": r.CreatedAt.Time,
+		}
+		if r.ReviewNote.Valid {
+			request["review_note"] = r.ReviewNote.String
+		}
+		if r.ReviewedAt.Valid {
+			request["reviewed_at"] = r.ReviewedAt.Time
+		}
+		result = append(result, request)
+	}
+
+	return result, newPaginationInfo(page, perPage, total), nil
+}
+
+// Review verifies or rejects the community's pending request and notifies the submitter
+func (s *VerificationService) Review(ctx context.Context, adminID, communityID uuid.UUID, action, note string) (map[string]interface{}, error) {
+	var status repository.VerificationStatus
+	switch action {
+	case "verify":
+		status = repository.VerificationStatusVerified
+	case "reject":
+		status = repository.VerificationStatusRejected
+		if note == "" {
+			return nil, ErrValidation.WithMessage("A reason is required when rejecting verification")
+		}
+	default:
+		return nil, ErrValidation.WithMessage("Action must be 'verify' or 'reject'")
+	}
+
+	community, err := s.repo.GetCommunityByID(ctx, uuidToPgtype(communityID))
+	if err == pgx.ErrNoRows {
+		return nil, ErrCommunityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.repo.WithTx(tx)
+
+	request, err := qtx.ReviewVerificationRequest(ctx, repository.ReviewVerificationRequestParams{
+		Status:      status,
+		ReviewNote:  pgtype.Text{String: note, Valid: note != ""},
+		ReviewedBy:  uuidToPgtype(adminID),
+		CommunityID: community.ID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, ErrNotFound.WithMessage("The community has no pending verification request")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("review verification request: %w", err)
+	}
+
+	if err := qtx.SetCommunityVerification(ctx, repository.SetCommunityVerificationParams{
+		VerificationStatus:    repository.NullVerificationStatus{VerificationStatus: status, Valid: true},
+		VerificationDocuments: request.Documents,
+		ID:                    community.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("set community verification: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	s.notifyDecision(ctx, community, request)
+
+	return s.requestResponse(ctx, request), nil
+}
+
+// notifyDecision tells the owner who submitted the request about the outcome
+func (s *VerificationService) notifyDecision(ctx context.Context, community repository.Community, request repository.CommunityVerificationRequest) {
+	if s.notifications == nil {
+		return
+	}
+	userID, err := uuid.FromBytes(request.SubmittedBy.Bytes[:])
+	if err != nil {
+		return
+	}
+
+	title := "Сообщество подтверждено"
+	body := fmt.Sprintf("«%s» прошло верификацию.", community.Name)
+	if request.Status == repository.VerificationStatusRejected {
+		title = "Верификация отклонена"
+		body = fmt.Sprintf("«%s»: %s", community.Name, request.ReviewNote.String)
+	}
+
+	_, err = s.notifications.Create(ctx, userID,
+		string(repository.NotificationTypeCommunityVerification),
+		title,
+		body,
+		map[string]any{
+			"community_id": pgtypeUUIDToStringRequired(community.ID),
+			"status":       string(request.Status),
+		},
+	)
+	if err != nil {
+		slog.Warn("failed to create community_verification notification", "user_id", userID, "error", err)
+	}
+}
+
+func (s *VerificationService) requestResponse(ctx context.Context, r repository.CommunityVerificationRequest) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":           pgtypeUUIDToStringRequired(r.ID),
+		"community_id": pgtypeUUIDToStringRequired(r.CommunityID),
+		"submitted_by": pgtypeUUIDToStringRequired(r.SubmittedBy),
+		"documents":    s.documentsResponse(ctx, r.Documents),
+		"comment":      r.Comment.String,
+		"status":       string(r.Status),
+		"created_at":   r.CreatedAt.Time,
+	}
+	if r.ReviewNote.Valid {
+		result["review_note"] = r.ReviewNote.String
+	}
+	if r.ReviewedAt.Valid {
+		result["reviewed_at"] = r.ReviewedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alma-amirseitov/Tennis-App/apps/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fakeS3 is an S3-compatible endpoint that accepts every upload and deletion
+// and records them as "METHOD /bucket/key"
+type fakeS3 struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func newFakeStorage(t *testing.T) (*StorageService, *fakeS3) {
+	t.Helper()
+
+	fake := &fakeS3{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.mu.Lock()
+		fake.requests = append(fake.requests, r.Method+" "+r.URL.Path)
+		fake.mu.Unlock()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	t.Cleanup(server.Close)
+
+	storage, err := NewStorageService(server.URL, "key", "secret", "public", "private", "https://cdn.example.com")
+	if err != nil {
+		t.Fatalf("storage: %v", err)
+	}
+	return storage, fake
+}
+
+func (f *fakeS3) calls(method string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var paths []string
+	for _, r := range f.requests {
+		if path, ok := strings.CutPrefix(r, method+" "); ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+var pdfDocument = VerificationFile{Name: "certificate.pdf", Data: []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n1 0 obj\n<<>>\nendobj\n")}
+
+// fakeVerificationCommunity serves one unverified community
+func fakeVerificationCommunity(db *fakeDB) repository.Community {
+	community := repository.Community{
+		ID:       uuidToPgtype(uuid.New()),
+		Name:     "Теннисный клуб Алматы",
+		IsActive: pgtype.Bool{Bool: true, Valid: true},
+	}
+	db.rows("GetCommunityByID", []any{community})
+	db.rows("GetLatestVerificationRequest")
+	db.rows("SetCommunityVerification", []any{})
+	return community
+}
+
+func TestSubmitVerificationStoresDocumentsPrivately(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	community := fakeVerificationCommunity(db)
+	db.on("CreateVerificationRequest", func(args []any) ([][]any, error) {
+		return [][]any{{repository.CommunityVerificationRequest{
+			ID:          uuidToPgtype(uuid.New()),
+			CommunityID: args[0].(pgtype.UUID),
+			SubmittedBy: args[1].(pgtype.UUID),
+			Documents:   args[2].([]byte),
+			Status:      repository.VerificationStatusPending,
+		}}}, nil
+	})
+	storage, s3 := newFakeStorage(t)
+
+	service := &VerificationService{repo: db.queries(), pool: db, storage: storage}
+	communityID, _ := uuid.FromBytes(community.ID.Bytes[:])
+	result, err := service.Submit(ctx, uuid.New(), communityID, []VerificationFile{pdfDocument}, "")
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if !db.committed() {
+		t.Fatal("submit did not commit")
+	}
+
+	uploads := s3.calls(http.MethodPut)
+	if len(uploads) != 1 || !strings.HasPrefix(uploads[0], "/private/communities/"+communityID.String()+"/verification/") {
+		t.Fatalf("uploads = %v, want one private document", uploads)
+	}
+	if deleted := s3.calls(http.MethodDelete); len(deleted) != 0 {
+		t.Errorf("deleted = %v, want none", deleted)
+	}
+
+	var stored []VerificationDocument
+	if err := json.Unmarshal(db.called("CreateVerificationRequest")[0].Args[2].([]byte), &stored); err != nil {
+		t.Fatalf("stored documents: %v", err)
+	}
+	if len(stored) != 1 || stored[0].URL != "" || "/private/"+stored[0].Key != uploads[0] {
+		t.Errorf("stored documents = %+v, want the private key only", stored)
+	}
+
+	documents := result["documents"].([]VerificationDocument)
+	if len(documents) != 1 || documents[0].Key != "" || !strings.Contains(documents[0].URL, "X-Amz-Signature=") {
+		t.Errorf("response documents = %+v, want a presigned link", documents)
+	}
+}
+
+func TestSubmitVerificationDeletesUploadsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	community := fakeVerificationCommunity(db)
+	db.on("CreateVerificationRequest", func([]any) ([][]any, error) {
+		return nil, errors.New("connection reset")
+	})
+	storage, s3 := newFakeStorage(t)
+
+	service := &VerificationService{repo: db.queries(), pool: db, storage: storage}
+	communityID, _ := uuid.FromBytes(community.ID.Bytes[:])
+	files := []VerificationFile{pdfDocument, pdfDocument}
+	if _, err := service.Submit(ctx, uuid.New(), communityID, files, ""); err == nil {
+		t.Fatal("submit succeeded, want error")
+	}
+	if db.committed() {
+		t.Error("failed submit committed")
+	}
+
+	uploads, deleted := s3.calls(http.MethodPut), s3.calls(http.MethodDelete)
+	if len(uploads) != 2 || strings.Join(deleted, ",") != strings.Join(uploads, ",") {
+		t.Errorf("uploads = %v, deleted = %v, want every upload deleted", uploads, deleted)
+	}
+}
+
+func TestReviewVerification(t *testing.T) {
+	tests := []struct {
+		action string
+		note   string
+		want   repository.VerificationStatus
+	}{
+		{"verify", "", repository.VerificationStatusVerified},
+		{"reject", "Документы нечитаемы", repository.VerificationStatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			ctx := context.Background()
+			db := newFakeDB()
+			community := fakeVerificationCommunity(db)
+			db.on("ReviewVerificationRequest", func(args []any) ([][]any, error) {
+				return [][]any{{repository.CommunityVerificationRequest{
+					ID:          uuidToPgtype(uuid.New()),
+					CommunityID: args[3].(pgtype.UUID),
+					SubmittedBy: uuidToPgtype(uuid.New()),
+					Documents:   []byte(`[{"name":"old.pdf","url":"https://cdn.example.com/old.pdf","content_type":"application/pdf"}]`),
+					Status:      args[0].(repository.VerificationStatus),
+					ReviewNote:  args[1].(pgtype.Text),
+				}}}, nil
+			})
+
+			service := &VerificationService{repo: db.queries(), pool: db}
+			communityID, _ := uuid.FromBytes(community.ID.Bytes[:])
+			result, err := service.Review(ctx, uuid.New(), communityID, tt.action, tt.note)
+			if err != nil {
+				t.Fatalf("review: %v", err)
+			}
+			if !db.committed() {
+				t.Fatal("review did not commit")
+			}
+			if result["status"] != string(tt.want) {
+				t.Errorf("status = %v, want %s", result["status"], tt.want)
+			}
+
+			set := db.called("SetCommunityVerification")
+			if len(set) != 1 || set[0].Args[0].(repository.NullVerificationStatus).VerificationStatus != tt.want {
+				t.Errorf("community updates = %+v, want one to %s", set, tt.want)
+			}
+			documents := result["documents"].([]VerificationDocument)
+			if len(documents) != 1 || documents[0].URL != "https://cdn.example.com/old.pdf" {
+				t.Errorf("documents = %+v, want the legacy link kept", documents)
+			}
+		})
+	}
+}
+
+func TestReviewVerificationRejectNeedsReason(t *testing.T) {
+	db := newFakeDB()
+	service := &VerificationService{repo: db.queries(), pool: db}
+
+	var appErr *AppError
+	if _, err := service.Review(context.Background(), uuid.New(), uuid.New(), "reject", ""); !errors.As(err, &appErr) || appErr.Code != ErrValidation.Code {
+		t.Errorf("error = %v, want validation error", err)
+	}
+	if names := db.names(); len(names) != 0 {
+		t.Errorf("queries = %v, want none", names)
+	}
+}
+
+func TestListCommunitiesBoostsVerified(t *testing.T) {
+	for _, query := range []string{"", "клуб"} {
+		db := newFakeDB()
+		db.rows("ListCommunities")
+		db.rows("CountCommunities", []any{int64(0)})
+
+		service := NewCommunityService(db.queries(), nil, nil)
+		if _, _, err := service.List(context.Background(), ListCommunitiesInput{Query: query}); err != nil {
+			t.Fatalf("list %q: %v", query, err)
+		}
+
+		sql := db.called("ListCommunities")[0].SQL
+		order := strings.TrimSpace(sql[strings.Index(sql, "ORDER BY")+len("ORDER BY"):])
+		if !strings.HasPrefix(order, "verification_status = 'verified' DESC") {
+			t.Errorf("list %q is ordered by %.60q, want verified communities first", query, order)
+		}
+	}
+}
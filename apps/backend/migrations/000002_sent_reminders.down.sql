@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000002_sent_reminders
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_matches_scheduled;
+DROP TABLE IF EXISTS sent_reminders CASCADE;
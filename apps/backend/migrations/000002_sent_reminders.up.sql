@@ -0,0 +1,19 @@
+-- =====================================================
+-- GAME REMINDERS
+-- One row per (user, event/match, reminder window).
+-- The unique key makes reminder delivery idempotent across
+-- restarts and multiple server instances.
+-- =====================================================
+
+CREATE TABLE sent_reminders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL,    -- 'event', 'match'
+    target_id UUID NOT NULL,
+    reminder_type VARCHAR(50) NOT NULL,  -- 'game_reminder_24h', 'game_reminder_1h'
+    sent_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(user_id, target_id, reminder_type)
+);
+
+CREATE INDEX idx_sent_reminders_target ON sent_reminders(target_id, reminder_type);
+CREATE INDEX idx_matches_scheduled ON matches(scheduled_time) WHERE scheduled_time IS NOT NULL;
@@ -0,0 +1,5 @@
+-- =====================================================
+-- Reverse migration: 000003_calendar_tokens
+-- =====================================================
+
+DROP TABLE IF EXISTS calendar_tokens CASCADE;
@@ -0,0 +1,10 @@
+-- =====================================================
+-- CALENDAR FEEDS
+-- Secret per-user token used in ICS subscription URLs.
+-- =====================================================
+
+CREATE TABLE calendar_tokens (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
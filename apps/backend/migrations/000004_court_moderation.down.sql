@@ -0,0 +1,17 @@
+-- =====================================================
+-- Reverse migration: 000004_court_moderation
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_courts_price;
+DROP INDEX IF EXISTS idx_courts_community;
+DROP INDEX IF EXISTS idx_courts_status;
+
+ALTER TABLE courts DROP CONSTRAINT IF EXISTS fk_courts_community;
+
+ALTER TABLE courts
+    DROP COLUMN IF EXISTS moderated_at,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderation_note,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS court_status;
@@ -0,0 +1,21 @@
+-- =====================================================
+-- COURT MODERATION
+-- Courts submitted by community admins are reviewed by a
+-- superadmin before they appear in the public directory.
+-- =====================================================
+
+CREATE TYPE court_status AS ENUM ('pending', 'approved', 'rejected');
+
+ALTER TABLE courts
+    ADD COLUMN status court_status DEFAULT 'approved',
+    ADD COLUMN moderation_note TEXT,
+    ADD COLUMN moderated_by UUID REFERENCES users(id),
+    ADD COLUMN moderated_at TIMESTAMPTZ;
+
+ALTER TABLE courts
+    ADD CONSTRAINT fk_courts_community
+    FOREIGN KEY (community_id) REFERENCES communities(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_courts_status ON courts(status);
+CREATE INDEX idx_courts_community ON courts(community_id);
+CREATE INDEX idx_courts_price ON courts(price_per_hour);
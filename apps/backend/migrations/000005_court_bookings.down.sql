@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000005_court_bookings
+-- =====================================================
+
+DROP TABLE IF EXISTS court_bookings CASCADE;
+DROP TYPE IF EXISTS booking_status;
@@ -0,0 +1,41 @@
+-- =====================================================
+-- COURT BOOKINGS
+-- A booking reserves one numbered court (1..courts.total_courts)
+-- for a time range. Overlapping confirmed bookings of the same
+-- court number are rejected by an exclusion constraint, so two
+-- concurrent requests can never both succeed.
+-- =====================================================
+
+CREATE EXTENSION IF NOT EXISTS "btree_gist";
+
+CREATE TYPE booking_status AS ENUM ('confirmed', 'cancelled');
+
+CREATE TABLE court_bookings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    court_id UUID NOT NULL REFERENCES courts(id) ON DELETE CASCADE,
+    court_number SMALLINT NOT NULL CHECK (court_number >= 1),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    status booking_status NOT NULL DEFAULT 'confirmed',
+    booked_by UUID NOT NULL REFERENCES users(id),
+    event_id UUID REFERENCES events(id) ON DELETE CASCADE,
+    total_price DECIMAL(10, 2),
+    currency VARCHAR(3) DEFAULT 'KZT',
+    split_count SMALLINT NOT NULL DEFAULT 1 CHECK (split_count >= 1),
+    note TEXT,
+    cancelled_by UUID REFERENCES users(id),
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (ends_at > starts_at),
+    CONSTRAINT court_bookings_no_overlap EXCLUDE USING gist (
+        court_id WITH =,
+        court_number WITH =,
+        tstzrange(starts_at, ends_at, '[)') WITH &&
+    ) WHERE (status = 'confirmed')
+);
+
+CREATE INDEX idx_court_bookings_court_time ON court_bookings(court_id, starts_at);
+CREATE INDEX idx_court_bookings_user ON court_bookings(booked_by, starts_at);
+CREATE UNIQUE INDEX idx_court_bookings_event ON court_bookings(event_id)
+    WHERE event_id IS NOT NULL AND status = 'confirmed';
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Reverse migration: 000006_geo_search
+-- =====================================================
+
+DROP TABLE IF EXISTS user_locations CASCADE;
+DROP INDEX IF EXISTS idx_courts_earth;
+
+DROP EXTENSION IF EXISTS "earthdistance";
+DROP EXTENSION IF EXISTS "cube";
@@ -0,0 +1,26 @@
+-- =====================================================
+-- GEO SEARCH
+-- "Near me" search is backed by the earthdistance extension:
+-- GiST indexes on ll_to_earth() let earth_box() prefilter
+-- candidates before the exact earth_distance() check.
+-- =====================================================
+
+CREATE EXTENSION IF NOT EXISTS "cube";
+CREATE EXTENSION IF NOT EXISTS "earthdistance";
+
+CREATE INDEX idx_courts_earth ON courts
+    USING gist (ll_to_earth(latitude::float8, longitude::float8))
+    WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
+
+-- Opt-in approximate home location of a player.
+-- Coordinates are rounded before they are stored and are never
+-- returned to other users; only a rounded distance is.
+CREATE TABLE user_locations (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION NOT NULL CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_locations_earth ON user_locations
+    USING gist (ll_to_earth(latitude, longitude));
@@ -0,0 +1,5 @@
+-- =====================================================
+-- Reverse migration: 000007_court_working_hours
+-- =====================================================
+
+DROP FUNCTION IF EXISTS court_is_open(JSONB, TIMESTAMPTZ);
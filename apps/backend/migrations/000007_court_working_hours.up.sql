@@ -0,0 +1,61 @@
+-- =====================================================
+-- COURT WORKING HOURS
+-- courts.working_hours format (validated by the API on write):
+--   {
+--     "weekly": {"mon": [{"open": "07:00", "close": "23:00"}], ...},
+--     "exceptions": [{"date": "2025-03-22", "closed": true},
+--                    {"date": "2025-03-25", "hours": [{"open": "10:00", "close": "16:00"}]}]
+--   }
+-- Times are local to Asia/Almaty; a missing day is closed.
+-- court_is_open() mirrors internal/pkg/workinghours so that
+-- "open now" filters can be applied before pagination.
+-- =====================================================
+
+CREATE OR REPLACE FUNCTION court_is_open(hours JSONB, at TIMESTAMPTZ)
+RETURNS BOOLEAN
+LANGUAGE plpgsql STABLE AS $$
+DECLARE
+    local_ts TIMESTAMP;
+    local_date TEXT;
+    local_time TEXT;
+    day_key TEXT;
+    exc JSONB;
+    intervals JSONB;
+BEGIN
+    IF hours IS NULL OR jsonb_typeof(hours) <> 'object' THEN
+        RETURN FALSE;
+    END IF;
+
+    local_ts := at AT TIME ZONE 'Asia/Almaty';
+    local_date := to_char(local_ts, 'YYYY-MM-DD');
+    local_time := to_char(local_ts, 'HH24:MI');
+    day_key := (ARRAY['mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun'])[EXTRACT(ISODOW FROM local_ts)::int];
+
+    IF jsonb_typeof(hours->'exceptions') = 'array' THEN
+        SELECT e INTO exc
+        FROM jsonb_array_elements(hours->'exceptions') e
+        WHERE e->>'date' = local_date
+        LIMIT 1;
+    END IF;
+
+    IF exc IS NOT NULL THEN
+        IF COALESCE((exc->>'closed')::boolean, FALSE) THEN
+            RETURN FALSE;
+        END IF;
+        intervals := exc->'hours';
+    ELSE
+        intervals := hours->'weekly'->day_key;
+    END IF;
+
+    IF intervals IS NULL OR jsonb_typeof(intervals) <> 'array' THEN
+        RETURN FALSE;
+    END IF;
+
+    -- "HH:MM" strings compare in time order; close is exclusive
+    RETURN EXISTS (
+        SELECT 1
+        FROM jsonb_array_elements(intervals) i
+        WHERE local_time >= i->>'open' AND local_time < i->>'close'
+    );
+END;
+$$;
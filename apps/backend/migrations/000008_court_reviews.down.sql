@@ -0,0 +1,7 @@
+-- =====================================================
+-- Reverse migration: 000008_court_reviews
+-- =====================================================
+
+DROP TABLE IF EXISTS court_ratings CASCADE;
+DROP TABLE IF EXISTS court_reviews CASCADE;
+DROP TYPE IF EXISTS review_status;
@@ -0,0 +1,45 @@
+-- =====================================================
+-- COURT REVIEWS
+-- Players who played at a court rate it on four criteria
+-- (1-5) and may leave a text review. One review per player
+-- per court; editing replaces the previous scores.
+-- court_ratings keeps per-court averages of published reviews
+-- so listings can show and sort by rating without aggregating.
+-- =====================================================
+
+CREATE TYPE review_status AS ENUM ('published', 'hidden');
+
+CREATE TABLE court_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    court_id UUID NOT NULL REFERENCES courts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    surface_score SMALLINT CHECK (surface_score BETWEEN 1 AND 5),
+    lighting_score SMALLINT CHECK (lighting_score BETWEEN 1 AND 5),
+    changing_rooms_score SMALLINT CHECK (changing_rooms_score BETWEEN 1 AND 5),
+    value_score SMALLINT CHECK (value_score BETWEEN 1 AND 5),
+    body TEXT,
+    status review_status NOT NULL DEFAULT 'published',
+    moderation_note TEXT,
+    moderated_by UUID REFERENCES users(id),
+    moderated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(court_id, user_id),
+    CHECK (COALESCE(surface_score, lighting_score, changing_rooms_score, value_score) IS NOT NULL)
+);
+
+CREATE INDEX idx_court_reviews_court ON court_reviews(court_id, created_at DESC) WHERE status = 'published';
+CREATE INDEX idx_court_reviews_status ON court_reviews(status, created_at DESC);
+
+CREATE TABLE court_ratings (
+    court_id UUID PRIMARY KEY REFERENCES courts(id) ON DELETE CASCADE,
+    review_count INT NOT NULL DEFAULT 0,
+    avg_rating DECIMAL(3, 2),
+    avg_surface DECIMAL(3, 2),
+    avg_lighting DECIMAL(3, 2),
+    avg_changing_rooms DECIMAL(3, 2),
+    avg_value DECIMAL(3, 2),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_court_ratings_rating ON court_ratings(avg_rating DESC NULLS LAST);
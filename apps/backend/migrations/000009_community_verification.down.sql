@@ -0,0 +1,8 @@
+-- =====================================================
+-- Reverse migration: 000009_community_verification
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'community_verification'
+-- stays in notification_type and is simply unused.
+
+DROP TABLE IF EXISTS community_verification_requests CASCADE;
@@ -0,0 +1,28 @@
+-- =====================================================
+-- COMMUNITY VERIFICATION
+-- Owners submit documents for review; a superadmin verifies
+-- or rejects the request with a note. Every submission is
+-- kept for audit, communities.verification_status mirrors
+-- the latest decision.
+-- =====================================================
+
+CREATE TABLE community_verification_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    submitted_by UUID NOT NULL REFERENCES users(id),
+    documents JSONB NOT NULL DEFAULT '[]'::jsonb,  -- [{"url", "content_type", "name"}]
+    comment TEXT,
+    status verification_status NOT NULL DEFAULT 'pending',
+    review_note TEXT,
+    reviewed_by UUID REFERENCES users(id),
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (status IN ('pending', 'verified', 'rejected'))
+);
+
+CREATE INDEX idx_cvr_community ON community_verification_requests(community_id, created_at DESC);
+CREATE INDEX idx_cvr_status ON community_verification_requests(status, created_at);
+CREATE UNIQUE INDEX idx_cvr_one_pending ON community_verification_requests(community_id)
+    WHERE status = 'pending';
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'community_verification';
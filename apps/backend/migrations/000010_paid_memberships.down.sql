@@ -0,0 +1,15 @@
+-- =====================================================
+-- Reverse migration: 000010_paid_memberships
+-- =====================================================
+
+-- PostgreSQL cannot drop enum values; 'expired' (member_status)
+-- and 'membership_dues' (notification_type) stay unused.
+-- Expired members fall back to 'left' so they can re-join.
+UPDATE community_members SET status = 'left' WHERE status = 'expired';
+
+DROP TABLE IF EXISTS membership_dues CASCADE;
+DROP TABLE IF EXISTS membership_plans CASCADE;
+
+DROP TYPE IF EXISTS payment_method;
+DROP TYPE IF EXISTS dues_status;
+DROP TYPE IF EXISTS membership_period;
@@ -0,0 +1,61 @@
+-- =====================================================
+-- PAID COMMUNITY MEMBERSHIPS
+-- Paid communities sell membership plans (monthly or per
+-- season). Every payment is a row in the dues ledger that
+-- covers [period_start, period_end]; a member stays active
+-- while their latest paid period has not ended. Admins record
+-- cash / Kaspi transfers by hand, online payments are settled
+-- by the payment provider callback.
+-- =====================================================
+
+CREATE TYPE membership_period AS ENUM ('monthly', 'seasonal');
+CREATE TYPE dues_status AS ENUM ('pending', 'paid', 'cancelled');
+CREATE TYPE payment_method AS ENUM ('cash', 'kaspi_transfer', 'online');
+
+ALTER TYPE member_status ADD VALUE IF NOT EXISTS 'expired';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'membership_dues';
+
+CREATE TABLE membership_plans (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    period membership_period NOT NULL,
+    duration_months SMALLINT NOT NULL CHECK (duration_months BETWEEN 1 AND 12),
+    price_amount DECIMAL(10,2) NOT NULL CHECK (price_amount >= 0),
+    price_currency VARCHAR(3) NOT NULL DEFAULT 'KZT',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (period <> 'monthly' OR duration_months = 1)
+);
+
+CREATE INDEX idx_membership_plans_community ON membership_plans(community_id, is_active);
+CREATE TRIGGER trg_membership_plans_updated BEFORE UPDATE ON membership_plans
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE membership_dues (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    plan_id UUID REFERENCES membership_plans(id) ON DELETE SET NULL,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'KZT',
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status dues_status NOT NULL DEFAULT 'pending',
+    payment_method payment_method NOT NULL,
+    payment_reference TEXT,                -- Kaspi transfer number, receipt id, ...
+    provider VARCHAR(50),                  -- online payments only
+    provider_payment_id TEXT,
+    recorded_by UUID REFERENCES users(id), -- admin who recorded a manual payment
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (period_end >= period_start),
+    UNIQUE(provider, provider_payment_id)
+);
+
+CREATE INDEX idx_membership_dues_member ON membership_dues(community_id, user_id, period_end DESC);
+CREATE INDEX idx_membership_dues_paid_end ON membership_dues(period_end) WHERE status = 'paid';
+CREATE TRIGGER trg_membership_dues_updated BEFORE UPDATE ON membership_dues
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at();
@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000011_community_invites
+-- =====================================================
+
+DROP TABLE IF EXISTS community_invite_uses CASCADE;
+DROP TABLE IF EXISTS community_invites CASCADE;
@@ -0,0 +1,34 @@
+-- =====================================================
+-- COMMUNITY INVITES
+-- Shareable invite codes (and links built from them) let
+-- people join without going through the review queue.
+-- An invite may expire, be limited to max_uses and carry
+-- the role new members get. Every accepted invite is kept
+-- in community_invite_uses so admins see who joined how.
+-- =====================================================
+
+CREATE TABLE community_invites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    role community_role NOT NULL DEFAULT 'member',
+    max_uses INT CHECK (max_uses > 0),   -- NULL = unlimited
+    use_count INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ,              -- NULL = never
+    created_by UUID NOT NULL REFERENCES users(id),
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (role <> 'owner')
+);
+
+CREATE INDEX idx_community_invites_community ON community_invites(community_id, created_at DESC);
+
+CREATE TABLE community_invite_uses (
+    invite_id UUID NOT NULL REFERENCES community_invites(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (invite_id, user_id)
+);
+
+CREATE INDEX idx_community_invite_uses_member ON community_invite_uses(community_id, user_id);
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Reverse migration: 000012_community_moderation
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'community_moderation'
+-- stays in notification_type and is simply unused.
+
+DROP TABLE IF EXISTS community_moderation_actions CASCADE;
+DROP TYPE IF EXISTS moderation_action;
@@ -0,0 +1,26 @@
+-- =====================================================
+-- COMMUNITY MODERATION
+-- Kicks, bans (temporary or permanent) and unbans issued
+-- by community staff. The table is the member's moderation
+-- history; community_members.status carries the current
+-- state ('banned'). A temporary ban is lifted lazily once
+-- banned_until has passed.
+-- =====================================================
+
+CREATE TYPE moderation_action AS ENUM ('kick', 'ban', 'unban');
+
+CREATE TABLE community_moderation_actions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action moderation_action NOT NULL,
+    reason TEXT,
+    banned_until TIMESTAMPTZ,            -- bans only; NULL = permanent
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (action = 'ban' OR banned_until IS NULL)
+);
+
+CREATE INDEX idx_cma_member ON community_moderation_actions(community_id, user_id, created_at DESC);
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'community_moderation';
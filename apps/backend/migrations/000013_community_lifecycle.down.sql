@@ -0,0 +1,10 @@
+-- =====================================================
+-- Reverse migration: 000013_community_lifecycle
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'community_ownership'
+-- stays in notification_type and is simply unused.
+
+DROP TABLE IF EXISTS community_deactivations CASCADE;
+DROP TABLE IF EXISTS community_ownership_transfers CASCADE;
+DROP TYPE IF EXISTS ownership_transfer_status;
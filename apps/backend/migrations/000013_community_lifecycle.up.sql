@@ -0,0 +1,42 @@
+-- =====================================================
+-- COMMUNITY LIFECYCLE
+-- Ownership transfers: the owner nominates an active member,
+-- who becomes owner only after accepting; the previous owner
+-- stays on as admin. Deactivation: the owner can take a
+-- community offline (communities.is_active = FALSE), which
+-- hides it, freezes its leaderboard and archives its chat.
+-- Only a superadmin can reactivate it. Each deactivation is
+-- kept here together with its reversal.
+-- =====================================================
+
+CREATE TYPE ownership_transfer_status AS ENUM ('pending', 'accepted', 'declined', 'cancelled', 'expired');
+
+CREATE TABLE community_ownership_transfers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status ownership_transfer_status NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (from_user_id <> to_user_id)
+);
+
+-- At most one open transfer per community
+CREATE UNIQUE INDEX idx_cot_pending ON community_ownership_transfers(community_id) WHERE status = 'pending';
+CREATE INDEX idx_cot_to_user ON community_ownership_transfers(to_user_id, created_at DESC);
+
+CREATE TABLE community_deactivations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    deactivated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    reactivated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reactivated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_community_deactivations_open ON community_deactivations(community_id) WHERE reactivated_at IS NULL;
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'community_ownership';
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Reverse migration: 000014_community_exports
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'export_ready'
+-- stays in notification_type and is simply unused.
+
+DROP TABLE IF EXISTS community_exports CASCADE;
+DROP TYPE IF EXISTS export_status;
@@ -0,0 +1,28 @@
+-- =====================================================
+-- COMMUNITY EXPORTS
+-- Spreadsheet exports (CSV/XLSX) of members, matches,
+-- the leaderboard and event attendance. Small exports
+-- stream straight to the admin; large ones run in the
+-- background and are tracked here until the file is
+-- uploaded and the requester is notified.
+-- =====================================================
+
+CREATE TYPE export_status AS ENUM ('pending', 'running', 'completed', 'failed');
+
+CREATE TABLE community_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    dataset VARCHAR(20) NOT NULL,        -- members | matches | leaderboard | attendance
+    format VARCHAR(10) NOT NULL,         -- csv | xlsx
+    status export_status NOT NULL DEFAULT 'pending',
+    row_count INT,
+    file_url TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_community_exports_community ON community_exports(community_id, created_at DESC);
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'export_ready';
@@ -0,0 +1,10 @@
+-- =====================================================
+-- Reverse migration: 000015_community_imports
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'placeholder'
+-- stays in user_status and is simply unused. Unclaimed
+-- placeholder users are kept as they may own matches.
+
+DROP TABLE IF EXISTS community_imports CASCADE;
+DROP TYPE IF EXISTS import_status;
@@ -0,0 +1,34 @@
+-- =====================================================
+-- COMMUNITY IMPORTS
+-- CSV import of members and historical match results
+-- for clubs moving onto the platform. An upload is
+-- parsed into a preview (valid rows plus row-level
+-- errors); committing it creates placeholder users,
+-- memberships and confirmed matches.
+--
+-- Placeholder users have a phone number but have never
+-- logged in; the first OTP login with that phone claims
+-- the account and turns it into an active user.
+-- =====================================================
+
+ALTER TYPE user_status ADD VALUE IF NOT EXISTS 'placeholder';
+
+CREATE TYPE import_status AS ENUM ('preview', 'committed');
+
+CREATE TABLE community_imports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    kind VARCHAR(20) NOT NULL,              -- members | matches
+    status import_status NOT NULL DEFAULT 'preview',
+    total_rows INT NOT NULL,
+    valid_rows JSONB NOT NULL DEFAULT '[]', -- normalised rows that passed validation
+    row_errors JSONB NOT NULL DEFAULT '[]', -- [{row, field, message}]
+    replay_ratings BOOLEAN NOT NULL DEFAULT FALSE,
+    result JSONB,                           -- counts recorded on commit
+    committed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    committed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_community_imports_community ON community_imports(community_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000016_community_announcements
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_notif_announcement;
+DROP TABLE IF EXISTS community_announcements CASCADE;
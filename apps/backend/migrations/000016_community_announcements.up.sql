@@ -0,0 +1,32 @@
+-- =====================================================
+-- COMMUNITY ANNOUNCEMENTS
+-- Admins post announcements to all members or to a
+-- subset filtered by role and NTRP level. Each one
+-- creates a community_news notification per recipient;
+-- the notification data carries the announcement id,
+-- which is how read counts are gathered.
+-- =====================================================
+
+CREATE TABLE community_announcements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+
+    -- Audience filter; NULL means no restriction
+    target_role community_role,
+    min_ntrp DECIMAL(2,1),
+    max_ntrp DECIMAL(2,1),
+
+    -- Delivery counts recorded when the announcement is sent
+    recipient_count INT NOT NULL DEFAULT 0,
+    push_count INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_community_announcements_community ON community_announcements(community_id, created_at DESC);
+
+CREATE INDEX idx_notif_announcement ON notifications((data ->> 'announcement_id'))
+    WHERE type = 'community_news';
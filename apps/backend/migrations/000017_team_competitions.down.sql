@@ -0,0 +1,11 @@
+-- =====================================================
+-- Reverse migration: 000017_team_competitions
+-- =====================================================
+
+-- Rubber matches stay in matches as ordinary matches.
+
+DROP TABLE IF EXISTS team_tie_lineups CASCADE;
+DROP TABLE IF EXISTS team_tie_rubbers CASCADE;
+DROP TABLE IF EXISTS team_ties CASCADE;
+DROP TABLE IF EXISTS team_members CASCADE;
+DROP TABLE IF EXISTS teams CASCADE;
@@ -0,0 +1,66 @@
+-- =====================================================
+-- TEAM COMPETITIONS
+-- Clubs field teams in interclub leagues. A tie is a
+-- club-vs-club fixture made of numbered singles and
+-- doubles rubbers; each team captain submits a lineup
+-- and once both are in, every rubber gets a match of
+-- its own. The tie score is derived from the confirmed
+-- rubber matches.
+-- =====================================================
+
+CREATE TABLE teams (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    captain_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(community_id, name)
+);
+
+CREATE TRIGGER trg_teams_updated BEFORE UPDATE ON teams FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user ON team_members(user_id);
+
+CREATE TABLE team_ties (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE, -- organising league or club
+    event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    home_team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    away_team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    scheduled_time TIMESTAMPTZ,
+    lineups_locked_at TIMESTAMPTZ,  -- set when both lineups are in and rubber matches exist
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (home_team_id != away_team_id)
+);
+
+CREATE INDEX idx_team_ties_community ON team_ties(community_id, scheduled_time DESC);
+CREATE INDEX idx_team_ties_event ON team_ties(event_id);
+
+CREATE TABLE team_tie_rubbers (
+    tie_id UUID NOT NULL REFERENCES team_ties(id) ON DELETE CASCADE,
+    rubber_number INT NOT NULL,
+    composition player_composition NOT NULL,  -- singles | doubles
+    match_id UUID UNIQUE REFERENCES matches(id) ON DELETE SET NULL,
+    PRIMARY KEY (tie_id, rubber_number)
+);
+
+CREATE TABLE team_tie_lineups (
+    tie_id UUID NOT NULL,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    rubber_number INT NOT NULL,
+    player_id UUID NOT NULL REFERENCES users(id),
+    partner_id UUID REFERENCES users(id),
+    submitted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (tie_id, team_id, rubber_number),
+    FOREIGN KEY (tie_id, rubber_number) REFERENCES team_tie_rubbers(tie_id, rubber_number) ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+-- =====================================================
+-- Reverse migration: 000018_community_ladders
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'ladder_challenge'
+-- stays in notification_type. Ladder matches stay in
+-- matches as ordinary matches.
+
+DROP TABLE IF EXISTS ladder_history CASCADE;
+DROP TABLE IF EXISTS ladder_challenges CASCADE;
+DROP TABLE IF EXISTS ladder_positions CASCADE;
+DROP TABLE IF EXISTS community_ladders CASCADE;
+DROP TYPE IF EXISTS ladder_change_reason;
+DROP TYPE IF EXISTS ladder_challenge_status;
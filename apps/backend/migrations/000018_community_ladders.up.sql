@@ -0,0 +1,79 @@
+-- =====================================================
+-- COMMUNITY LADDERS
+-- A challenge ladder runs alongside the ELO ranking. A
+-- player may challenge anyone up to challenge_range
+-- positions above them; the defender has accept_hours to
+-- respond and the match must be played within play_days.
+-- When the challenger wins the confirmed match the two
+-- swap positions. Declining, or letting a challenge
+-- lapse, drops the defender decline_penalty positions.
+-- Every position change is kept in ladder_history.
+-- =====================================================
+
+CREATE TYPE ladder_challenge_status AS ENUM ('pending', 'accepted', 'declined', 'cancelled', 'expired', 'completed');
+CREATE TYPE ladder_change_reason AS ENUM ('joined', 'left', 'challenge_won', 'challenge_lost', 'decline_penalty', 'shifted');
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'ladder_challenge';
+
+CREATE TABLE community_ladders (
+    community_id UUID PRIMARY KEY REFERENCES communities(id) ON DELETE CASCADE,
+    challenge_range INT NOT NULL DEFAULT 3 CHECK (challenge_range > 0),
+    accept_hours INT NOT NULL DEFAULT 72 CHECK (accept_hours > 0),
+    play_days INT NOT NULL DEFAULT 14 CHECK (play_days > 0),
+    decline_penalty INT NOT NULL DEFAULT 1 CHECK (decline_penalty >= 0),  -- positions the defender drops
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_community_ladders_updated BEFORE UPDATE ON community_ladders FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE ladder_positions (
+    community_id UUID NOT NULL REFERENCES community_ladders(community_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INT NOT NULL CHECK (position > 0),
+    joined_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (community_id, user_id),
+    -- Deferred so positions can be swapped and shifted inside one transaction
+    CONSTRAINT uq_ladder_position UNIQUE (community_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+CREATE TABLE ladder_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES community_ladders(community_id) ON DELETE CASCADE,
+    challenger_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    defender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Positions when the challenge was issued
+    challenger_position INT NOT NULL,
+    defender_position INT NOT NULL,
+
+    status ladder_challenge_status NOT NULL DEFAULT 'pending',
+    accept_deadline TIMESTAMPTZ NOT NULL,
+    play_deadline TIMESTAMPTZ,   -- set on accept
+    match_id UUID UNIQUE REFERENCES matches(id) ON DELETE SET NULL,
+    winner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    responded_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    CHECK (challenger_id != defender_id)
+);
+
+CREATE INDEX idx_ladder_challenges_community ON ladder_challenges(community_id, created_at DESC);
+CREATE INDEX idx_ladder_challenges_open ON ladder_challenges(status, accept_deadline, play_deadline)
+    WHERE status IN ('pending', 'accepted');
+
+CREATE TABLE ladder_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    community_id UUID NOT NULL REFERENCES community_ladders(community_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_position INT,   -- NULL when joining
+    new_position INT,   -- NULL when leaving
+    reason ladder_change_reason NOT NULL,
+    challenge_id UUID REFERENCES ladder_challenges(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_ladder_history_community ON ladder_history(community_id, created_at DESC);
+CREATE INDEX idx_ladder_history_user ON ladder_history(community_id, user_id, created_at DESC);
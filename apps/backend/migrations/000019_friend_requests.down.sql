@@ -0,0 +1,18 @@
+-- =====================================================
+-- Reverse migration: 000019_friend_requests
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'friend_request'
+-- and 'friend_accepted' stay in notification_type.
+-- Pending requests are dropped; accepted friendships stay
+-- as rows in both directions.
+
+DELETE FROM friends WHERE status = 'pending';
+
+DROP INDEX IF EXISTS idx_friends_incoming;
+
+ALTER TABLE friends
+    DROP COLUMN IF EXISTS accepted_at,
+    DROP COLUMN IF EXISTS status;
+
+DROP TYPE IF EXISTS friend_status;
@@ -0,0 +1,29 @@
+-- =====================================================
+-- FRIEND REQUESTS
+-- Friendship becomes mutual: a request is a pending
+-- friends row from the requester; accepting it marks the
+-- row accepted and adds the reverse row, so every
+-- friendship is stored in both directions and
+-- CheckFriendship keeps looking at one row.
+-- =====================================================
+
+CREATE TYPE friend_status AS ENUM ('pending', 'accepted');
+
+ALTER TABLE friends
+    ADD COLUMN status friend_status NOT NULL DEFAULT 'accepted',
+    ADD COLUMN accepted_at TIMESTAMPTZ;
+
+-- Existing one-way friends become mutual friendships
+UPDATE friends SET accepted_at = created_at;
+
+INSERT INTO friends (user_id, friend_id, created_at, status, accepted_at)
+SELECT friend_id, user_id, created_at, 'accepted', created_at
+FROM friends
+ON CONFLICT (user_id, friend_id) DO NOTHING;
+
+ALTER TABLE friends ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX idx_friends_incoming ON friends(friend_id, created_at DESC) WHERE status = 'pending';
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'friend_request';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'friend_accepted';
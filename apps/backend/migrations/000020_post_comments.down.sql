@@ -0,0 +1,13 @@
+-- =====================================================
+-- Reverse migration: 000020_post_comments
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'comment_mention'
+-- stays in notification_type.
+
+DROP TRIGGER IF EXISTS trg_post_comment_count ON post_comments;
+DROP FUNCTION IF EXISTS update_post_comment_count();
+
+DROP TABLE IF EXISTS post_comments;
+
+UPDATE posts SET comment_count = 0;
@@ -0,0 +1,42 @@
+-- =====================================================
+-- POST COMMENTS
+-- Comments with one level of replies: a reply's
+-- parent_id always points at a top-level comment.
+-- mentions holds the users tagged in the comment.
+-- posts.comment_count is kept by a trigger so replies
+-- removed through the parent's cascade are counted too.
+-- =====================================================
+
+CREATE TABLE post_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES post_comments(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    mentions UUID[] NOT NULL DEFAULT '{}',
+    edited_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_post_comments_post ON post_comments(post_id, created_at) WHERE parent_id IS NULL;
+CREATE INDEX idx_post_comments_parent ON post_comments(parent_id, created_at);
+
+-- Increments rather than recounts: concurrent inserts serialize on the
+-- post row and each one adds exactly its own comment.
+CREATE OR REPLACE FUNCTION update_post_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts SET comment_count = comment_count + 1 WHERE id = NEW.post_id;
+        RETURN NEW;
+    END IF;
+    UPDATE posts SET comment_count = GREATEST(comment_count - 1, 0) WHERE id = OLD.post_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_post_comment_count
+AFTER INSERT OR DELETE ON post_comments
+FOR EACH ROW EXECUTE FUNCTION update_post_comment_count();
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'comment_mention';
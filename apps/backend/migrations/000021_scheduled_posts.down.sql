@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000021_scheduled_posts
+-- =====================================================
+
+DROP INDEX IF EXISTS idx_posts_community_scheduled;
+DROP INDEX IF EXISTS idx_posts_scheduled;
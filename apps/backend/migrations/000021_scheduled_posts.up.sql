@@ -0,0 +1,10 @@
+-- =====================================================
+-- SCHEDULED POSTS
+-- A scheduled post is stored unpublished with
+-- scheduled_at set; the publisher claims due posts
+-- with FOR UPDATE SKIP LOCKED so that concurrent
+-- server instances never publish one twice.
+-- =====================================================
+
+CREATE INDEX idx_posts_scheduled ON posts(scheduled_at) WHERE is_published = FALSE;
+CREATE INDEX idx_posts_community_scheduled ON posts(author_community_id, scheduled_at) WHERE is_published = FALSE;
@@ -0,0 +1,9 @@
+-- =====================================================
+-- Reverse migration: 000022_match_result_posts
+-- =====================================================
+
+-- Result posts already created stay in the feed.
+
+DROP INDEX IF EXISTS idx_posts_match_result;
+
+ALTER TABLE users DROP COLUMN IF EXISTS share_match_results;
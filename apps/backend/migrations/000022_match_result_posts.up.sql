@@ -0,0 +1,13 @@
+-- =====================================================
+-- MATCH RESULT POSTS
+-- Confirmed matches are posted to the feed for players
+-- who opted in through share_match_results. The partial
+-- unique index keeps a match from being posted twice
+-- by the same author.
+-- =====================================================
+
+ALTER TABLE users ADD COLUMN share_match_results BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX idx_posts_match_result
+    ON posts(match_id, author_type, COALESCE(author_user_id, author_community_id))
+    WHERE is_match_result = TRUE;
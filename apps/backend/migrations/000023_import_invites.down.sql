@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000023_import_invites
+-- =====================================================
+
+-- PostgreSQL cannot drop an enum value; 'community_invite'
+-- stays in notification_type.
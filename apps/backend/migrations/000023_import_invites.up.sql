@@ -0,0 +1,9 @@
+-- =====================================================
+-- IMPORT INVITES
+-- Member imports only enrol placeholder users. Phones
+-- that already belong to a registered account get a
+-- single-use invite and a community_invite notification
+-- instead, so nobody joins a community without agreeing.
+-- =====================================================
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'community_invite';
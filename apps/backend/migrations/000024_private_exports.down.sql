@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000024_private_exports
+-- =====================================================
+
+-- Dropped public links cannot be restored; exports made
+-- since keep their private object keys.
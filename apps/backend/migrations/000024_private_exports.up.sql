@@ -0,0 +1,10 @@
+-- =====================================================
+-- PRIVATE EXPORTS
+-- Background exports now live in the private bucket and
+-- file_url holds the object key, signed on every read.
+-- Links of earlier exports pointed at the public bucket
+-- and are dropped; remove their files under exports/
+-- from the public bucket as well.
+-- =====================================================
+
+UPDATE community_exports SET file_url = NULL WHERE file_url LIKE 'http%';
@@ -0,0 +1,6 @@
+-- =====================================================
+-- Reverse migration: 000025_backfill_profile_complete
+-- =====================================================
+
+-- The backfilled flags cannot be told apart from ones
+-- set by profile setup and are left in place.
@@ -0,0 +1,16 @@
+-- =====================================================
+-- BACKFILL PROFILE COMPLETE
+-- Profile setup used to be refused once a user had a
+-- first and last name; it now goes by
+-- is_profile_complete, since imported placeholder users
+-- arrive with a name but still have to set up their
+-- profile. Registered users who have a name but never
+-- got the flag are marked complete so that profile
+-- setup cannot overwrite their data.
+-- =====================================================
+
+UPDATE users SET is_profile_complete = TRUE
+WHERE is_profile_complete IS DISTINCT FROM TRUE
+  AND first_name IS NOT NULL
+  AND last_name IS NOT NULL
+  AND status <> 'placeholder';